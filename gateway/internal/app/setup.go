@@ -13,6 +13,7 @@ import (
 
 const readTimeout = 15 * time.Second
 const weatherRequestTimeout = 5 * time.Second
+const weatherStreamKeepAlive = 15 * time.Second
 
 func (a *App) setupHTTPServer() *http.Server {
 	router := gin.Default()
@@ -26,6 +27,11 @@ func (a *App) setupHTTPServer() *http.Server {
 		api.GET("/confirm/:token", subh.NewConfirmGETHandler(subService))
 		api.GET("/unsubscribe/:token", subh.NewUnsubscribeGETHandler(subService))
 		api.GET("/weather", weathh.NewWeatherGETHandler(weathService, weatherRequestTimeout))
+		api.GET("/weather/stream", weathh.NewWeatherStreamGETHandler(weathService, weatherStreamKeepAlive))
+		api.GET("/forecast", weathh.NewForecastGETHandler(weathService, weatherRequestTimeout))
+		api.GET("/weather/history", weathh.NewHistoryGETHandler(weathService, weatherRequestTimeout))
+		api.GET("/alerts", weathh.NewAlertsGETHandler(weathService, weatherRequestTimeout))
+		api.GET("/air-quality", weathh.NewAirQualityGETHandler(weathService, weatherRequestTimeout))
 	}
 	httpSrv := http.Server{
 		Addr:        ":" + a.cfg.APIGatewayPort,
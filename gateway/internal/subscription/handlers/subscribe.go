@@ -14,6 +14,7 @@ type subReqBody struct {
 	Email     string `json:"email" binding:"required,email"`
 	Frequency string `json:"frequency" binding:"required,oneof=daily hourly"`
 	City      string `json:"city" binding:"required"`
+	Units     string `json:"units" binding:"omitempty,oneof=metric imperial"`
 }
 
 type subscriber interface {
@@ -32,6 +33,7 @@ func NewSubscribePOSTHandler(service subscriber) gin.HandlerFunc {
 			Email:     body.Email,
 			Frequency: body.Frequency,
 			City:      body.City,
+			Units:     body.Units,
 		}
 
 		err := service.Subscribe(input)
@@ -19,6 +19,7 @@ type SubscriptionInput struct {
 	Email     string
 	Frequency string
 	City      string
+	Units     string
 }
 
 type GRPCAdapter struct {
@@ -37,6 +38,7 @@ func (a *GRPCAdapter) Subscribe(subInput SubscriptionInput) error {
 		Email:     subInput.Email,
 		Frequency: subInput.Frequency,
 		City:      subInput.City,
+		Units:     subInput.Units,
 	}
 
 	_, err := a.client.Subscribe(ctx, &sub)
@@ -40,6 +40,7 @@ func TestGRPCAdapter_Subscribe(t *testing.T) {
 		client := &mockClient{
 			subscribeFn: func(ctx context.Context, in *pb.SubscribeRequest) (*pb.SubscribeResponse, error) {
 				require.Equal(t, "test@example.com", in.Email)
+				require.Equal(t, "imperial", in.Units)
 				return &pb.SubscribeResponse{}, nil
 			},
 		}
@@ -50,6 +51,7 @@ func TestGRPCAdapter_Subscribe(t *testing.T) {
 			Email:     "test@example.com",
 			Frequency: "daily",
 			City:      "Kyiv",
+			Units:     "imperial",
 		})
 
 		// Assert
@@ -1,7 +1,123 @@
 package domain
 
+import "time"
+
+// Units is the measurement system weather values are expressed in.
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"
+	UnitsImperial Units = "imperial"
+)
+
+type Location struct {
+	ID       int64
+	Name     string
+	Country  string
+	Lat      float64
+	Lon      float64
+	Timezone string
+}
+
 type Weather struct {
+	Temperature   float64
+	Humidity      float64
+	Description   string
+	WindSpeed     float64
+	WindDirection float64
+	Pressure      float64
+	Precipitation float64
+	CloudCover    float64
+	Visibility    float64
+	UVIndex       float64
+	ObservedAt    time.Time
+	Source        string
+	Location      Location
+	Units         Units
+	// Age is how long ago the weather service cached the value, zero when it is brand new
+	Age time.Duration
+	// Stale is set when providers failed and an expired cached value was served instead
+	Stale bool
+}
+
+type HourlyForecast struct {
+	Time        time.Time
 	Temperature float64
 	Humidity    float64
 	Description string
 }
+
+type DailyForecast struct {
+	Date           time.Time
+	MinTemperature float64
+	MaxTemperature float64
+	Humidity       float64
+	Description    string
+}
+
+type Forecast struct {
+	Hourly   []HourlyForecast
+	Daily    []DailyForecast
+	Location Location
+	Units    Units
+	Age      time.Duration
+	Stale    bool
+}
+
+// AlertSeverity grades a weather alert, from minor to extreme, unknown when the provider does not grade it.
+type AlertSeverity string
+
+const (
+	AlertSeverityUnknown  AlertSeverity = "unknown"
+	AlertSeverityMinor    AlertSeverity = "minor"
+	AlertSeverityModerate AlertSeverity = "moderate"
+	AlertSeveritySevere   AlertSeverity = "severe"
+	AlertSeverityExtreme  AlertSeverity = "extreme"
+)
+
+type Alert struct {
+	Severity  AlertSeverity
+	Event     string
+	Headline  string
+	Effective time.Time
+	// Expires is zero when the alert has no announced end
+	Expires time.Time
+	Areas   []string
+}
+
+type Alerts struct {
+	Alerts   []Alert
+	Location Location
+	Source   string
+	Age      time.Duration
+	Stale    bool
+}
+
+// AirQuality holds the air pollution near a location, concentrations in µg/m³.
+type AirQuality struct {
+	// US EPA air quality index, 0-500
+	AQI  int
+	PM25 float64
+	PM10 float64
+	O3   float64
+	NO2  float64
+	// grains/m³ by plant, nil when the providers have no pollen counts for the location
+	Pollen     map[string]float64
+	ObservedAt time.Time
+	Location   Location
+	Source     string
+	Age        time.Duration
+	Stale      bool
+}
+
+// Observation is a stored reading of one provider, Samples is how many readings a downsampled one averages.
+type Observation struct {
+	Weather Weather
+	Samples int
+}
+
+type History struct {
+	Observations []Observation
+	Location     Location
+	Units        Units
+}
@@ -6,4 +6,19 @@ var (
 	ErrInternal           = errors.New("internal error")
 	ErrCityNotFound       = errors.New("city not found")
 	ErrWeatherUnavailable = errors.New("weather api is unavailable")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrNotImplemented     = errors.New("not implemented by the weather service")
 )
+
+// CityNotFoundError matches ErrCityNotFound and carries names of known cities close to the requested one.
+type CityNotFoundError struct {
+	Suggestions []string
+}
+
+func (e *CityNotFoundError) Error() string {
+	return ErrCityNotFound.Error()
+}
+
+func (e *CityNotFoundError) Unwrap() error {
+	return ErrCityNotFound
+}
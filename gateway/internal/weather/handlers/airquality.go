@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type airQualityService interface {
+	GetAirQuality(ctx context.Context, city string) (domain.AirQuality, error)
+}
+
+type airQualityResp struct {
+	AQI  int     `json:"aqi"`
+	PM25 float64 `json:"pm2_5"`
+	PM10 float64 `json:"pm10"`
+	O3   float64 `json:"o3"`
+	NO2  float64 `json:"no2"`
+	// omitted when the providers have no pollen counts for the location
+	Pollen     map[string]float64 `json:"pollen,omitempty"`
+	ObservedAt time.Time          `json:"observed_at"`
+	Location   locationResp       `json:"location"`
+	Source     string             `json:"source"`
+	Stale      bool               `json:"stale"`
+	Age        int64              `json:"age"`
+}
+
+// NewAirQualityGETHandler returns the air pollution and pollen counts for ?city=, concentrations in µg/m³.
+func NewAirQualityGETHandler(service airQualityService, requestTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		city := c.Query("city")
+		if city == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		ctxWithTimeout, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+		defer cancel()
+		airQuality, err := service.GetAirQuality(ctxWithTimeout, city)
+		if errors.Is(err, domain.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		if errors.Is(err, domain.ErrCityNotFound) {
+			c.JSON(http.StatusNotFound, cityNotFoundBody(err))
+			return
+		}
+		if errors.Is(err, domain.ErrNotImplemented) {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "no weather provider has air quality data"})
+			return
+		}
+		if errors.Is(err, domain.ErrWeatherUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "sources are unavailable"})
+			return
+		}
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get air quality for given city"})
+			return
+		}
+
+		c.JSON(http.StatusOK, airQualityResp{
+			AQI:        airQuality.AQI,
+			PM25:       airQuality.PM25,
+			PM10:       airQuality.PM10,
+			O3:         airQuality.O3,
+			NO2:        airQuality.NO2,
+			Pollen:     airQuality.Pollen,
+			ObservedAt: airQuality.ObservedAt,
+			Location:   toLocationResp(airQuality.Location),
+			Source:     airQuality.Source,
+			Stale:      airQuality.Stale,
+			Age:        int64(airQuality.Age.Seconds()),
+		})
+	}
+}
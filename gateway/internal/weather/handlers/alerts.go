@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type alertsService interface {
+	GetAlerts(ctx context.Context, city string) (domain.Alerts, error)
+}
+
+type alertResp struct {
+	Severity  domain.AlertSeverity `json:"severity"`
+	Event     string               `json:"event"`
+	Headline  string               `json:"headline"`
+	Effective time.Time            `json:"effective"`
+	// nil when the alert has no announced end
+	Expires *time.Time `json:"expires"`
+	Areas   []string   `json:"areas"`
+}
+
+type alertsResp struct {
+	Alerts   []alertResp  `json:"alerts"`
+	Location locationResp `json:"location"`
+	Source   string       `json:"source"`
+	Stale    bool         `json:"stale"`
+	Age      int64        `json:"age"`
+}
+
+// NewAlertsGETHandler returns the severe weather alerts in effect for ?city=, the earliest first.
+func NewAlertsGETHandler(service alertsService, requestTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		city := c.Query("city")
+		if city == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		ctxWithTimeout, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+		defer cancel()
+		alerts, err := service.GetAlerts(ctxWithTimeout, city)
+		if errors.Is(err, domain.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		if errors.Is(err, domain.ErrCityNotFound) {
+			c.JSON(http.StatusNotFound, cityNotFoundBody(err))
+			return
+		}
+		if errors.Is(err, domain.ErrNotImplemented) {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "no weather provider has alerts"})
+			return
+		}
+		if errors.Is(err, domain.ErrWeatherUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "sources are unavailable"})
+			return
+		}
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get alerts for given city"})
+			return
+		}
+
+		resp := alertsResp{
+			Alerts:   make([]alertResp, 0, len(alerts.Alerts)),
+			Location: toLocationResp(alerts.Location),
+			Source:   alerts.Source,
+			Stale:    alerts.Stale,
+			Age:      int64(alerts.Age.Seconds()),
+		}
+		for _, alert := range alerts.Alerts {
+			a := alertResp{
+				Severity:  alert.Severity,
+				Event:     alert.Event,
+				Headline:  alert.Headline,
+				Effective: alert.Effective,
+				Areas:     alert.Areas,
+			}
+			if !alert.Expires.IsZero() {
+				expires := alert.Expires
+				a.Expires = &expires
+			}
+			resp.Alerts = append(resp.Alerts, a)
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
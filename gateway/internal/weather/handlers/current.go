@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
@@ -12,47 +14,150 @@ import (
 )
 
 type weatherService interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+	GetCurrent(ctx context.Context, city string, units domain.Units) (domain.Weather, error)
+	GetCurrentByCoordinates(ctx context.Context, lat, lon float64, units domain.Units) (domain.Weather, error)
 }
 
 type weatherResp struct {
-	Temperature float64 `json:"temperature"`
-	Humidity    float64 `json:"humidity"`
-	Description string  `json:"description"`
+	Temperature   float64      `json:"temperature"`
+	Humidity      float64      `json:"humidity"`
+	Description   string       `json:"description"`
+	WindSpeed     float64      `json:"wind_speed"`
+	WindDirection float64      `json:"wind_direction"`
+	Pressure      float64      `json:"pressure"`
+	Precipitation float64      `json:"precipitation"`
+	CloudCover    float64      `json:"cloud_cover"`
+	Visibility    float64      `json:"visibility"`
+	UVIndex       float64      `json:"uv_index"`
+	ObservedAt    time.Time    `json:"observed_at"`
+	Source        string       `json:"source"`
+	Location      locationResp `json:"location"`
+	Units         domain.Units `json:"units"`
+	Stale         bool         `json:"stale"`
+	// seconds since the value was cached by the weather service
+	Age int64 `json:"age"`
 }
 
 func NewWeatherGETHandler(service weatherService, requestTimeout time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		city := c.Query("city")
-		if city == "" {
+		query, ok := parseCurrentQuery(c)
+		if !ok {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 			return
 		}
 		ctxWithTimeout, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
 		defer cancel()
-		weatherEnt, err := service.GetCurrent(ctxWithTimeout, city)
-		if errors.Is(err, domain.ErrCityNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "city not found"})
-			return
-		}
-		if errors.Is(err, domain.ErrInternal) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather for given city"})
-			return
-		}
-		if errors.Is(err, domain.ErrWeatherUnavailable) {
-			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "sources are unavailable"})
-			return
+		var weatherEnt domain.Weather
+		var err error
+		if query.coords != nil {
+			weatherEnt, err = service.GetCurrentByCoordinates(ctxWithTimeout, query.coords.lat, query.coords.lon, query.units)
+		} else {
+			weatherEnt, err = service.GetCurrent(ctxWithTimeout, query.city, query.units)
 		}
 		if err != nil {
-			log.Println(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather for given city"})
+			writeCurrentError(c, err)
 			return
 		}
-		weatherResp := weatherResp{
-			Temperature: weatherEnt.Temperature,
-			Humidity:    weatherEnt.Humidity,
-			Description: weatherEnt.Description,
-		}
-		c.JSON(http.StatusOK, weatherResp)
+		c.JSON(http.StatusOK, toWeatherResp(weatherEnt))
+	}
+}
+
+// writeCurrentError maps a failed current weather lookup to an http response.
+func writeCurrentError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrInvalidRequest) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if errors.Is(err, domain.ErrCityNotFound) {
+		c.JSON(http.StatusNotFound, cityNotFoundBody(err))
+		return
+	}
+	if errors.Is(err, domain.ErrInternal) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather for given city"})
+		return
+	}
+	if errors.Is(err, domain.ErrWeatherUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "sources are unavailable"})
+		return
+	}
+	log.Println(err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather for given city"})
+}
+
+func toWeatherResp(weatherEnt domain.Weather) weatherResp {
+	return weatherResp{
+		Temperature:   weatherEnt.Temperature,
+		Humidity:      weatherEnt.Humidity,
+		Description:   weatherEnt.Description,
+		WindSpeed:     weatherEnt.WindSpeed,
+		WindDirection: weatherEnt.WindDirection,
+		Pressure:      weatherEnt.Pressure,
+		Precipitation: weatherEnt.Precipitation,
+		CloudCover:    weatherEnt.CloudCover,
+		Visibility:    weatherEnt.Visibility,
+		UVIndex:       weatherEnt.UVIndex,
+		ObservedAt:    weatherEnt.ObservedAt,
+		Source:        weatherEnt.Source,
+		Location:      toLocationResp(weatherEnt.Location),
+		Units:         weatherEnt.Units,
+		Stale:         weatherEnt.Stale,
+		Age:           int64(weatherEnt.Age.Seconds()),
+	}
+}
+
+type currentQuery struct {
+	city   string
+	coords *coordinates
+	units  domain.Units
+}
+
+// parseCurrentQuery reads city or lat/lon and units, reporting false when the query is invalid.
+func parseCurrentQuery(c *gin.Context) (currentQuery, bool) {
+	city := c.Query("city")
+	coords, coordsErr := parseCoordinates(c)
+	units, unitsOk := parseUnits(c)
+	// exactly one of city or lat/lon must be given
+	if coordsErr != nil || !unitsOk || (city == "" && coords == nil) || (city != "" && coords != nil) {
+		return currentQuery{}, false
+	}
+	return currentQuery{city: city, coords: coords, units: units}, true
+}
+
+type coordinates struct {
+	lat float64
+	lon float64
+}
+
+var errCoordinatesNotFinite = errors.New("coordinates must be finite numbers")
+
+// parseCoordinates reads the optional lat/lon query pair, returning nil when neither is set.
+// ParseFloat accepts "NaN" and "Inf", which are no coordinates.
+func parseCoordinates(c *gin.Context) (*coordinates, error) {
+	rawLat, rawLon := c.Query("lat"), c.Query("lon")
+	if rawLat == "" && rawLon == "" {
+		return nil, nil
+	}
+	lat, err := strconv.ParseFloat(rawLat, 64)
+	if err != nil {
+		return nil, err
+	}
+	lon, err := strconv.ParseFloat(rawLon, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(lat) || math.IsInf(lat, 0) || math.IsNaN(lon) || math.IsInf(lon, 0) {
+		return nil, errCoordinatesNotFinite
+	}
+	return &coordinates{lat: lat, lon: lon}, nil
+}
+
+// parseUnits reads the optional "units" query parameter, defaulting to metric.
+func parseUnits(c *gin.Context) (domain.Units, bool) {
+	units := domain.Units(c.DefaultQuery("units", string(domain.UnitsMetric)))
+	switch units {
+	case domain.UnitsMetric, domain.UnitsImperial:
+		return units, true
+	default:
+		return "", false
 	}
 }
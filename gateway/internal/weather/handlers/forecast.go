@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type forecastService interface {
+	GetForecast(ctx context.Context, city string, days int, units domain.Units) (domain.Forecast, error)
+}
+
+type hourlyForecastResp struct {
+	Time        time.Time `json:"time"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Description string    `json:"description"`
+}
+
+type dailyForecastResp struct {
+	Date           string  `json:"date"`
+	MinTemperature float64 `json:"min_temperature"`
+	MaxTemperature float64 `json:"max_temperature"`
+	Humidity       float64 `json:"humidity"`
+	Description    string  `json:"description"`
+}
+
+type forecastResp struct {
+	Hourly   []hourlyForecastResp `json:"hourly"`
+	Daily    []dailyForecastResp  `json:"daily"`
+	Location locationResp         `json:"location"`
+	Units    domain.Units         `json:"units"`
+	Stale    bool                 `json:"stale"`
+	Age      int64                `json:"age"`
+}
+
+func NewForecastGETHandler(service forecastService, requestTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		city := c.Query("city")
+		units, unitsOk := parseUnits(c)
+		if city == "" || !unitsOk {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		days := 0
+		if rawDays := c.Query("days"); rawDays != "" {
+			var err error
+			days, err = strconv.Atoi(rawDays)
+			if err != nil || days < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+				return
+			}
+		}
+		ctxWithTimeout, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+		defer cancel()
+		forecast, err := service.GetForecast(ctxWithTimeout, city, days, units)
+		if errors.Is(err, domain.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		if errors.Is(err, domain.ErrCityNotFound) {
+			c.JSON(http.StatusNotFound, cityNotFoundBody(err))
+			return
+		}
+		if errors.Is(err, domain.ErrInternal) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get forecast for given city"})
+			return
+		}
+		if errors.Is(err, domain.ErrWeatherUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "sources are unavailable"})
+			return
+		}
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get forecast for given city"})
+			return
+		}
+
+		resp := forecastResp{
+			Hourly:   make([]hourlyForecastResp, 0, len(forecast.Hourly)),
+			Daily:    make([]dailyForecastResp, 0, len(forecast.Daily)),
+			Location: toLocationResp(forecast.Location),
+			Units:    forecast.Units,
+			Stale:    forecast.Stale,
+			Age:      int64(forecast.Age.Seconds()),
+		}
+		for _, hour := range forecast.Hourly {
+			resp.Hourly = append(resp.Hourly, hourlyForecastResp{
+				Time:        hour.Time,
+				Temperature: hour.Temperature,
+				Humidity:    hour.Humidity,
+				Description: hour.Description,
+			})
+		}
+		for _, day := range forecast.Daily {
+			resp.Daily = append(resp.Daily, dailyForecastResp{
+				Date:           day.Date.Format(dateLayout),
+				MinTemperature: day.MinTemperature,
+				MaxTemperature: day.MaxTemperature,
+				Humidity:       day.Humidity,
+				Description:    day.Description,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
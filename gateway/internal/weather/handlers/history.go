@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type historyService interface {
+	GetHistory(ctx context.Context, city string, from, to time.Time, units domain.Units) (domain.History, error)
+}
+
+type observationResp struct {
+	Temperature   float64   `json:"temperature"`
+	Humidity      float64   `json:"humidity"`
+	Description   string    `json:"description"`
+	WindSpeed     float64   `json:"wind_speed"`
+	WindDirection float64   `json:"wind_direction"`
+	Pressure      float64   `json:"pressure"`
+	Precipitation float64   `json:"precipitation"`
+	CloudCover    float64   `json:"cloud_cover"`
+	Visibility    float64   `json:"visibility"`
+	UVIndex       float64   `json:"uv_index"`
+	ObservedAt    time.Time `json:"observed_at"`
+	Source        string    `json:"source"`
+	// 1 for readings as fetched, or how many readings a downsampled one averages
+	Samples int `json:"samples"`
+}
+
+type historyResp struct {
+	Observations []observationResp `json:"observations"`
+	Location     locationResp      `json:"location"`
+	Units        domain.Units      `json:"units"`
+}
+
+// NewHistoryGETHandler returns the stored readings for ?city= observed between ?from= and the optional ?to=,
+// both RFC 3339 times, to defaults to now.
+func NewHistoryGETHandler(service historyService, requestTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		city := c.Query("city")
+		units, unitsOk := parseUnits(c)
+		from, fromErr := time.Parse(time.RFC3339, c.Query("from"))
+		var to time.Time
+		var toErr error
+		if rawTo := c.Query("to"); rawTo != "" {
+			to, toErr = time.Parse(time.RFC3339, rawTo)
+		}
+		if city == "" || !unitsOk || fromErr != nil || toErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		ctxWithTimeout, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+		defer cancel()
+		history, err := service.GetHistory(ctxWithTimeout, city, from, to, units)
+		if errors.Is(err, domain.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		if errors.Is(err, domain.ErrCityNotFound) {
+			c.JSON(http.StatusNotFound, cityNotFoundBody(err))
+			return
+		}
+		if errors.Is(err, domain.ErrNotImplemented) {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "weather history is disabled"})
+			return
+		}
+		if errors.Is(err, domain.ErrWeatherUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "weather history is unavailable"})
+			return
+		}
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather history for given city"})
+			return
+		}
+
+		resp := historyResp{
+			Observations: make([]observationResp, 0, len(history.Observations)),
+			Location:     toLocationResp(history.Location),
+			Units:        history.Units,
+		}
+		for _, o := range history.Observations {
+			resp.Observations = append(resp.Observations, observationResp{
+				Temperature:   o.Weather.Temperature,
+				Humidity:      o.Weather.Humidity,
+				Description:   o.Weather.Description,
+				WindSpeed:     o.Weather.WindSpeed,
+				WindDirection: o.Weather.WindDirection,
+				Pressure:      o.Weather.Pressure,
+				Precipitation: o.Weather.Precipitation,
+				CloudCover:    o.Weather.CloudCover,
+				Visibility:    o.Weather.Visibility,
+				UVIndex:       o.Weather.UVIndex,
+				ObservedAt:    o.Weather.ObservedAt,
+				Source:        o.Weather.Source,
+				Samples:       o.Samples,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type locationResp struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	Country  string  `json:"country"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Timezone string  `json:"timezone"`
+}
+
+func toLocationResp(loc domain.Location) locationResp {
+	return locationResp{
+		ID:       loc.ID,
+		Name:     loc.Name,
+		Country:  loc.Country,
+		Lat:      loc.Lat,
+		Lon:      loc.Lon,
+		Timezone: loc.Timezone,
+	}
+}
+
+// cityNotFoundBody builds the 404 body, adding "did you mean" suggestions when the weather service sent any.
+func cityNotFoundBody(err error) gin.H {
+	body := gin.H{"error": "city not found"}
+	var notFound *domain.CityNotFoundError
+	if errors.As(err, &notFound) && len(notFound.Suggestions) > 0 {
+		body["suggestions"] = notFound.Suggestions
+	}
+	return body
+}
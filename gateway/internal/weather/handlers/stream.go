@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type watchService interface {
+	WatchCurrent(ctx context.Context, city string, units domain.Units) (<-chan domain.Weather, error)
+	WatchCurrentByCoordinates(ctx context.Context, lat, lon float64, units domain.Units) (<-chan domain.Weather, error)
+}
+
+// NewWeatherStreamGETHandler relays live current weather to the browser as Server-Sent Events.
+// Every reading is sent as a "weather" event, and a comment is written every keepAlive
+// so proxies do not close an idle connection. The stream ends when the client goes away.
+func NewWeatherStreamGETHandler(service watchService, keepAlive time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query, ok := parseCurrentQuery(c)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		var updates <-chan domain.Weather
+		var err error
+		if query.coords != nil {
+			updates, err = service.WatchCurrentByCoordinates(ctx, query.coords.lat, query.coords.lon, query.units)
+		} else {
+			updates, err = service.WatchCurrent(ctx, query.city, query.units)
+		}
+		if err != nil {
+			writeCurrentError(c, err)
+			return
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		ticker := time.NewTicker(keepAlive)
+		defer ticker.Stop()
+		// c.Stream flushes after every step, a slow client blocks the write and the
+		// upstream stream is not read meanwhile, so backpressure reaches the weather service
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case weatherEnt, ok := <-updates:
+				if !ok {
+					return false
+				}
+				c.SSEvent("weather", toWeatherResp(weatherEnt))
+				return true
+			case <-ticker.C:
+				_, err := io.WriteString(w, ": keepalive\n\n")
+				return err == nil
+			}
+		})
+	}
+}
@@ -2,13 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/gateway/internal/weather/domain"
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GRPCAdapter struct {
@@ -19,36 +23,304 @@ func NewGRPCAdapter(client pb.WeatherServiceClient) *GRPCAdapter {
 	return &GRPCAdapter{client: client}
 }
 
-func (s *GRPCAdapter) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	req := pb.GetCurrentRequest{
-		City: city,
+func (s *GRPCAdapter) GetCurrent(ctx context.Context, city string, units domain.Units) (domain.Weather, error) {
+	return s.getCurrent(ctx, &pb.GetCurrentRequest{
+		Query: &pb.GetCurrentRequest_City{City: city},
+		Units: domainToPBUnits(units),
+	})
+}
+
+func (s *GRPCAdapter) GetCurrentByCoordinates(ctx context.Context, lat, lon float64, units domain.Units) (domain.Weather, error) {
+	return s.getCurrent(ctx, &pb.GetCurrentRequest{
+		Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: lat, Lon: lon}},
+		Units: domainToPBUnits(units),
+	})
+}
+
+func (s *GRPCAdapter) getCurrent(ctx context.Context, req *pb.GetCurrentRequest) (domain.Weather, error) {
+	resp, err := s.client.GetCurrent(ctx, req)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
+	}
+	return pbToDomainWeather(resp), nil
+}
+
+func (s *GRPCAdapter) WatchCurrent(ctx context.Context, city string, units domain.Units) (<-chan domain.Weather, error) {
+	return s.watchCurrent(ctx, &pb.GetCurrentRequest{
+		Query: &pb.GetCurrentRequest_City{City: city},
+		Units: domainToPBUnits(units),
+	})
+}
+
+func (s *GRPCAdapter) WatchCurrentByCoordinates(ctx context.Context, lat, lon float64, units domain.Units) (<-chan domain.Weather, error) {
+	return s.watchCurrent(ctx, &pb.GetCurrentRequest{
+		Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: lat, Lon: lon}},
+		Units: domainToPBUnits(units),
+	})
+}
+
+// watchCurrent opens the WatchCurrent stream and waits for the first reading, so a bad query
+// or unavailable sources are reported as an error instead of an empty stream. Later readings
+// are delivered on the returned channel, which is closed when ctx is done or the stream ends.
+// The stream is not read while the consumer is busy, so gRPC flow control slows the server down.
+func (s *GRPCAdapter) watchCurrent(ctx context.Context, req *pb.GetCurrentRequest) (<-chan domain.Weather, error) {
+	stream, err := s.client.WatchCurrent(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
 	}
-	resp, err := s.client.GetCurrent(ctx, &req)
+	first, err := stream.Recv()
 	if err != nil {
-		st, ok := status.FromError(err)
-		if !ok {
-			log.Println(fmt.Errorf("grpc adapter: %v", err))
-			return domain.Weather{}, fmt.Errorf("grpc adapter: %w", domain.ErrInternal)
+		return nil, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
+	}
+
+	updates := make(chan domain.Weather, 1)
+	updates <- pbToDomainWeather(first)
+	go func() {
+		defer close(updates)
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return
+			}
+			if err != nil {
+				log.Println(fmt.Errorf("grpc adapter: watch stream: %w", err))
+				return
+			}
+			select {
+			case updates <- pbToDomainWeather(resp):
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
+	return updates, nil
+}
 
-		log.Println(fmt.Errorf("grpc adapter: %s", st.Message()))
-		return domain.Weather{}, fmt.Errorf("grpc adapter: %w", gRPCToDomainError(st.Code()))
-	}
+func pbToDomainWeather(resp *pb.GetCurrentResponse) domain.Weather {
+	weather := resp.GetWeather()
 	return domain.Weather{
-		Humidity:    float64(resp.Humidity),
-		Temperature: float64(resp.Temperature),
-		Description: resp.Description,
+		Temperature:   weather.GetTemperature(),
+		Humidity:      weather.GetHumidity(),
+		Description:   weather.GetDescription(),
+		WindSpeed:     weather.GetWindSpeed(),
+		WindDirection: weather.GetWindDirection(),
+		Pressure:      weather.GetPressure(),
+		Precipitation: weather.GetPrecipitation(),
+		CloudCover:    weather.GetCloudCover(),
+		Visibility:    weather.GetVisibility(),
+		UVIndex:       weather.GetUvIndex(),
+		ObservedAt:    weather.GetObservedAt().AsTime(),
+		Source:        weather.GetSource(),
+		Location:      pbToDomainLocation(resp.GetLocation()),
+		Units:         pbToDomainUnits(resp.GetUnits()),
+		Age:           resp.GetAge().AsDuration(),
+		Stale:         resp.GetStale(),
+	}
+}
+
+func (s *GRPCAdapter) GetForecast(ctx context.Context, city string, days int, units domain.Units) (domain.Forecast, error) {
+	req := pb.GetForecastRequest{
+		City:  city,
+		Days:  int32(days),
+		Units: domainToPBUnits(units),
+	}
+	resp, err := s.client.GetForecast(ctx, &req)
+	if err != nil {
+		return domain.Forecast{}, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
+	}
+
+	forecast := domain.Forecast{
+		Hourly:   make([]domain.HourlyForecast, 0, len(resp.Hourly)),
+		Daily:    make([]domain.DailyForecast, 0, len(resp.Daily)),
+		Location: pbToDomainLocation(resp.Location),
+		Units:    pbToDomainUnits(resp.GetUnits()),
+		Age:      resp.GetAge().AsDuration(),
+		Stale:    resp.GetStale(),
+	}
+	for _, hour := range resp.Hourly {
+		forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+			Time:        hour.GetTime().AsTime(),
+			Temperature: hour.Temperature,
+			Humidity:    hour.Humidity,
+			Description: hour.Description,
+		})
+	}
+	for _, day := range resp.Daily {
+		forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+			Date:           day.GetDate().AsTime(),
+			MinTemperature: day.MinTemperature,
+			MaxTemperature: day.MaxTemperature,
+			Humidity:       day.Humidity,
+			Description:    day.Description,
+		})
+	}
+	return forecast, nil
+}
+
+func (s *GRPCAdapter) GetHistory(ctx context.Context, city string, from, to time.Time, units domain.Units) (domain.History, error) {
+	req := pb.GetHistoryRequest{
+		City:  city,
+		From:  timestamppb.New(from),
+		Units: domainToPBUnits(units),
+	}
+	if !to.IsZero() {
+		req.To = timestamppb.New(to)
+	}
+	resp, err := s.client.GetHistory(ctx, &req)
+	if err != nil {
+		return domain.History{}, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
+	}
+
+	history := domain.History{
+		Observations: make([]domain.Observation, 0, len(resp.Observations)),
+		Location:     pbToDomainLocation(resp.GetLocation()),
+		Units:        pbToDomainUnits(resp.GetUnits()),
+	}
+	for _, o := range resp.Observations {
+		w := o.GetWeather()
+		history.Observations = append(history.Observations, domain.Observation{
+			Weather: domain.Weather{
+				Temperature:   w.GetTemperature(),
+				Humidity:      w.GetHumidity(),
+				Description:   w.GetDescription(),
+				WindSpeed:     w.GetWindSpeed(),
+				WindDirection: w.GetWindDirection(),
+				Pressure:      w.GetPressure(),
+				Precipitation: w.GetPrecipitation(),
+				CloudCover:    w.GetCloudCover(),
+				Visibility:    w.GetVisibility(),
+				UVIndex:       w.GetUvIndex(),
+				ObservedAt:    w.GetObservedAt().AsTime(),
+				Source:        w.GetSource(),
+			},
+			Samples: int(o.GetSamples()),
+		})
+	}
+	return history, nil
+}
+
+func (s *GRPCAdapter) GetAlerts(ctx context.Context, city string) (domain.Alerts, error) {
+	resp, err := s.client.GetAlerts(ctx, &pb.GetAlertsRequest{City: city})
+	if err != nil {
+		return domain.Alerts{}, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
+	}
+
+	alerts := domain.Alerts{
+		Alerts:   make([]domain.Alert, 0, len(resp.Alerts)),
+		Location: pbToDomainLocation(resp.GetLocation()),
+		Source:   resp.GetSource(),
+		Age:      resp.GetAge().AsDuration(),
+		Stale:    resp.GetStale(),
+	}
+	for _, a := range resp.Alerts {
+		alert := domain.Alert{
+			Severity:  pbToDomainAlertSeverity(a.GetSeverity()),
+			Event:     a.GetEvent(),
+			Headline:  a.GetHeadline(),
+			Effective: a.GetEffective().AsTime(),
+			Areas:     a.GetAreas(),
+		}
+		if a.GetExpires() != nil {
+			alert.Expires = a.GetExpires().AsTime()
+		}
+		alerts.Alerts = append(alerts.Alerts, alert)
+	}
+	return alerts, nil
+}
+
+func (s *GRPCAdapter) GetAirQuality(ctx context.Context, city string) (domain.AirQuality, error) {
+	resp, err := s.client.GetAirQuality(ctx, &pb.GetAirQualityRequest{City: city})
+	if err != nil {
+		return domain.AirQuality{}, fmt.Errorf("grpc adapter: %w", statusToDomainError(err))
+	}
+
+	air := resp.GetAirQuality()
+	return domain.AirQuality{
+		AQI:        int(air.GetAqi()),
+		PM25:       air.GetPm2_5(),
+		PM10:       air.GetPm10(),
+		O3:         air.GetO3(),
+		NO2:        air.GetNo2(),
+		Pollen:     air.GetPollen(),
+		ObservedAt: air.GetObservedAt().AsTime(),
+		Location:   pbToDomainLocation(resp.GetLocation()),
+		Source:     resp.GetSource(),
+		Age:        resp.GetAge().AsDuration(),
+		Stale:      resp.GetStale(),
 	}, nil
 }
 
+// statusToDomainError logs the status of a failed call and converts it to a domain error.
+func statusToDomainError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		log.Println(fmt.Errorf("grpc adapter: %v", err))
+		return domain.ErrInternal
+	}
+
+	log.Println(fmt.Errorf("grpc adapter: %s", st.Message()))
+	if st.Code() == codes.NotFound {
+		for _, detail := range st.Details() {
+			if suggestions, ok := detail.(*pb.CitySuggestions); ok {
+				return &domain.CityNotFoundError{Suggestions: suggestions.Suggestions}
+			}
+		}
+	}
+	return gRPCToDomainError(st.Code())
+}
+
+func pbToDomainLocation(loc *pb.Location) domain.Location {
+	return domain.Location{
+		ID:       loc.GetId(),
+		Name:     loc.GetName(),
+		Country:  loc.GetCountry(),
+		Lat:      loc.GetLat(),
+		Lon:      loc.GetLon(),
+		Timezone: loc.GetTimezone(),
+	}
+}
+
+func domainToPBUnits(units domain.Units) pb.Units {
+	if units == domain.UnitsImperial {
+		return pb.Units_UNITS_IMPERIAL
+	}
+	return pb.Units_UNITS_METRIC
+}
+
+func pbToDomainAlertSeverity(severity pb.AlertSeverity) domain.AlertSeverity {
+	switch severity {
+	case pb.AlertSeverity_ALERT_SEVERITY_MINOR:
+		return domain.AlertSeverityMinor
+	case pb.AlertSeverity_ALERT_SEVERITY_MODERATE:
+		return domain.AlertSeverityModerate
+	case pb.AlertSeverity_ALERT_SEVERITY_SEVERE:
+		return domain.AlertSeveritySevere
+	case pb.AlertSeverity_ALERT_SEVERITY_EXTREME:
+		return domain.AlertSeverityExtreme
+	default:
+		return domain.AlertSeverityUnknown
+	}
+}
+
+func pbToDomainUnits(units pb.Units) domain.Units {
+	if units == pb.Units_UNITS_IMPERIAL {
+		return domain.UnitsImperial
+	}
+	return domain.UnitsMetric
+}
+
 func gRPCToDomainError(code codes.Code) error {
 	switch code {
+	case codes.InvalidArgument:
+		return domain.ErrInvalidRequest
 	case codes.NotFound:
 		return domain.ErrCityNotFound
 	case codes.Internal:
 		return domain.ErrInternal
 	case codes.Unavailable:
 		return domain.ErrWeatherUnavailable
+	case codes.Unimplemented:
+		return domain.ErrNotImplemented
 	default:
 		return domain.ErrInternal
 	}
@@ -26,9 +26,19 @@ func (h *WeatherNotifyCommandHandler) Handle(command messaging.WeatherNotifyComm
 		Token: command.Token,
 	}
 	weather := mailers.Weather{
-		Temperature: command.Weather.Temperature,
-		Humidity:    command.Weather.Humidity,
-		Description: command.Weather.Description,
+		Temperature:   command.Weather.Temperature,
+		Humidity:      command.Weather.Humidity,
+		Description:   command.Weather.Description,
+		WindSpeed:     command.Weather.WindSpeed,
+		WindDirection: command.Weather.WindDirection,
+		Pressure:      command.Weather.Pressure,
+		Precipitation: command.Weather.Precipitation,
+		CloudCover:    command.Weather.CloudCover,
+		Visibility:    command.Weather.Visibility,
+		UVIndex:       command.Weather.UVIndex,
+		ObservedAt:    command.Weather.ObservedAt,
+		Source:        command.Weather.Source,
+		Units:         command.Weather.Units,
 	}
 	err := h.Mailer.SendCurrent(sub, weather)
 	if err != nil {
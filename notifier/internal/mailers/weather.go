@@ -5,14 +5,59 @@ import (
 	"fmt"
 	"html/template"
 	"log"
+	"time"
 )
 
 type Weather struct {
-	Temperature float64
-	Humidity    float64
-	Description string
+	Temperature   float64
+	Humidity      float64
+	Description   string
+	WindSpeed     float64
+	WindDirection float64
+	Pressure      float64
+	Precipitation float64
+	CloudCover    float64
+	Visibility    float64
+	UVIndex       float64
+	ObservedAt    time.Time
+	Source        string
+	Units         string // metric or imperial
 }
 
+const unitsImperial = "imperial"
+
+// unitLabels are the unit suffixes and number formats weather.html renders values with.
+type unitLabels struct {
+	Temperature         string
+	WindSpeed           string
+	Pressure            string
+	PressureFormat      string
+	Precipitation       string
+	PrecipitationFormat string
+	Visibility          string
+}
+
+var (
+	metricLabels = unitLabels{
+		Temperature:         "°C",
+		WindSpeed:           "m/s",
+		Pressure:            "hPa",
+		PressureFormat:      "%.0f",
+		Precipitation:       "mm/h",
+		PrecipitationFormat: "%.1f",
+		Visibility:          "km",
+	}
+	imperialLabels = unitLabels{
+		Temperature:         "°F",
+		WindSpeed:           "mph",
+		Pressure:            "inHg",
+		PressureFormat:      "%.2f",
+		Precipitation:       "in/h",
+		PrecipitationFormat: "%.2f",
+		Visibility:          "mi",
+	}
+)
+
 type WeatherEmailNotifier struct {
 	sender emailBackend
 }
@@ -33,12 +78,26 @@ func (m *WeatherEmailNotifier) SendCurrent(subscription Subscription, weather We
 		log.Printf("weather mailer: %v\n", err)
 		return fmt.Errorf("weather mailer: %w", ErrInternal)
 	}
+	labels := metricLabels
+	if weather.Units == unitsImperial {
+		labels = imperialLabels
+	}
 	var body bytes.Buffer
 	err = tmpl.Execute(&body, map[string]any{
-		"Temperature": weather.Temperature,
-		"Humidity":    weather.Humidity,
-		"Condition":   weather.Description,
-		"Link":        unsubscribeURL,
+		"Temperature":   weather.Temperature,
+		"Humidity":      weather.Humidity,
+		"Condition":     weather.Description,
+		"WindSpeed":     weather.WindSpeed,
+		"WindDirection": weather.WindDirection,
+		"Pressure":      weather.Pressure,
+		"Precipitation": weather.Precipitation,
+		"CloudCover":    weather.CloudCover,
+		"Visibility":    weather.Visibility,
+		"UVIndex":       weather.UVIndex,
+		"ObservedAt":    weather.ObservedAt.Format(time.RFC1123),
+		"Source":        weather.Source,
+		"Units":         labels,
+		"Link":          unsubscribeURL,
 	})
 	if err != nil {
 		log.Printf("weather mailer: %v\n", err)
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMiss is returned by Get when the key is not cached.
+	ErrMiss = errors.New("cache miss")
+	// ErrNegative is returned by Get when the key is cached as known to have no value.
+	ErrNegative = errors.New("cache negative entry")
+)
+
+// Cache is a key-value cache of T shared by all backends in this package.
+type Cache[T any] interface {
+	// Get stores the cached value of key in value or returns ErrMiss or ErrNegative.
+	Get(ctx context.Context, key string, value *T) error
+	// Set stores value for the default ttl of the cache.
+	Set(ctx context.Context, key string, value T) error
+	SetWithTTL(ctx context.Context, key string, value T, ttl time.Duration) error
+	// SetNegative remembers that key has no value, for a ttl shorter than the one of values.
+	SetNegative(ctx context.Context, key string) error
+	// MGet returns the cached values of keys, leaving out the ones that are not cached or negative.
+	MGet(ctx context.Context, keys []string) (map[string]T, error)
+	MSet(ctx context.Context, values map[string]T) error
+	Delete(ctx context.Context, keys ...string) error
+	// Purge deletes the keys matching the glob pattern and returns how many there were.
+	Purge(ctx context.Context, pattern string) (int, error)
+}
+
+var (
+	_ Cache[struct{}] = (*RedisCacheClient[struct{}])(nil)
+	_ Cache[struct{}] = (*LRU[struct{}])(nil)
+	_ Cache[struct{}] = (*Tiered[struct{}])(nil)
+)
+
+// GetOrLoad returns the cached value of key, or loads and caches it. A load error that negative
+// accepts is cached as a negative entry, so until it expires GetOrLoad returns ErrNegative without loading.
+// negative may be nil. Errors of the cache itself are treated as misses.
+func GetOrLoad[T any](
+	ctx context.Context, c Cache[T], key string, load func(ctx context.Context) (T, error), negative func(error) bool,
+) (T, error) {
+	var value T
+	err := c.Get(ctx, key, &value)
+	if err == nil || errors.Is(err, ErrNegative) {
+		return value, err
+	}
+
+	value, err = load(ctx)
+	if err != nil {
+		if negative != nil && negative(err) {
+			_ = c.SetNegative(ctx, key)
+		}
+		return value, err
+	}
+	_ = c.Set(ctx, key, value)
+	return value, nil
+}
+
+var patternEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// EscapePattern escapes the glob metacharacters of s, so it matches only itself in Purge.
+func EscapePattern(s string) string {
+	return patternEscaper.Replace(s)
+}
@@ -0,0 +1,78 @@
+//go:build unit
+
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	isNotFound := func(err error) bool { return errors.Is(err, errNotFound) }
+
+	t.Run("LoadsOnceAndCaches", func(t *testing.T) {
+		// Arrange
+		c := cache.NewLRU[int](10, 0)
+		calls := 0
+		load := func(ctx context.Context) (int, error) {
+			calls++
+			return 42, nil
+		}
+
+		// Act
+		first, firstErr := cache.GetOrLoad(ctx, c, "a", load, isNotFound)
+		second, secondErr := cache.GetOrLoad(ctx, c, "a", load, isNotFound)
+
+		// Assert
+		require.NoError(t, firstErr)
+		require.NoError(t, secondErr)
+		assert.Equal(t, 42, first)
+		assert.Equal(t, 42, second)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("CachesNegativeErrors", func(t *testing.T) {
+		// Arrange
+		c := cache.NewLRU[int](10, 0)
+		calls := 0
+		load := func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errNotFound
+		}
+
+		// Act
+		_, firstErr := cache.GetOrLoad(ctx, c, "atlantis", load, isNotFound)
+		_, secondErr := cache.GetOrLoad(ctx, c, "atlantis", load, isNotFound)
+
+		// Assert
+		require.ErrorIs(t, firstErr, errNotFound)
+		require.ErrorIs(t, secondErr, cache.ErrNegative)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("DoesNotCacheOtherErrors", func(t *testing.T) {
+		// Arrange
+		c := cache.NewLRU[int](10, 0)
+		calls := 0
+		load := func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errRemoteDown
+		}
+
+		// Act
+		_, _ = cache.GetOrLoad(ctx, c, "a", load, isNotFound)
+		_, err := cache.GetOrLoad(ctx, c, "a", load, isNotFound)
+
+		// Assert
+		require.ErrorIs(t, err, errRemoteDown)
+		assert.Equal(t, 2, calls)
+	})
+}
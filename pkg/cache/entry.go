@@ -0,0 +1,37 @@
+package cache
+
+import "time"
+
+// Entry wraps a cached value with its freshness deadlines. Until SoftExpiresAt the value is fresh,
+// until HardExpiresAt it may still be served while a newer one is loaded, and after that it is only
+// good as a last resort. The entry itself is kept in the cache for longer than HardExpiresAt.
+type Entry[T any] struct {
+	Value         T         `json:"value"`
+	StoredAt      time.Time `json:"stored_at"`
+	SoftExpiresAt time.Time `json:"soft_expires_at"`
+	HardExpiresAt time.Time `json:"hard_expires_at"`
+}
+
+func NewEntry[T any](value T, now time.Time, softTTL, hardTTL time.Duration) Entry[T] {
+	return Entry[T]{
+		Value:         value,
+		StoredAt:      now,
+		SoftExpiresAt: now.Add(softTTL),
+		HardExpiresAt: now.Add(hardTTL),
+	}
+}
+
+// Fresh reports whether the value can be served as is.
+func (e Entry[T]) Fresh(now time.Time) bool {
+	return now.Before(e.SoftExpiresAt)
+}
+
+// Revalidatable reports whether the value can be served while a newer one is loaded in the background.
+func (e Entry[T]) Revalidatable(now time.Time) bool {
+	return now.Before(e.HardExpiresAt)
+}
+
+// Age is how long ago the value was stored.
+func (e Entry[T]) Age(now time.Time) time.Duration {
+	return now.Sub(e.StoredAt)
+}
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"path"
+	"sync"
+	"time"
+)
+
+type lruItem[T any] struct {
+	key       string
+	value     T
+	negative  bool
+	expiresAt time.Time
+}
+
+// LRU is an in-process cache that keeps at most capacity values and evicts the least recently used one
+// to make room. Values expire after the ttl, zero keeps them until they are evicted. Negative entries
+// use the same ttl. Values are stored as is, so callers must not modify what they get back.
+type LRU[T any] struct {
+	capacity int
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	// front is the most recently used
+	order *list.List
+}
+
+func NewLRU[T any](capacity int, ttl time.Duration) *LRU[T] {
+	return &LRU[T]{
+		capacity: max(capacity, 1),
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *LRU[T]) Get(ctx context.Context, key string, value *T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.lookup(key, time.Now())
+	if !ok {
+		return ErrMiss
+	}
+	if item.negative {
+		return ErrNegative
+	}
+	*value = item.value
+	return nil
+}
+
+func (c *LRU[T]) Set(ctx context.Context, key string, value T) error {
+	return c.SetWithTTL(ctx, key, value, c.ttl)
+}
+
+func (c *LRU[T]) SetWithTTL(ctx context.Context, key string, value T, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(&lruItem[T]{key: key, value: value}, ttl)
+	return nil
+}
+
+func (c *LRU[T]) SetNegative(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(&lruItem[T]{key: key, negative: true}, c.ttl)
+	return nil
+}
+
+func (c *LRU[T]) MSet(ctx context.Context, values map[string]T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, value := range values {
+		c.store(&lruItem[T]{key: key, value: value}, c.ttl)
+	}
+	return nil
+}
+
+func (c *LRU[T]) MGet(ctx context.Context, keys []string) (map[string]T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	found := make(map[string]T, len(keys))
+	for _, key := range keys {
+		if item, ok := c.lookup(key, now); ok && !item.negative {
+			found[key] = item.value
+		}
+	}
+	return found, nil
+}
+
+func (c *LRU[T]) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if elem, ok := c.items[key]; ok {
+			c.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (c *LRU[T]) Purge(ctx context.Context, pattern string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	purged := 0
+	for key, elem := range c.items {
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return purged, err
+		}
+		if matched {
+			c.remove(elem)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// Len returns the number of values held, including expired ones not yet dropped.
+func (c *LRU[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// lookup returns the live item of key and marks it as recently used, dropping it if it expired.
+func (c *LRU[T]) lookup(key string, now time.Time) (*lruItem[T], bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := elem.Value.(*lruItem[T])
+	if !item.expiresAt.IsZero() && !now.Before(item.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return item, true
+}
+
+func (c *LRU[T]) store(item *lruItem[T], ttl time.Duration) {
+	if ttl > 0 {
+		item.expiresAt = time.Now().Add(ttl)
+	}
+	if elem, ok := c.items[item.key]; ok {
+		elem.Value = item
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[item.key] = c.order.PushFront(item)
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRU[T]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*lruItem[T]).key)
+}
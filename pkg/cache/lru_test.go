@@ -0,0 +1,111 @@
+//go:build unit
+
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		// Arrange
+		lru := cache.NewLRU[int](2, 0)
+		require.NoError(t, lru.Set(ctx, "a", 1))
+		require.NoError(t, lru.Set(ctx, "b", 2))
+		var value int
+		require.NoError(t, lru.Get(ctx, "a", &value))
+
+		// Act
+		require.NoError(t, lru.Set(ctx, "c", 3))
+
+		// Assert
+		assert.ErrorIs(t, lru.Get(ctx, "b", &value), cache.ErrMiss)
+		require.NoError(t, lru.Get(ctx, "a", &value))
+		assert.Equal(t, 1, value)
+		require.NoError(t, lru.Get(ctx, "c", &value))
+		assert.Equal(t, 3, value)
+		assert.Equal(t, 2, lru.Len())
+	})
+
+	t.Run("Expires", func(t *testing.T) {
+		// Arrange
+		lru := cache.NewLRU[int](2, 20*time.Millisecond)
+		require.NoError(t, lru.Set(ctx, "a", 1))
+
+		// Act
+		time.Sleep(40 * time.Millisecond)
+
+		// Assert
+		var value int
+		assert.ErrorIs(t, lru.Get(ctx, "a", &value), cache.ErrMiss)
+		assert.Equal(t, 0, lru.Len(), "expired value should be dropped")
+	})
+
+	t.Run("MGetAndDelete", func(t *testing.T) {
+		// Arrange
+		lru := cache.NewLRU[int](10, 0)
+		require.NoError(t, lru.Set(ctx, "a", 1))
+		require.NoError(t, lru.Set(ctx, "b", 2))
+
+		// Act
+		require.NoError(t, lru.Delete(ctx, "b", "missing"))
+		found, err := lru.MGet(ctx, []string{"a", "b", "c"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1}, found)
+	})
+
+	t.Run("NegativeEntries", func(t *testing.T) {
+		// Arrange
+		lru := cache.NewLRU[int](10, 0)
+		require.NoError(t, lru.Set(ctx, "a", 1))
+
+		// Act
+		require.NoError(t, lru.SetNegative(ctx, "a"))
+
+		// Assert
+		var value int
+		require.ErrorIs(t, lru.Get(ctx, "a", &value), cache.ErrNegative)
+		found, err := lru.MGet(ctx, []string{"a"})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("PerKeyTTL", func(t *testing.T) {
+		// Arrange
+		lru := cache.NewLRU[int](10, time.Hour)
+
+		// Act
+		require.NoError(t, lru.SetWithTTL(ctx, "short", 1, 20*time.Millisecond))
+		require.NoError(t, lru.Set(ctx, "long", 2))
+		time.Sleep(40 * time.Millisecond)
+
+		// Assert
+		var value int
+		assert.ErrorIs(t, lru.Get(ctx, "short", &value), cache.ErrMiss)
+		assert.NoError(t, lru.Get(ctx, "long", &value))
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		// Arrange
+		lru := cache.NewLRU[int](10, 0)
+		require.NoError(t, lru.MSet(ctx, map[string]int{"geo:1": 1, "forecast:geo:1:3": 2, "geo:2": 3}))
+
+		// Act
+		purged, err := lru.Purge(ctx, cache.EscapePattern("geo:1"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		assert.Equal(t, 2, lru.Len())
+	})
+}
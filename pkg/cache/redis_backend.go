@@ -3,35 +3,181 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// stored for negative entries, it is not valid JSON so it can't be mistaken for a value
+	negativeMarker = "!negative"
+
+	defaultNegativeTTL = time.Minute
+	// keys fetched per SCAN call while purging
+	purgeScanCount = 500
+)
+
+// RedisOption configures a RedisCacheClient.
+type RedisOption func(*redisOptions)
+
+type redisOptions struct {
+	prefix      string
+	negativeTTL time.Duration
+}
+
+// WithNamespace prefixes every key with namespace and the schema version of the cached values,
+// e.g. "weather:current:v2:". Bumping the version makes values stored in an old format unreachable,
+// they expire on their own.
+func WithNamespace(namespace string, version int) RedisOption {
+	return func(o *redisOptions) {
+		o.prefix = fmt.Sprintf("%s:v%d:", namespace, version)
+	}
+}
+
+// WithNegativeTTL sets how long negative entries are kept, one minute by default.
+func WithNegativeTTL(ttl time.Duration) RedisOption {
+	return func(o *redisOptions) {
+		o.negativeTTL = ttl
+	}
+}
+
 type RedisCacheClient[T any] struct {
 	client *redis.Client
 	ttl    time.Duration
+	opts   redisOptions
 }
 
-func NewRedisCacheClient[T any](client *redis.Client, ttl time.Duration) *RedisCacheClient[T] {
+// NewRedisCacheClient creates a client that stores values as JSON and keeps them for ttl, zero keeps them forever.
+func NewRedisCacheClient[T any](client *redis.Client, ttl time.Duration, opts ...RedisOption) *RedisCacheClient[T] {
+	o := redisOptions{negativeTTL: defaultNegativeTTL}
+	for _, opt := range opts {
+		opt(&o)
+	}
 	return &RedisCacheClient[T]{
 		client: client,
 		ttl:    ttl,
+		opts:   o,
 	}
 }
 
 func (r *RedisCacheClient[T]) Set(ctx context.Context, key string, value T) error {
+	return r.SetWithTTL(ctx, key, value, r.ttl)
+}
+
+func (r *RedisCacheClient[T]) SetWithTTL(ctx context.Context, key string, value T, ttl time.Duration) error {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, data, r.ttl).Err()
+	return r.client.Set(ctx, r.key(key), data, ttl).Err()
+}
+
+func (r *RedisCacheClient[T]) SetNegative(ctx context.Context, key string) error {
+	return r.client.Set(ctx, r.key(key), negativeMarker, r.opts.negativeTTL).Err()
 }
 
 func (r *RedisCacheClient[T]) Get(ctx context.Context, key string, value *T) error {
-	data, err := r.client.Get(ctx, key).Bytes()
+	data, err := r.client.Get(ctx, r.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrMiss
+	}
 	if err != nil {
 		return err
 	}
+	if string(data) == negativeMarker {
+		return ErrNegative
+	}
 	return json.Unmarshal(data, value)
 }
+
+// MGet fetches all keys in one round trip and returns the values that were found.
+// Missing keys, negative entries and values that fail to decode are left out of the result.
+func (r *RedisCacheClient[T]) MGet(ctx context.Context, keys []string) (map[string]T, error) {
+	found := make(map[string]T, len(keys))
+	if len(keys) == 0 {
+		return found, nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.key(key)
+	}
+	raw, err := r.client.MGet(ctx, prefixed...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range raw {
+		data, ok := item.(string)
+		if !ok || data == negativeMarker {
+			continue
+		}
+		var value T
+		if err := json.Unmarshal([]byte(data), &value); err != nil {
+			continue
+		}
+		found[keys[i]] = value
+	}
+	return found, nil
+}
+
+// MSet stores all values in one round trip, each with the default ttl.
+func (r *RedisCacheClient[T]) MSet(ctx context.Context, values map[string]T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	pipe := r.client.Pipeline()
+	for key, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, r.key(key), data, r.ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisCacheClient[T]) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.key(key)
+	}
+	return r.client.Del(ctx, prefixed...).Err()
+}
+
+// Purge deletes the keys of the namespace matching the glob pattern and returns how many there were.
+// "*" empties the whole namespace. Keys are found with SCAN, so Redis is not blocked meanwhile.
+func (r *RedisCacheClient[T]) Purge(ctx context.Context, pattern string) (int, error) {
+	purged := 0
+	iter := r.client.Scan(ctx, 0, r.key(pattern), purgeScanCount).Iterator()
+	batch := make([]string, 0, purgeScanCount)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) < purgeScanCount {
+			continue
+		}
+		if err := r.client.Unlink(ctx, batch...).Err(); err != nil {
+			return purged, err
+		}
+		purged += len(batch)
+		batch = batch[:0]
+	}
+	if err := iter.Err(); err != nil {
+		return purged, err
+	}
+	if len(batch) > 0 {
+		if err := r.client.Unlink(ctx, batch...).Err(); err != nil {
+			return purged, err
+		}
+		purged += len(batch)
+	}
+	return purged, nil
+}
+
+func (r *RedisCacheClient[T]) key(key string) string {
+	return r.opts.prefix + key
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const originIDBytes = 8
+
+// Invalidation lists the keys, and glob patterns of keys, whose local copies are outdated.
+// Settings carries other state every replica keeps its own copy of, by name, with its new value.
+type Invalidation struct {
+	Keys     []string          `json:"keys,omitempty"`
+	Patterns []string          `json:"patterns,omitempty"`
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+type invalidationMessage struct {
+	Origin string `json:"origin"`
+	Invalidation
+}
+
+// RedisInvalidator spreads cache invalidations between replicas over a Redis pub/sub channel.
+// Every instance tags what it publishes, so it does not act on its own invalidations.
+type RedisInvalidator struct {
+	client  *redis.Client
+	channel string
+	origin  string
+
+	mu       sync.RWMutex
+	handlers []func(inv Invalidation)
+}
+
+func NewRedisInvalidator(client *redis.Client, channel string) (*RedisInvalidator, error) {
+	originBytes := make([]byte, originIDBytes)
+	if _, err := rand.Read(originBytes); err != nil {
+		return nil, err
+	}
+	return &RedisInvalidator{
+		client:  client,
+		channel: channel,
+		origin:  hex.EncodeToString(originBytes),
+	}, nil
+}
+
+// Publish tells the other replicas that the keys of inv changed.
+func (i *RedisInvalidator) Publish(ctx context.Context, inv Invalidation) error {
+	data, err := json.Marshal(invalidationMessage{Origin: i.origin, Invalidation: inv})
+	if err != nil {
+		return err
+	}
+	return i.client.Publish(ctx, i.channel, data).Err()
+}
+
+// OnInvalidate registers handler to be called with what other replicas invalidate.
+func (i *RedisInvalidator) OnInvalidate(handler func(inv Invalidation)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, handler)
+}
+
+// Listen delivers invalidations of other replicas to the handlers until ctx is done.
+// Messages published while the connection is down are lost, so local copies must expire on their own.
+func (i *RedisInvalidator) Listen(ctx context.Context) {
+	sub := i.client.Subscribe(ctx, i.channel)
+	defer func() {
+		if err := sub.Close(); err != nil {
+			log.Println("cache: close invalidation subscription:", err)
+		}
+	}()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var inv invalidationMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+				log.Println("cache: decode invalidation:", err)
+				continue
+			}
+			if inv.Origin == i.origin {
+				continue
+			}
+			i.dispatch(inv.Invalidation)
+		}
+	}
+}
+
+func (i *RedisInvalidator) dispatch(inv Invalidation) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for _, handler := range i.handlers {
+		handler(inv)
+	}
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const lockTokenBytes = 16
+
+// releaseScript deletes the lock only while it still holds our token,
+// so an expired lock that another instance took over is left alone.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLocker hands out short-lived locks shared by every instance using the same Redis.
+type RedisLocker struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisLocker(client *redis.Client, ttl time.Duration) *RedisLocker {
+	return &RedisLocker{client: client, ttl: ttl}
+}
+
+// TryLock takes the lock for key without waiting. The lock expires after the ttl
+// even if unlock is never called, so a crashed holder can't keep it forever.
+func (l *RedisLocker) TryLock(ctx context.Context, key string) (unlock func(ctx context.Context) error, acquired bool, err error) {
+	tokenBytes := make([]byte, lockTokenBytes)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, false, err
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	acquired, err = l.client.SetNX(ctx, key, token, l.ttl).Result()
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+	unlock = func(ctx context.Context) error {
+		return releaseScript.Run(ctx, l.client, []string{key}, token).Err()
+	}
+	return unlock, true, nil
+}
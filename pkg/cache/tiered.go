@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	TierLocal  = "local"
+	TierRemote = "remote"
+)
+
+type tierMetrics interface {
+	CacheTierHit(tier string)
+	CacheTierMiss(tier string)
+}
+
+type invalidator interface {
+	Publish(ctx context.Context, inv Invalidation) error
+	OnInvalidate(handler func(inv Invalidation))
+}
+
+// Tiered puts a local cache, usually an LRU, in front of a remote one shared by all replicas.
+// Reads try the local tier first and copy remote hits into it. Writes go to both tiers and tell
+// the other replicas to drop their local copies, so they read the new value from the remote tier.
+// While the remote tier is down, values already in the local tier are still served.
+// Negative entries are kept only in the remote tier, so they are never outdated in a replica.
+type Tiered[T any] struct {
+	local       Cache[T]
+	remote      Cache[T]
+	invalidator invalidator
+	metrics     tierMetrics
+}
+
+func NewTiered[T any](local, remote Cache[T], invalidator invalidator, metrics tierMetrics) *Tiered[T] {
+	t := &Tiered[T]{local: local, remote: remote, invalidator: invalidator, metrics: metrics}
+	invalidator.OnInvalidate(t.dropLocal)
+	return t
+}
+
+func (t *Tiered[T]) Get(ctx context.Context, key string, value *T) error {
+	if err := t.local.Get(ctx, key, value); err == nil {
+		t.metrics.CacheTierHit(TierLocal)
+		return nil
+	}
+	t.metrics.CacheTierMiss(TierLocal)
+
+	if err := t.remote.Get(ctx, key, value); err != nil {
+		t.metrics.CacheTierMiss(TierRemote)
+		return err
+	}
+	t.metrics.CacheTierHit(TierRemote)
+	if err := t.local.Set(ctx, key, *value); err != nil {
+		log.Println("cache: fill local tier:", err)
+	}
+	return nil
+}
+
+// Set stores value in both tiers. It is kept in the local tier even if the remote tier fails.
+func (t *Tiered[T]) Set(ctx context.Context, key string, value T) error {
+	if err := t.local.Set(ctx, key, value); err != nil {
+		log.Println("cache: set local tier:", err)
+	}
+	if err := t.remote.Set(ctx, key, value); err != nil {
+		return err
+	}
+	return t.publish(ctx, Invalidation{Keys: []string{key}})
+}
+
+// SetWithTTL stores value in the remote tier for ttl. The local tier keeps it for its own ttl.
+func (t *Tiered[T]) SetWithTTL(ctx context.Context, key string, value T, ttl time.Duration) error {
+	if err := t.local.Set(ctx, key, value); err != nil {
+		log.Println("cache: set local tier:", err)
+	}
+	if err := t.remote.SetWithTTL(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	return t.publish(ctx, Invalidation{Keys: []string{key}})
+}
+
+func (t *Tiered[T]) SetNegative(ctx context.Context, key string) error {
+	if err := t.local.Delete(ctx, key); err != nil {
+		log.Println("cache: delete local tier:", err)
+	}
+	if err := t.remote.SetNegative(ctx, key); err != nil {
+		return err
+	}
+	return t.publish(ctx, Invalidation{Keys: []string{key}})
+}
+
+func (t *Tiered[T]) MGet(ctx context.Context, keys []string) (map[string]T, error) {
+	found, err := t.local.MGet(ctx, keys)
+	if err != nil {
+		log.Println("cache: mget local tier:", err)
+		found = make(map[string]T, len(keys))
+	}
+	missing := make([]string, 0, len(keys)-len(found))
+	for _, key := range keys {
+		if _, ok := found[key]; ok {
+			t.metrics.CacheTierHit(TierLocal)
+			continue
+		}
+		t.metrics.CacheTierMiss(TierLocal)
+		missing = append(missing, key)
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	remote, err := t.remote.MGet(ctx, missing)
+	if err != nil {
+		for range missing {
+			t.metrics.CacheTierMiss(TierRemote)
+		}
+		return found, err
+	}
+	for _, key := range missing {
+		value, ok := remote[key]
+		if !ok {
+			t.metrics.CacheTierMiss(TierRemote)
+			continue
+		}
+		t.metrics.CacheTierHit(TierRemote)
+		found[key] = value
+		if err := t.local.Set(ctx, key, value); err != nil {
+			log.Println("cache: fill local tier:", err)
+		}
+	}
+	return found, nil
+}
+
+func (t *Tiered[T]) MSet(ctx context.Context, values map[string]T) error {
+	if err := t.local.MSet(ctx, values); err != nil {
+		log.Println("cache: mset local tier:", err)
+	}
+	if err := t.remote.MSet(ctx, values); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return t.publish(ctx, Invalidation{Keys: keys})
+}
+
+func (t *Tiered[T]) Delete(ctx context.Context, keys ...string) error {
+	if err := t.local.Delete(ctx, keys...); err != nil {
+		log.Println("cache: delete local tier:", err)
+	}
+	if err := t.remote.Delete(ctx, keys...); err != nil {
+		return err
+	}
+	return t.publish(ctx, Invalidation{Keys: keys})
+}
+
+// Purge deletes the matching keys from both tiers and every replica and returns how many the remote tier had.
+func (t *Tiered[T]) Purge(ctx context.Context, pattern string) (int, error) {
+	if _, err := t.local.Purge(ctx, pattern); err != nil {
+		log.Println("cache: purge local tier:", err)
+	}
+	purged, err := t.remote.Purge(ctx, pattern)
+	if err != nil {
+		return purged, err
+	}
+	return purged, t.publish(ctx, Invalidation{Patterns: []string{pattern}})
+}
+
+func (t *Tiered[T]) publish(ctx context.Context, inv Invalidation) error {
+	if err := t.invalidator.Publish(ctx, inv); err != nil {
+		return fmt.Errorf("cache: publish invalidation: %w", err)
+	}
+	return nil
+}
+
+// dropLocal removes from the local tier what another replica invalidated.
+func (t *Tiered[T]) dropLocal(inv Invalidation) {
+	ctx := context.Background()
+	if err := t.local.Delete(ctx, inv.Keys...); err != nil {
+		log.Println("cache: invalidate local tier:", err)
+	}
+	for _, pattern := range inv.Patterns {
+		if _, err := t.local.Purge(ctx, pattern); err != nil {
+			log.Println("cache: invalidate local tier:", err)
+		}
+	}
+}
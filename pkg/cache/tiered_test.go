@@ -0,0 +1,180 @@
+//go:build unit
+
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRemoteDown = errors.New("remote is down")
+
+// memBus delivers invalidations to every other replica attached to it.
+type memBus struct {
+	mu       sync.Mutex
+	replicas []*memInvalidator
+}
+
+type memInvalidator struct {
+	bus      *memBus
+	handlers []func(inv cache.Invalidation)
+}
+
+func (b *memBus) attach() *memInvalidator {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	inv := &memInvalidator{bus: b}
+	b.replicas = append(b.replicas, inv)
+	return inv
+}
+
+func (i *memInvalidator) Publish(ctx context.Context, inv cache.Invalidation) error {
+	i.bus.mu.Lock()
+	defer i.bus.mu.Unlock()
+	for _, other := range i.bus.replicas {
+		if other == i {
+			continue
+		}
+		for _, handler := range other.handlers {
+			handler(inv)
+		}
+	}
+	return nil
+}
+
+func (i *memInvalidator) OnInvalidate(handler func(inv cache.Invalidation)) {
+	i.handlers = append(i.handlers, handler)
+}
+
+// flakyCache is a remote tier that can be taken down.
+type flakyCache struct {
+	*cache.LRU[int]
+	down bool
+}
+
+func (c *flakyCache) Get(ctx context.Context, key string, value *int) error {
+	if c.down {
+		return errRemoteDown
+	}
+	return c.LRU.Get(ctx, key, value)
+}
+
+func (c *flakyCache) MGet(ctx context.Context, keys []string) (map[string]int, error) {
+	if c.down {
+		return nil, errRemoteDown
+	}
+	return c.LRU.MGet(ctx, keys)
+}
+
+type mockTierMetrics struct {
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newMockTierMetrics() *mockTierMetrics {
+	return &mockTierMetrics{hits: make(map[string]int), misses: make(map[string]int)}
+}
+
+func (m *mockTierMetrics) CacheTierHit(tier string)  { m.hits[tier]++ }
+func (m *mockTierMetrics) CacheTierMiss(tier string) { m.misses[tier]++ }
+
+func TestTiered(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FillsLocalTierFromRemote", func(t *testing.T) {
+		// Arrange
+		remote := &flakyCache{LRU: cache.NewLRU[int](10, 0)}
+		require.NoError(t, remote.Set(ctx, "a", 1))
+		metrics := newMockTierMetrics()
+		tiered := cache.NewTiered[int](cache.NewLRU[int](10, 0), remote, (&memBus{}).attach(), metrics)
+
+		// Act
+		var first, second int
+		require.NoError(t, tiered.Get(ctx, "a", &first))
+		require.NoError(t, tiered.Get(ctx, "a", &second))
+
+		// Assert
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 1, second)
+		assert.Equal(t, 1, metrics.hits[cache.TierRemote])
+		assert.Equal(t, 1, metrics.hits[cache.TierLocal])
+		assert.Equal(t, 1, metrics.misses[cache.TierLocal])
+	})
+
+	t.Run("ServesLocalTierWhileRemoteIsDown", func(t *testing.T) {
+		// Arrange
+		remote := &flakyCache{LRU: cache.NewLRU[int](10, 0)}
+		tiered := cache.NewTiered[int](cache.NewLRU[int](10, 0), remote, (&memBus{}).attach(), newMockTierMetrics())
+		require.NoError(t, tiered.Set(ctx, "a", 1))
+		remote.down = true
+
+		// Act
+		var value int
+		err := tiered.Get(ctx, "a", &value)
+		found, mgetErr := tiered.MGet(ctx, []string{"a", "b"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+		require.ErrorIs(t, mgetErr, errRemoteDown)
+		assert.Equal(t, map[string]int{"a": 1}, found)
+	})
+
+	t.Run("InvalidatesOtherReplicas", func(t *testing.T) {
+		// Arrange
+		bus := &memBus{}
+		remote := &flakyCache{LRU: cache.NewLRU[int](10, 0)}
+		first := cache.NewTiered[int](cache.NewLRU[int](10, 0), remote, bus.attach(), newMockTierMetrics())
+		second := cache.NewTiered[int](cache.NewLRU[int](10, 0), remote, bus.attach(), newMockTierMetrics())
+		require.NoError(t, first.Set(ctx, "a", 1))
+		var value int
+		require.NoError(t, second.Get(ctx, "a", &value))
+
+		// Act
+		require.NoError(t, first.Set(ctx, "a", 2))
+
+		// Assert
+		require.NoError(t, second.Get(ctx, "a", &value))
+		assert.Equal(t, 2, value, "second replica should drop its local copy and read the new value")
+	})
+
+	t.Run("PurgesOtherReplicas", func(t *testing.T) {
+		// Arrange
+		bus := &memBus{}
+		remote := &flakyCache{LRU: cache.NewLRU[int](10, 0)}
+		first := cache.NewTiered[int](cache.NewLRU[int](10, 0), remote, bus.attach(), newMockTierMetrics())
+		secondLocal := cache.NewLRU[int](10, 0)
+		second := cache.NewTiered[int](secondLocal, remote, bus.attach(), newMockTierMetrics())
+		require.NoError(t, second.MSet(ctx, map[string]int{"forecast:kyiv:1": 1, "forecast:kyiv:3": 3, "forecast:lviv:1": 5}))
+
+		// Act
+		purged, err := first.Purge(ctx, "forecast:kyiv:*")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+		assert.Equal(t, 1, secondLocal.Len(), "second replica should drop the purged keys only")
+	})
+
+	t.Run("KeepsNegativeEntriesRemote", func(t *testing.T) {
+		// Arrange
+		local := cache.NewLRU[int](10, 0)
+		remote := &flakyCache{LRU: cache.NewLRU[int](10, 0)}
+		tiered := cache.NewTiered[int](local, remote, (&memBus{}).attach(), newMockTierMetrics())
+		require.NoError(t, tiered.Set(ctx, "atlantis", 1))
+
+		// Act
+		require.NoError(t, tiered.SetNegative(ctx, "atlantis"))
+
+		// Assert
+		var value int
+		require.ErrorIs(t, tiered.Get(ctx, "atlantis", &value), cache.ErrNegative)
+		assert.Equal(t, 0, local.Len())
+	})
+}
@@ -14,6 +14,55 @@ const (
 	Open                  // All requests are blocked for a timeout duration.
 )
 
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// StateChangeFunc is called after the breaker moved from one state to another.
+type StateChangeFunc func(from, to State)
+
+// Option configures optional behaviour of a CircuitBreaker.
+type Option func(cb *CircuitBreaker)
+
+// WithFailureRate makes the breaker also trip when at least rate (0 to 1) of the calls in window failed.
+// The rate is only checked once the window holds minCalls calls, so a few failures on low traffic don't trip it.
+func WithFailureRate(rate float64, minCalls int, window Window) Option {
+	return func(cb *CircuitBreaker) {
+		cb.failureRate = rate
+		cb.minCalls = minCalls
+		if window.Size > 0 {
+			cb.window = newCountWindow(window.Size)
+		} else {
+			cb.window = newTimeWindow(window.Length)
+		}
+	}
+}
+
+// WithHalfOpenProbes limits how many calls may run at once while HalfOpen, so a recovering
+// resource is not hit by all waiting callers together. Zero means no limit.
+func WithHalfOpenProbes(n int) Option {
+	return func(cb *CircuitBreaker) {
+		cb.maxProbes = n
+	}
+}
+
+// WithSlowCallThreshold makes successful calls reported with SuccessAfter count as failures
+// when they took threshold or longer.
+func WithSlowCallThreshold(threshold time.Duration) Option {
+	return func(cb *CircuitBreaker) {
+		cb.slowCall = threshold
+	}
+}
+
 // CircuitBreaker is a simple thread-safe implementation of the Circuit Breaker pattern.
 // It prevents system overload by blocking calls to a failing resource for a specified duration.
 type CircuitBreaker struct {
@@ -22,19 +71,32 @@ type CircuitBreaker struct {
 
 	state             State
 	timeout           time.Duration // Duration the circuit remains open before attempting recovery.
-	maxFails          int           // Number of consecutive failures allowed before opening the circuit.
+	maxFails          int           // Number of consecutive failures allowed before opening the circuit, 0 disables it.
 	lastFailTime      time.Time     // Timestamp of the most recent failure.
 	openedUntil       time.Time     // If open, the circuit remains blocked until this time.
 	failCount         int           // Consecutive failure counter.
 	recoverCount      int           // Number of successful calls during HalfOpen state.
 	attemptsToRecover int           // Required successes in HalfOpen to transition back to Closed.
+
+	failureRate float64       // Share of failed calls in the window that opens the circuit, 0 disables it.
+	minCalls    int           // Calls the window must hold before the failure rate is checked.
+	window      slidingWindow // Outcomes of the recent calls while Closed.
+	maxProbes   int           // Calls allowed at once during HalfOpen state, 0 means no limit.
+	probes      int           // Calls running during HalfOpen state.
+	slowCall    time.Duration // Latency from which a successful call counts as failed, 0 disables it.
+	hooks       []StateChangeFunc
+	changes     []stateChange // Transitions not yet passed to hooks.
+}
+
+type stateChange struct {
+	from, to State
 }
 
 // NewCircuitBreaker initializes a new CircuitBreaker with specified timeout, failure threshold,
 // and the number of successful attempts required to recover.
-func NewCircuitBreaker(timeout time.Duration, maxFails int, attemptsToRecover int) *CircuitBreaker {
+func NewCircuitBreaker(timeout time.Duration, maxFails int, attemptsToRecover int, opts ...Option) *CircuitBreaker {
 	now := time.Now()
-	return &CircuitBreaker{
+	breaker := &CircuitBreaker{
 		state:             Closed,
 		timeout:           timeout,
 		maxFails:          maxFails,
@@ -45,6 +107,18 @@ func NewCircuitBreaker(timeout time.Duration, maxFails int, attemptsToRecover in
 		recoverCount:      0,
 		Now:               time.Now,
 	}
+	for _, opt := range opts {
+		opt(breaker)
+	}
+	return breaker
+}
+
+// OnStateChange registers fn to be called on every state transition. Hooks run in the goroutine
+// that caused the transition, after the breaker is unlocked, so they may call the breaker.
+func (cb *CircuitBreaker) OnStateChange(fn StateChangeFunc) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.hooks = append(cb.hooks, fn)
 }
 
 // defineStatus evaluates and updates the internal state of the circuit breaker.
@@ -62,16 +136,17 @@ func (cb *CircuitBreaker) defineStatus() {
 			cb.failCount = 0
 		}
 
-		// Transition to Open if failure threshold is exceeded.
-		if cb.failCount >= cb.maxFails {
+		// Transition to Open if failure threshold or failure rate is exceeded.
+		if (cb.maxFails > 0 && cb.failCount >= cb.maxFails) || cb.rateExceeded(now) {
 			cb.failCount = 0
-			cb.state = Open
+			cb.lastFailTime = now
+			cb.setState(Open)
 		}
 
 	case HalfOpen:
 		// If enough successful attempts in HalfOpen, restore to Closed.
-		if cb.recoverCount == cb.attemptsToRecover {
-			cb.state = Closed
+		if cb.recoverCount >= cb.attemptsToRecover {
+			cb.setState(Closed)
 			cb.recoverCount = 0
 			cb.failCount = 0
 		}
@@ -79,27 +154,72 @@ func (cb *CircuitBreaker) defineStatus() {
 	case Open:
 		// If timeout has expired, allow test calls by entering HalfOpen.
 		if now.After(cb.openedUntil) {
-			cb.state = HalfOpen
+			cb.setState(HalfOpen)
 			cb.recoverCount = 0
 			cb.failCount = 0
 		}
 	}
 }
 
+// rateExceeded reports whether the failure rate over the window is at or over the threshold.
+func (cb *CircuitBreaker) rateExceeded(now time.Time) bool {
+	if cb.window == nil || cb.failureRate <= 0 {
+		return false
+	}
+	calls, failures := cb.window.counts(now)
+	return calls > 0 && calls >= cb.minCalls && float64(failures)/float64(calls) >= cb.failureRate
+}
+
+// setState moves the breaker to state and queues the transition for the hooks.
+func (cb *CircuitBreaker) setState(state State) {
+	if state == cb.state {
+		return
+	}
+	cb.changes = append(cb.changes, stateChange{from: cb.state, to: state})
+	cb.state = state
+	cb.probes = 0
+	if cb.window != nil {
+		cb.window.reset()
+	}
+}
+
+// unlock releases the breaker and then runs the hooks for the transitions made while it was held.
+func (cb *CircuitBreaker) unlock() {
+	changes, hooks := cb.changes, cb.hooks
+	cb.changes = nil
+	cb.mu.Unlock()
+	for _, change := range changes {
+		for _, hook := range hooks {
+			hook(change.from, change.to)
+		}
+	}
+}
+
 // Allowed returns true if the circuit is not in the Open state.
-// This method should be called before executing any protected logic.
+// This method should be called before executing any protected logic. With a HalfOpen probe limit,
+// the outcome of every allowed call must be reported with Success, SuccessAfter, Fail or Ignore.
 func (cb *CircuitBreaker) Allowed() bool {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	defer cb.unlock()
 	cb.defineStatus()
-	return cb.state != Open
+
+	switch cb.state {
+	case Open:
+		return false
+	case HalfOpen:
+		if cb.maxProbes > 0 && cb.probes >= cb.maxProbes {
+			return false
+		}
+		cb.probes++
+	}
+	return true
 }
 
 // Fail reports a failed operation to the circuit breaker.
 // It triggers state transitions and timeout timers as needed.
 func (cb *CircuitBreaker) Fail() {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	defer cb.unlock()
 	cb.defineStatus()
 
 	now := cb.Now()
@@ -108,30 +228,90 @@ func (cb *CircuitBreaker) Fail() {
 	case Closed:
 		cb.lastFailTime = now
 		cb.failCount++
+		if cb.window != nil {
+			cb.window.add(now, true)
+		}
 
 	case HalfOpen:
 		// Any failure during recovery puts the breaker back to Open immediately.
 		cb.lastFailTime = now
-		cb.state = Open
+		cb.setState(Open)
 	}
+	cb.defineStatus()
 }
 
 // Success reports a successful operation to the circuit breaker.
-// It is only relevant during the HalfOpen state, where it counts toward recovery.
+// During the HalfOpen state it counts toward recovery.
 func (cb *CircuitBreaker) Success() {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	defer cb.unlock()
 	cb.defineStatus()
 
-	if cb.state == HalfOpen {
+	switch cb.state {
+	case Closed:
+		if cb.window != nil {
+			cb.window.add(cb.Now(), false)
+		}
+	case HalfOpen:
 		cb.recoverCount++
+		cb.releaseProbe()
+	}
+	cb.defineStatus()
+}
+
+// SuccessAfter reports a successful operation that took latency. Calls at or over
+// the slow call threshold are reported as failures.
+func (cb *CircuitBreaker) SuccessAfter(latency time.Duration) {
+	if cb.slowCall > 0 && latency >= cb.slowCall {
+		cb.Fail()
+		return
+	}
+	cb.Success()
+}
+
+// Ignore reports an allowed operation whose outcome says nothing about the health of the resource,
+// so it is neither a failure nor a success. It only frees the HalfOpen probe the operation held.
+func (cb *CircuitBreaker) Ignore() {
+	cb.mu.Lock()
+	defer cb.unlock()
+	cb.defineStatus()
+	if cb.state == HalfOpen {
+		cb.releaseProbe()
+	}
+}
+
+func (cb *CircuitBreaker) releaseProbe() {
+	if cb.probes > 0 {
+		cb.probes--
+	}
+}
+
+// ForceOpen opens the circuit now, as if the failure threshold was reached.
+// It tests recovery after the timeout as usual.
+func (cb *CircuitBreaker) ForceOpen() {
+	cb.mu.Lock()
+	defer cb.unlock()
+	cb.failCount = 0
+	cb.lastFailTime = cb.Now()
+	cb.setState(Open)
+}
+
+// ForceClose closes the circuit now and forgets the failures seen so far.
+func (cb *CircuitBreaker) ForceClose() {
+	cb.mu.Lock()
+	defer cb.unlock()
+	cb.failCount = 0
+	cb.recoverCount = 0
+	cb.setState(Closed)
+	if cb.window != nil {
+		cb.window.reset()
 	}
 }
 
 // State returns the current state of the circuit breaker.
 func (cb *CircuitBreaker) State() State {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	defer cb.unlock()
 	cb.defineStatus()
 	return cb.state
 }
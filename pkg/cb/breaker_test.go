@@ -129,3 +129,152 @@ func TestCircuitBreaker(main *testing.T) {
 		assert.False(t, breaker.Allowed())
 	})
 }
+
+func TestCircuitBreakerOptions(main *testing.T) {
+	main.Run("OpenOnFailureRate", func(t *testing.T) {
+		// Arrange
+		breaker := cb.NewCircuitBreaker(time.Minute, 0, 1, cb.WithFailureRate(0.5, 4, cb.Window{Size: 4}))
+
+		// Act
+		breaker.Success()
+		breaker.Fail()
+		breaker.Success()
+		require.Equal(t, cb.Closed, breaker.State(), "below the minimum number of calls")
+		breaker.Fail()
+
+		// Assert
+		require.Equal(t, cb.Open, breaker.State())
+	})
+
+	main.Run("CountWindowForgetsOldCalls", func(t *testing.T) {
+		// Arrange
+		breaker := cb.NewCircuitBreaker(time.Minute, 0, 1, cb.WithFailureRate(0.5, 4, cb.Window{Size: 4}))
+
+		// Act
+		breaker.Fail()
+		breaker.Success()
+		breaker.Success()
+		breaker.Success()
+		breaker.Fail()
+
+		// Assert
+		require.Equal(t, cb.Closed, breaker.State(), "the first failure fell out of the window")
+	})
+
+	main.Run("TimeWindowForgetsOldCalls", func(t *testing.T) {
+		// Arrange
+		currentTime := time.Now()
+		breaker := cb.NewCircuitBreaker(time.Minute, 0, 1, cb.WithFailureRate(0.5, 2, cb.Window{Length: time.Minute}))
+		breaker.Now = func() time.Time {
+			return currentTime
+		}
+
+		// Act
+		breaker.Fail()
+		currentTime = currentTime.Add(2 * time.Minute)
+		breaker.Success()
+		breaker.Success()
+		breaker.Fail()
+
+		// Assert
+		require.Equal(t, cb.Closed, breaker.State())
+	})
+
+	main.Run("LimitsHalfOpenProbes", func(t *testing.T) {
+		// Arrange
+		currentTime := time.Now()
+		breaker := cb.NewCircuitBreaker(time.Minute, 1, 2, cb.WithHalfOpenProbes(1))
+		breaker.Now = func() time.Time {
+			return currentTime
+		}
+		breaker.Fail()
+		currentTime = currentTime.Add(2 * time.Minute)
+
+		// Act
+		first := breaker.Allowed()
+		second := breaker.Allowed()
+		breaker.Ignore()
+		third := breaker.Allowed()
+		breaker.Success()
+		fourth := breaker.Allowed()
+		breaker.Success()
+
+		// Assert
+		assert.True(t, first)
+		assert.False(t, second, "only one probe may run at once")
+		assert.True(t, third)
+		assert.True(t, fourth)
+		assert.Equal(t, cb.Closed, breaker.State())
+	})
+
+	main.Run("SlowCallsCountAsFailures", func(t *testing.T) {
+		// Arrange
+		breaker := cb.NewCircuitBreaker(time.Minute, 2, 1, cb.WithSlowCallThreshold(time.Second))
+
+		// Act
+		breaker.SuccessAfter(100 * time.Millisecond)
+		breaker.SuccessAfter(2 * time.Second)
+		breaker.SuccessAfter(3 * time.Second)
+
+		// Assert
+		require.Equal(t, cb.Open, breaker.State())
+	})
+
+	main.Run("NotifiesStateChanges", func(t *testing.T) {
+		// Arrange
+		currentTime := time.Now()
+		breaker := cb.NewCircuitBreaker(time.Minute, 1, 1)
+		breaker.Now = func() time.Time {
+			return currentTime
+		}
+		var changes []string
+		breaker.OnStateChange(func(from, to cb.State) {
+			changes = append(changes, from.String()+"->"+to.String())
+			breaker.State()
+		})
+
+		// Act
+		breaker.Fail()
+		currentTime = currentTime.Add(2 * time.Minute)
+		breaker.Allowed()
+		breaker.Success()
+
+		// Assert
+		assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, changes)
+	})
+}
+
+func TestCircuitBreakerForce(main *testing.T) {
+	main.Run("ForceOpen", func(t *testing.T) {
+		// Arrange
+		currentTime := time.Now()
+		breaker := cb.NewCircuitBreaker(time.Minute, 10, 1)
+		breaker.Now = func() time.Time {
+			return currentTime
+		}
+
+		// Act
+		breaker.ForceOpen()
+		open := breaker.State()
+		currentTime = currentTime.Add(2 * time.Minute)
+
+		// Assert
+		require.Equal(t, cb.Open, open)
+		require.Equal(t, cb.HalfOpen, breaker.State(), "forced open breaker recovers as usual")
+	})
+
+	main.Run("ForceClose", func(t *testing.T) {
+		// Arrange
+		breaker := cb.NewCircuitBreaker(time.Minute, 2, 1)
+		breaker.Fail()
+		breaker.Fail()
+		require.Equal(t, cb.Open, breaker.State())
+
+		// Act
+		breaker.ForceClose()
+		breaker.Fail()
+
+		// Assert
+		require.Equal(t, cb.Closed, breaker.State(), "failures before closing are forgotten")
+	})
+}
@@ -0,0 +1,100 @@
+package cb
+
+import "time"
+
+// number of buckets a time-based window is split into, it moves forward one bucket at a time
+const timeWindowBuckets = 10
+
+// Window is the span of recent calls the failure rate is computed over.
+type Window struct {
+	// Size makes a count-based window of the last Size calls.
+	Size int
+	// Length makes a time-based window of the calls in the last Length, used when Size is 0.
+	Length time.Duration
+}
+
+// slidingWindow counts the outcomes of recent calls.
+type slidingWindow interface {
+	add(now time.Time, failed bool)
+	counts(now time.Time) (calls, failures int)
+	reset()
+}
+
+// countWindow keeps the outcomes of the last len(outcomes) calls in a ring.
+type countWindow struct {
+	outcomes []bool
+	next     int
+	calls    int
+	failures int
+}
+
+func newCountWindow(size int) *countWindow {
+	return &countWindow{outcomes: make([]bool, size)}
+}
+
+func (w *countWindow) add(_ time.Time, failed bool) {
+	if w.calls == len(w.outcomes) {
+		w.calls--
+		if w.outcomes[w.next] {
+			w.failures--
+		}
+	}
+	w.outcomes[w.next] = failed
+	w.next = (w.next + 1) % len(w.outcomes)
+	w.calls++
+	if failed {
+		w.failures++
+	}
+}
+
+func (w *countWindow) counts(time.Time) (int, int) {
+	return w.calls, w.failures
+}
+
+func (w *countWindow) reset() {
+	w.next, w.calls, w.failures = 0, 0, 0
+}
+
+type windowBucket struct {
+	start    time.Time
+	calls    int
+	failures int
+}
+
+// timeWindow counts calls in buckets of equal width, dropping a bucket once it is older than the window.
+type timeWindow struct {
+	length  time.Duration
+	width   time.Duration
+	buckets [timeWindowBuckets]windowBucket
+}
+
+func newTimeWindow(length time.Duration) *timeWindow {
+	return &timeWindow{length: length, width: max(length/timeWindowBuckets, 1)}
+}
+
+func (w *timeWindow) add(now time.Time, failed bool) {
+	start := now.Truncate(w.width)
+	bucket := &w.buckets[(start.UnixNano()/int64(w.width))%timeWindowBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = windowBucket{start: start}
+	}
+	bucket.calls++
+	if failed {
+		bucket.failures++
+	}
+}
+
+func (w *timeWindow) counts(now time.Time) (int, int) {
+	calls, failures := 0, 0
+	for _, bucket := range w.buckets {
+		if bucket.calls > 0 && now.Sub(bucket.start) < w.length {
+			calls += bucket.calls
+			failures += bucket.failures
+		}
+	}
+	return calls, failures
+}
+
+func (w *timeWindow) reset() {
+	w.buckets = [timeWindowBuckets]windowBucket{}
+}
@@ -0,0 +1,68 @@
+package hub
+
+import (
+	"context"
+	"sync"
+)
+
+// Hub fans out values published under a key to everybody subscribed to that key.
+// Each subscriber buffers a single value and a newer one replaces an unread older one,
+// so publishers never block on slow subscribers, who only miss intermediate values.
+type Hub[T any] struct {
+	mu   sync.Mutex
+	subs map[string]map[chan T]struct{}
+}
+
+func New[T any]() *Hub[T] {
+	return &Hub[T]{subs: make(map[string]map[chan T]struct{})}
+}
+
+// Subscribe delivers values published under key until ctx is done, then closes the channel.
+func (h *Hub[T]) Subscribe(ctx context.Context, key string) <-chan T {
+	ch := make(chan T, 1)
+
+	h.mu.Lock()
+	if h.subs[key] == nil {
+		h.subs[key] = make(map[chan T]struct{})
+	}
+	h.subs[key][ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[key], ch)
+		if len(h.subs[key]) == 0 {
+			delete(h.subs, key)
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Publish hands value to every subscriber of key without blocking.
+func (h *Hub[T]) Publish(key string, value T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[key] {
+		select {
+		case ch <- value:
+		default:
+			// drop the value the subscriber hasn't read yet; only publishers send and they
+			// hold the lock, so there is room for the new one afterwards
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- value
+		}
+	}
+}
+
+// Subscribers returns the number of active subscriptions to key.
+func (h *Hub[T]) Subscribers(key string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[key])
+}
@@ -0,0 +1,71 @@
+//go:build unit
+
+package hub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub(main *testing.T) {
+	main.Run("FanOut", func(t *testing.T) {
+		// Arrange
+		h := hub.New[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		first := h.Subscribe(ctx, "kyiv")
+		second := h.Subscribe(ctx, "kyiv")
+		other := h.Subscribe(ctx, "lviv")
+
+		// Act
+		h.Publish("kyiv", 1)
+
+		// Assert
+		assert.Equal(t, 1, <-first)
+		assert.Equal(t, 1, <-second)
+		assert.Empty(t, other)
+	})
+
+	main.Run("SlowSubscriberGetsLatest", func(t *testing.T) {
+		// Arrange
+		h := hub.New[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		updates := h.Subscribe(ctx, "kyiv")
+
+		// Act
+		h.Publish("kyiv", 1)
+		h.Publish("kyiv", 2)
+		h.Publish("kyiv", 3)
+
+		// Assert
+		assert.Equal(t, 3, <-updates)
+		assert.Empty(t, updates)
+	})
+
+	main.Run("CancelUnsubscribes", func(t *testing.T) {
+		// Arrange
+		h := hub.New[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+		updates := h.Subscribe(ctx, "kyiv")
+		require.Equal(t, 1, h.Subscribers("kyiv"))
+
+		// Act
+		cancel()
+
+		// Assert
+		select {
+		case _, ok := <-updates:
+			assert.False(t, ok, "channel should be closed")
+		case <-time.After(time.Second):
+			t.Fatal("channel was not closed")
+		}
+		assert.Equal(t, 0, h.Subscribers("kyiv"))
+		h.Publish("kyiv", 1)
+	})
+}
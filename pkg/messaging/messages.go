@@ -1,14 +1,26 @@
 package messaging
 
+import "time"
+
 type SubscribeEvent struct {
 	Email string `json:"email"`
 	Token string `json:"token"`
 }
 
 type Weather struct {
-	Temperature float64 `json:"temperature"`
-	Humidity    float64 `json:"humidity"`
-	Description string  `json:"description"`
+	Temperature   float64   `json:"temperature"`
+	Humidity      float64   `json:"humidity"`
+	Description   string    `json:"description"`
+	WindSpeed     float64   `json:"wind_speed"`
+	WindDirection float64   `json:"wind_direction"`
+	Pressure      float64   `json:"pressure"`
+	Precipitation float64   `json:"precipitation"`
+	CloudCover    float64   `json:"cloud_cover"`
+	Visibility    float64   `json:"visibility"`
+	UVIndex       float64   `json:"uv_index"`
+	ObservedAt    time.Time `json:"observed_at"`
+	Source        string    `json:"source"`
+	Units         string    `json:"units"` // metric or imperial
 }
 
 type WeatherNotifyCommand struct {
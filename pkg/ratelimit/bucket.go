@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket allows bursts of up to capacity calls and refills at a steady rate,
+// so over any minute no more than perMinute calls get through on average.
+// The zero value is not usable, create it with NewTokenBucket.
+type TokenBucket struct {
+	capacity float64
+	// tokens added per second
+	rate float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a full bucket for perMinute calls a minute.
+func NewTokenBucket(perMinute int) *TokenBucket {
+	return &TokenBucket{
+		capacity: float64(perMinute),
+		rate:     float64(perMinute) / time.Minute.Seconds(),
+		tokens:   float64(perMinute),
+		last:     time.Now(),
+	}
+}
+
+// Allow takes a token if there is one.
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Remaining returns how many calls would be allowed right now.
+func (b *TokenBucket) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	return int(b.tokens)
+}
+
+// Drain empties the bucket, for when the other side says the limit is reached before we think it is.
+func (b *TokenBucket) Drain() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	b.tokens = 0
+}
+
+func (b *TokenBucket) refill(now time.Time) {
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
@@ -0,0 +1,43 @@
+//go:build unit
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Run("AllowsBurstUpToCapacity", func(t *testing.T) {
+		// Arrange
+		bucket := ratelimit.NewTokenBucket(3)
+
+		// Act
+		allowed := 0
+		for range 5 {
+			if bucket.Allow() {
+				allowed++
+			}
+		}
+
+		// Assert
+		assert.Equal(t, 3, allowed)
+		assert.Equal(t, 0, bucket.Remaining())
+	})
+
+	t.Run("Refills", func(t *testing.T) {
+		// Arrange
+		bucket := ratelimit.NewTokenBucket(6000)
+		bucket.Drain()
+		assert.False(t, bucket.Allow())
+
+		// Act
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert
+		assert.True(t, bucket.Allow(), "6000 a minute is one every 10ms")
+	})
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// kept a day longer than the day it counts, so clock skew between replicas can't reset it early
+const budgetRetention = 48 * time.Hour
+
+// spendScript counts a call and starts the expiry of the counter with the first one.
+var spendScript = redis.NewScript(`
+local spent = redis.call("INCR", KEYS[1])
+if spent == 1 then
+	redis.call("EXPIRE", KEYS[1], ARGV[1])
+end
+return spent
+`)
+
+// RedisDailyBudget counts calls per UTC day in Redis, so the budget is shared by all replicas
+// and survives restarts.
+type RedisDailyBudget struct {
+	client *redis.Client
+	name   string
+	limit  int
+}
+
+// NewRedisDailyBudget creates a budget of limit calls a day, stored under keys named after name.
+func NewRedisDailyBudget(client *redis.Client, name string, limit int) *RedisDailyBudget {
+	return &RedisDailyBudget{client: client, name: name, limit: limit}
+}
+
+// Spend counts a call and reports whether it still fits into today's budget and how many calls are left.
+func (b *RedisDailyBudget) Spend(ctx context.Context) (remaining int, ok bool, err error) {
+	spent, err := spendScript.Run(ctx, b.client, []string{b.key(time.Now())}, int(budgetRetention.Seconds())).Int()
+	if err != nil {
+		return 0, false, err
+	}
+	return max(b.limit-spent, 0), spent <= b.limit, nil
+}
+
+// Remaining returns how many calls are left today without spending one.
+func (b *RedisDailyBudget) Remaining(ctx context.Context) (int, error) {
+	spent, err := b.client.Get(ctx, b.key(time.Now())).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	return max(b.limit-spent, 0), nil
+}
+
+func (b *RedisDailyBudget) key(now time.Time) string {
+	return fmt.Sprintf("quota:%s:%s", b.name, now.UTC().Format(time.DateOnly))
+}
@@ -0,0 +1,51 @@
+package singleflight
+
+import (
+	"context"
+	"sync"
+)
+
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Group deduplicates concurrent calls with the same key, so only one of them does the work
+// and the others share its result. The zero value is ready to use.
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// Do runs fn unless a call with the same key is already in flight, in which case it waits for
+// that call's result or until ctx is done. leader reports whether fn was run by this caller.
+// The leader's fn runs with whatever context it captured, so its cancellation fails the waiters too.
+func (g *Group[T]) Do(ctx context.Context, key string, fn func() (T, error)) (value T, leader bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[T])
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.value, false, c.err
+		case <-ctx.Done():
+			var zero T
+			return zero, false, ctx.Err()
+		}
+	}
+	c := &call[T]{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.value, c.err = fn()
+	return c.value, true, c.err
+}
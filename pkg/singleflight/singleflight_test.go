@@ -0,0 +1,118 @@
+//go:build unit
+
+package singleflight_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/singleflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Do(t *testing.T) {
+	t.Run("CoalescesConcurrentCalls", func(t *testing.T) {
+		// Arrange
+		var group singleflight.Group[int]
+		var calls, leaders atomic.Int32
+		release := make(chan struct{})
+		const callers = 10
+		var wg sync.WaitGroup
+
+		// Act
+		for range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, leader, err := group.Do(context.Background(), "kyiv", func() (int, error) {
+					calls.Add(1)
+					<-release
+					return 42, nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, 42, value)
+				if leader {
+					leaders.Add(1)
+				}
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		// Assert
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, int32(1), leaders.Load())
+	})
+
+	t.Run("SharesError", func(t *testing.T) {
+		// Arrange
+		var group singleflight.Group[int]
+		errBoom := errors.New("boom")
+		started := make(chan struct{})
+		release := make(chan struct{})
+		go func() {
+			_, _, _ = group.Do(context.Background(), "kyiv", func() (int, error) {
+				close(started)
+				<-release
+				return 0, errBoom
+			})
+		}()
+		<-started
+		time.AfterFunc(10*time.Millisecond, func() { close(release) })
+
+		// Act
+		_, leader, err := group.Do(context.Background(), "kyiv", func() (int, error) {
+			return 1, nil
+		})
+
+		// Assert
+		assert.False(t, leader)
+		assert.ErrorIs(t, err, errBoom)
+	})
+
+	t.Run("WaiterGivesUpOnOwnContext", func(t *testing.T) {
+		// Arrange
+		var group singleflight.Group[int]
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		go func() {
+			_, _, _ = group.Do(context.Background(), "kyiv", func() (int, error) {
+				close(started)
+				<-release
+				return 1, nil
+			})
+		}()
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// Act
+		_, leader, err := group.Do(ctx, "kyiv", func() (int, error) { return 2, nil })
+
+		// Assert
+		assert.False(t, leader)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("NextCallRunsAgain", func(t *testing.T) {
+		// Arrange
+		var group singleflight.Group[int]
+		_, _, err := group.Do(context.Background(), "kyiv", func() (int, error) { return 1, nil })
+		require.NoError(t, err)
+
+		// Act
+		value, leader, err := group.Do(context.Background(), "kyiv", func() (int, error) { return 2, nil })
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, leader)
+		assert.Equal(t, 2, value)
+	})
+}
@@ -22,10 +22,12 @@ const (
 )
 
 type SubscribeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Frequency     string                 `protobuf:"bytes,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Email     string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Frequency string                 `protobuf:"bytes,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	City      string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	// "metric" or "imperial", metric when empty
+	Units         string `protobuf:"bytes,4,opt,name=units,proto3" json:"units,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +83,13 @@ func (x *SubscribeRequest) GetCity() string {
 	return ""
 }
 
+func (x *SubscribeRequest) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
 type SubscribeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -305,11 +314,12 @@ var File_proto_sub_v1alpha2_sub_proto protoreflect.FileDescriptor
 
 const file_proto_sub_v1alpha2_sub_proto_rawDesc = "" +
 	"\n" +
-	"\x1cproto/sub/v1alpha2/sub.proto\x12\fsub.v1alpha2\"Z\n" +
+	"\x1cproto/sub/v1alpha2/sub.proto\x12\fsub.v1alpha2\"p\n" +
 	"\x10SubscribeRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1c\n" +
 	"\tfrequency\x18\x02 \x01(\tR\tfrequency\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\"-\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x14\n" +
+	"\x05units\x18\x04 \x01(\tR\x05units\"-\n" +
 	"\x11SubscribeResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"&\n" +
 	"\x0eConfirmRequest\x12\x14\n" +
@@ -22,8 +22,12 @@ const (
 )
 
 type GetCurrentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Query:
+	//
+	//	*GetCurrentRequest_City
+	//	*GetCurrentRequest_Coordinates
+	Query         isGetCurrentRequest_Query `protobuf_oneof:"query"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -58,25 +62,114 @@ func (*GetCurrentRequest) Descriptor() ([]byte, []int) {
 	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *GetCurrentRequest) GetQuery() isGetCurrentRequest_Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
 func (x *GetCurrentRequest) GetCity() string {
 	if x != nil {
-		return x.City
+		if x, ok := x.Query.(*GetCurrentRequest_City); ok {
+			return x.City
+		}
 	}
 	return ""
 }
 
+func (x *GetCurrentRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		if x, ok := x.Query.(*GetCurrentRequest_Coordinates); ok {
+			return x.Coordinates
+		}
+	}
+	return nil
+}
+
+type isGetCurrentRequest_Query interface {
+	isGetCurrentRequest_Query()
+}
+
+type GetCurrentRequest_City struct {
+	City string `protobuf:"bytes,1,opt,name=city,proto3,oneof"`
+}
+
+type GetCurrentRequest_Coordinates struct {
+	Coordinates *Coordinates `protobuf:"bytes,2,opt,name=coordinates,proto3,oneof"`
+}
+
+func (*GetCurrentRequest_City) isGetCurrentRequest_Query() {}
+
+func (*GetCurrentRequest_Coordinates) isGetCurrentRequest_Query() {}
+
+type Coordinates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// degrees, -90..90
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	// degrees, -180..180
+	Lon           float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Coordinates) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
 type GetCurrentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Temperature   float32                `protobuf:"fixed32,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
 	Humidity      float32                `protobuf:"fixed32,2,opt,name=humidity,proto3" json:"humidity,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Location      *Location              `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetCurrentResponse) Reset() {
 	*x = GetCurrentResponse{}
-	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[1]
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -88,7 +181,7 @@ func (x *GetCurrentResponse) String() string {
 func (*GetCurrentResponse) ProtoMessage() {}
 
 func (x *GetCurrentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[1]
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,7 +194,7 @@ func (x *GetCurrentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCurrentResponse.ProtoReflect.Descriptor instead.
 func (*GetCurrentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{1}
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetCurrentResponse) GetTemperature() float32 {
@@ -125,20 +218,453 @@ func (x *GetCurrentResponse) GetDescription() string {
 	return ""
 }
 
+func (x *GetCurrentResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// canonical location the requested city was resolved to
+type Location struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// GeoNames id
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// ISO 3166-1 alpha-2 country code
+	Country string  `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Lat     float64 `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon     float64 `protobuf:"fixed64,5,opt,name=lon,proto3" json:"lon,omitempty"`
+	// IANA time zone, e.g. Europe/Kyiv
+	Timezone      string `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Location) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Location) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Location) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *Location) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// attached to NotFound status when the city can't be resolved
+type CitySuggestions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []string               `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CitySuggestions) Reset() {
+	*x = CitySuggestions{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CitySuggestions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CitySuggestions) ProtoMessage() {}
+
+func (x *CitySuggestions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CitySuggestions.ProtoReflect.Descriptor instead.
+func (*CitySuggestions) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CitySuggestions) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetForecastRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// number of days including today, defaults to 3 when omitted
+	Days          int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastRequest) Reset() {
+	*x = GetForecastRequest{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastRequest) ProtoMessage() {}
+
+func (x *GetForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetForecastRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetForecastRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type HourlyForecast struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unix timestamp (seconds) of the beginning of the hour
+	Time          int64   `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature   float32 `protobuf:"fixed32,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity      float32 `protobuf:"fixed32,3,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description   string  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HourlyForecast) Reset() {
+	*x = HourlyForecast{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecast) ProtoMessage() {}
+
+func (x *HourlyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecast.ProtoReflect.Descriptor instead.
+func (*HourlyForecast) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HourlyForecast) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetTemperature() float32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetHumidity() float32 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DailyForecast struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unix timestamp (seconds) of the beginning of the day
+	Date           int64   `protobuf:"varint,1,opt,name=date,proto3" json:"date,omitempty"`
+	MinTemperature float32 `protobuf:"fixed32,2,opt,name=min_temperature,json=minTemperature,proto3" json:"min_temperature,omitempty"`
+	MaxTemperature float32 `protobuf:"fixed32,3,opt,name=max_temperature,json=maxTemperature,proto3" json:"max_temperature,omitempty"`
+	Humidity       float32 `protobuf:"fixed32,4,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description    string  `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DailyForecast) Reset() {
+	*x = DailyForecast{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecast) ProtoMessage() {}
+
+func (x *DailyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecast.ProtoReflect.Descriptor instead.
+func (*DailyForecast) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DailyForecast) GetDate() int64 {
+	if x != nil {
+		return x.Date
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetMinTemperature() float32 {
+	if x != nil {
+		return x.MinTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetMaxTemperature() float32 {
+	if x != nil {
+		return x.MaxTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetHumidity() float32 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hourly        []*HourlyForecast      `protobuf:"bytes,1,rep,name=hourly,proto3" json:"hourly,omitempty"`
+	Daily         []*DailyForecast       `protobuf:"bytes,2,rep,name=daily,proto3" json:"daily,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastResponse) Reset() {
+	*x = GetForecastResponse{}
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastResponse) ProtoMessage() {}
+
+func (x *GetForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha1_weather_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetForecastResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha1_weather_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetForecastResponse) GetHourly() []*HourlyForecast {
+	if x != nil {
+		return x.Hourly
+	}
+	return nil
+}
+
+func (x *GetForecastResponse) GetDaily() []*DailyForecast {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *GetForecastResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
 var File_proto_weath_v1alpha1_weather_proto protoreflect.FileDescriptor
 
 const file_proto_weath_v1alpha1_weather_proto_rawDesc = "" +
 	"\n" +
-	"\"proto/weath/v1alpha1/weather.proto\x12\x10weather.v1alpha1\"'\n" +
-	"\x11GetCurrentRequest\x12\x12\n" +
-	"\x04city\x18\x01 \x01(\tR\x04city\"t\n" +
+	"\"proto/weath/v1alpha1/weather.proto\x12\x10weather.v1alpha1\"u\n" +
+	"\x11GetCurrentRequest\x12\x14\n" +
+	"\x04city\x18\x01 \x01(\tH\x00R\x04city\x12A\n" +
+	"\vcoordinates\x18\x02 \x01(\v2\x1d.weather.v1alpha1.CoordinatesH\x00R\vcoordinatesB\a\n" +
+	"\x05query\"1\n" +
+	"\vCoordinates\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"\xac\x01\n" +
 	"\x12GetCurrentResponse\x12 \n" +
 	"\vtemperature\x18\x01 \x01(\x02R\vtemperature\x12\x1a\n" +
 	"\bhumidity\x18\x02 \x01(\x02R\bhumidity\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription2i\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x126\n" +
+	"\blocation\x18\x04 \x01(\v2\x1a.weather.v1alpha1.LocationR\blocation\"\x88\x01\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x10\n" +
+	"\x03lat\x18\x04 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x05 \x01(\x01R\x03lon\x12\x1a\n" +
+	"\btimezone\x18\x06 \x01(\tR\btimezone\"3\n" +
+	"\x0fCitySuggestions\x12 \n" +
+	"\vsuggestions\x18\x01 \x03(\tR\vsuggestions\"<\n" +
+	"\x12GetForecastRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\x84\x01\n" +
+	"\x0eHourlyForecast\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\x02R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x03 \x01(\x02R\bhumidity\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xb3\x01\n" +
+	"\rDailyForecast\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\x03R\x04date\x12'\n" +
+	"\x0fmin_temperature\x18\x02 \x01(\x02R\x0eminTemperature\x12'\n" +
+	"\x0fmax_temperature\x18\x03 \x01(\x02R\x0emaxTemperature\x12\x1a\n" +
+	"\bhumidity\x18\x04 \x01(\x02R\bhumidity\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xbe\x01\n" +
+	"\x13GetForecastResponse\x128\n" +
+	"\x06hourly\x18\x01 \x03(\v2 .weather.v1alpha1.HourlyForecastR\x06hourly\x125\n" +
+	"\x05daily\x18\x02 \x03(\v2\x1f.weather.v1alpha1.DailyForecastR\x05daily\x126\n" +
+	"\blocation\x18\x03 \x01(\v2\x1a.weather.v1alpha1.LocationR\blocation2\xc5\x01\n" +
 	"\x0eWeatherService\x12W\n" +
 	"\n" +
-	"GetCurrent\x12#.weather.v1alpha1.GetCurrentRequest\x1a$.weather.v1alpha1.GetCurrentResponseBtZrgithub.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weather/v1alpha1;weatherv1alpha1b\x06proto3"
+	"GetCurrent\x12#.weather.v1alpha1.GetCurrentRequest\x1a$.weather.v1alpha1.GetCurrentResponse\x12Z\n" +
+	"\vGetForecast\x12$.weather.v1alpha1.GetForecastRequest\x1a%.weather.v1alpha1.GetForecastResponseBtZrgithub.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weather/v1alpha1;weatherv1alpha1b\x06proto3"
 
 var (
 	file_proto_weath_v1alpha1_weather_proto_rawDescOnce sync.Once
@@ -152,19 +678,33 @@ func file_proto_weath_v1alpha1_weather_proto_rawDescGZIP() []byte {
 	return file_proto_weath_v1alpha1_weather_proto_rawDescData
 }
 
-var file_proto_weath_v1alpha1_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_weath_v1alpha1_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_weath_v1alpha1_weather_proto_goTypes = []any{
-	(*GetCurrentRequest)(nil),  // 0: weather.v1alpha1.GetCurrentRequest
-	(*GetCurrentResponse)(nil), // 1: weather.v1alpha1.GetCurrentResponse
+	(*GetCurrentRequest)(nil),   // 0: weather.v1alpha1.GetCurrentRequest
+	(*Coordinates)(nil),         // 1: weather.v1alpha1.Coordinates
+	(*GetCurrentResponse)(nil),  // 2: weather.v1alpha1.GetCurrentResponse
+	(*Location)(nil),            // 3: weather.v1alpha1.Location
+	(*CitySuggestions)(nil),     // 4: weather.v1alpha1.CitySuggestions
+	(*GetForecastRequest)(nil),  // 5: weather.v1alpha1.GetForecastRequest
+	(*HourlyForecast)(nil),      // 6: weather.v1alpha1.HourlyForecast
+	(*DailyForecast)(nil),       // 7: weather.v1alpha1.DailyForecast
+	(*GetForecastResponse)(nil), // 8: weather.v1alpha1.GetForecastResponse
 }
 var file_proto_weath_v1alpha1_weather_proto_depIdxs = []int32{
-	0, // 0: weather.v1alpha1.WeatherService.GetCurrent:input_type -> weather.v1alpha1.GetCurrentRequest
-	1, // 1: weather.v1alpha1.WeatherService.GetCurrent:output_type -> weather.v1alpha1.GetCurrentResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: weather.v1alpha1.GetCurrentRequest.coordinates:type_name -> weather.v1alpha1.Coordinates
+	3, // 1: weather.v1alpha1.GetCurrentResponse.location:type_name -> weather.v1alpha1.Location
+	6, // 2: weather.v1alpha1.GetForecastResponse.hourly:type_name -> weather.v1alpha1.HourlyForecast
+	7, // 3: weather.v1alpha1.GetForecastResponse.daily:type_name -> weather.v1alpha1.DailyForecast
+	3, // 4: weather.v1alpha1.GetForecastResponse.location:type_name -> weather.v1alpha1.Location
+	0, // 5: weather.v1alpha1.WeatherService.GetCurrent:input_type -> weather.v1alpha1.GetCurrentRequest
+	5, // 6: weather.v1alpha1.WeatherService.GetForecast:input_type -> weather.v1alpha1.GetForecastRequest
+	2, // 7: weather.v1alpha1.WeatherService.GetCurrent:output_type -> weather.v1alpha1.GetCurrentResponse
+	8, // 8: weather.v1alpha1.WeatherService.GetForecast:output_type -> weather.v1alpha1.GetForecastResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_weath_v1alpha1_weather_proto_init() }
@@ -172,13 +712,17 @@ func file_proto_weath_v1alpha1_weather_proto_init() {
 	if File_proto_weath_v1alpha1_weather_proto != nil {
 		return
 	}
+	file_proto_weath_v1alpha1_weather_proto_msgTypes[0].OneofWrappers = []any{
+		(*GetCurrentRequest_City)(nil),
+		(*GetCurrentRequest_Coordinates)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_weath_v1alpha1_weather_proto_rawDesc), len(file_proto_weath_v1alpha1_weather_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,2063 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/weath/v1alpha2/weather.proto
+
+package weatherv1alpha2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// measurement system of the returned values, weather itself is always stored in metric
+type Units int32
+
+const (
+	// treated as UNITS_METRIC
+	Units_UNITS_UNSPECIFIED Units = 0
+	// °C, m/s, hPa, mm/h, km
+	Units_UNITS_METRIC Units = 1
+	// °F, mph, inHg, in/h, mi
+	Units_UNITS_IMPERIAL Units = 2
+)
+
+// Enum value maps for Units.
+var (
+	Units_name = map[int32]string{
+		0: "UNITS_UNSPECIFIED",
+		1: "UNITS_METRIC",
+		2: "UNITS_IMPERIAL",
+	}
+	Units_value = map[string]int32{
+		"UNITS_UNSPECIFIED": 0,
+		"UNITS_METRIC":      1,
+		"UNITS_IMPERIAL":    2,
+	}
+)
+
+func (x Units) Enum() *Units {
+	p := new(Units)
+	*p = x
+	return p
+}
+
+func (x Units) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Units) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_weath_v1alpha2_weather_proto_enumTypes[0].Descriptor()
+}
+
+func (Units) Type() protoreflect.EnumType {
+	return &file_proto_weath_v1alpha2_weather_proto_enumTypes[0]
+}
+
+func (x Units) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Units.Descriptor instead.
+func (Units) EnumDescriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{0}
+}
+
+// how severe the issuer rates an alert, on the CAP scale
+type AlertSeverity int32
+
+const (
+	// the provider did not rate the alert
+	AlertSeverity_ALERT_SEVERITY_UNSPECIFIED AlertSeverity = 0
+	AlertSeverity_ALERT_SEVERITY_MINOR       AlertSeverity = 1
+	AlertSeverity_ALERT_SEVERITY_MODERATE    AlertSeverity = 2
+	AlertSeverity_ALERT_SEVERITY_SEVERE      AlertSeverity = 3
+	AlertSeverity_ALERT_SEVERITY_EXTREME     AlertSeverity = 4
+)
+
+// Enum value maps for AlertSeverity.
+var (
+	AlertSeverity_name = map[int32]string{
+		0: "ALERT_SEVERITY_UNSPECIFIED",
+		1: "ALERT_SEVERITY_MINOR",
+		2: "ALERT_SEVERITY_MODERATE",
+		3: "ALERT_SEVERITY_SEVERE",
+		4: "ALERT_SEVERITY_EXTREME",
+	}
+	AlertSeverity_value = map[string]int32{
+		"ALERT_SEVERITY_UNSPECIFIED": 0,
+		"ALERT_SEVERITY_MINOR":       1,
+		"ALERT_SEVERITY_MODERATE":    2,
+		"ALERT_SEVERITY_SEVERE":      3,
+		"ALERT_SEVERITY_EXTREME":     4,
+	}
+)
+
+func (x AlertSeverity) Enum() *AlertSeverity {
+	p := new(AlertSeverity)
+	*p = x
+	return p
+}
+
+func (x AlertSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_weath_v1alpha2_weather_proto_enumTypes[1].Descriptor()
+}
+
+func (AlertSeverity) Type() protoreflect.EnumType {
+	return &file_proto_weath_v1alpha2_weather_proto_enumTypes[1]
+}
+
+func (x AlertSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertSeverity.Descriptor instead.
+func (AlertSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{1}
+}
+
+type GetCurrentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Query:
+	//
+	//	*GetCurrentRequest_City
+	//	*GetCurrentRequest_Coordinates
+	Query         isGetCurrentRequest_Query `protobuf_oneof:"query"`
+	Units         Units                     `protobuf:"varint,3,opt,name=units,proto3,enum=weather.v1alpha2.Units" json:"units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentRequest) Reset() {
+	*x = GetCurrentRequest{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentRequest) ProtoMessage() {}
+
+func (x *GetCurrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetCurrentRequest) GetQuery() isGetCurrentRequest_Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *GetCurrentRequest) GetCity() string {
+	if x != nil {
+		if x, ok := x.Query.(*GetCurrentRequest_City); ok {
+			return x.City
+		}
+	}
+	return ""
+}
+
+func (x *GetCurrentRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		if x, ok := x.Query.(*GetCurrentRequest_Coordinates); ok {
+			return x.Coordinates
+		}
+	}
+	return nil
+}
+
+func (x *GetCurrentRequest) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+type isGetCurrentRequest_Query interface {
+	isGetCurrentRequest_Query()
+}
+
+type GetCurrentRequest_City struct {
+	City string `protobuf:"bytes,1,opt,name=city,proto3,oneof"`
+}
+
+type GetCurrentRequest_Coordinates struct {
+	Coordinates *Coordinates `protobuf:"bytes,2,opt,name=coordinates,proto3,oneof"`
+}
+
+func (*GetCurrentRequest_City) isGetCurrentRequest_Query() {}
+
+func (*GetCurrentRequest_Coordinates) isGetCurrentRequest_Query() {}
+
+type Coordinates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// degrees, -90..90
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	// degrees, -180..180
+	Lon           float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Coordinates) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type Weather struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// degrees Celsius, or Fahrenheit for UNITS_IMPERIAL
+	Temperature float64 `protobuf:"fixed64,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	// percent
+	Humidity    float64 `protobuf:"fixed64,2,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// meters per second, or miles per hour for UNITS_IMPERIAL
+	WindSpeed float64 `protobuf:"fixed64,4,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	// degrees clockwise from north the wind blows from
+	WindDirection float64 `protobuf:"fixed64,5,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	// hPa, or inches of mercury for UNITS_IMPERIAL
+	Pressure float64 `protobuf:"fixed64,6,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	// mm per hour, or inches per hour for UNITS_IMPERIAL
+	Precipitation float64 `protobuf:"fixed64,7,opt,name=precipitation,proto3" json:"precipitation,omitempty"`
+	// percent
+	CloudCover float64 `protobuf:"fixed64,8,opt,name=cloud_cover,json=cloudCover,proto3" json:"cloud_cover,omitempty"`
+	// km, or miles for UNITS_IMPERIAL
+	Visibility float64                `protobuf:"fixed64,9,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	UvIndex    float64                `protobuf:"fixed64,10,opt,name=uv_index,json=uvIndex,proto3" json:"uv_index,omitempty"`
+	ObservedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	// name of the provider that answered, e.g. weatherapi.com
+	Source        string `protobuf:"bytes,12,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Weather) Reset() {
+	*x = Weather{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Weather) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weather) ProtoMessage() {}
+
+func (x *Weather) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weather.ProtoReflect.Descriptor instead.
+func (*Weather) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Weather) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Weather) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *Weather) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Weather) GetWindSpeed() float64 {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return 0
+}
+
+func (x *Weather) GetWindDirection() float64 {
+	if x != nil {
+		return x.WindDirection
+	}
+	return 0
+}
+
+func (x *Weather) GetPressure() float64 {
+	if x != nil {
+		return x.Pressure
+	}
+	return 0
+}
+
+func (x *Weather) GetPrecipitation() float64 {
+	if x != nil {
+		return x.Precipitation
+	}
+	return 0
+}
+
+func (x *Weather) GetCloudCover() float64 {
+	if x != nil {
+		return x.CloudCover
+	}
+	return 0
+}
+
+func (x *Weather) GetVisibility() float64 {
+	if x != nil {
+		return x.Visibility
+	}
+	return 0
+}
+
+func (x *Weather) GetUvIndex() float64 {
+	if x != nil {
+		return x.UvIndex
+	}
+	return 0
+}
+
+func (x *Weather) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *Weather) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type GetCurrentResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Weather  *Weather               `protobuf:"bytes,1,opt,name=weather,proto3" json:"weather,omitempty"`
+	Location *Location              `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// units the weather is expressed in, never UNITS_UNSPECIFIED
+	Units Units `protobuf:"varint,3,opt,name=units,proto3,enum=weather.v1alpha2.Units" json:"units,omitempty"`
+	// set when providers failed and an expired cached reading was returned instead
+	Stale bool `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	// how long ago the reading was fetched from providers, zero for fresh ones
+	Age           *durationpb.Duration `protobuf:"bytes,5,opt,name=age,proto3" json:"age,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentResponse) Reset() {
+	*x = GetCurrentResponse{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentResponse) ProtoMessage() {}
+
+func (x *GetCurrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCurrentResponse) GetWeather() *Weather {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *GetCurrentResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetCurrentResponse) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+func (x *GetCurrentResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GetCurrentResponse) GetAge() *durationpb.Duration {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+// canonical location the requested city was resolved to
+type Location struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// GeoNames id, 0 for coordinate lookups
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// ISO 3166-1 alpha-2 country code
+	Country string  `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Lat     float64 `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon     float64 `protobuf:"fixed64,5,opt,name=lon,proto3" json:"lon,omitempty"`
+	// IANA time zone, e.g. Europe/Kyiv
+	Timezone      string `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Location) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Location) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Location) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *Location) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// attached to NotFound status when the city can't be resolved
+type CitySuggestions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []string               `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CitySuggestions) Reset() {
+	*x = CitySuggestions{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CitySuggestions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CitySuggestions) ProtoMessage() {}
+
+func (x *CitySuggestions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CitySuggestions.ProtoReflect.Descriptor instead.
+func (*CitySuggestions) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CitySuggestions) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetForecastRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// number of days including today, defaults to 3 when omitted
+	Days          int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	Units         Units `protobuf:"varint,3,opt,name=units,proto3,enum=weather.v1alpha2.Units" json:"units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastRequest) Reset() {
+	*x = GetForecastRequest{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastRequest) ProtoMessage() {}
+
+func (x *GetForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetForecastRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetForecastRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetForecastRequest) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+type HourlyForecast struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// beginning of the hour
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature   float64                `protobuf:"fixed64,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity      float64                `protobuf:"fixed64,3,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HourlyForecast) Reset() {
+	*x = HourlyForecast{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecast) ProtoMessage() {}
+
+func (x *HourlyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecast.ProtoReflect.Descriptor instead.
+func (*HourlyForecast) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HourlyForecast) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HourlyForecast) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DailyForecast struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// beginning of the day
+	Date           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	MinTemperature float64                `protobuf:"fixed64,2,opt,name=min_temperature,json=minTemperature,proto3" json:"min_temperature,omitempty"`
+	MaxTemperature float64                `protobuf:"fixed64,3,opt,name=max_temperature,json=maxTemperature,proto3" json:"max_temperature,omitempty"`
+	Humidity       float64                `protobuf:"fixed64,4,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description    string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DailyForecast) Reset() {
+	*x = DailyForecast{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecast) ProtoMessage() {}
+
+func (x *DailyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecast.ProtoReflect.Descriptor instead.
+func (*DailyForecast) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DailyForecast) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DailyForecast) GetMinTemperature() float64 {
+	if x != nil {
+		return x.MinTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetMaxTemperature() float64 {
+	if x != nil {
+		return x.MaxTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetForecastResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hourly   []*HourlyForecast      `protobuf:"bytes,1,rep,name=hourly,proto3" json:"hourly,omitempty"`
+	Daily    []*DailyForecast       `protobuf:"bytes,2,rep,name=daily,proto3" json:"daily,omitempty"`
+	Location *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// units the temperatures are expressed in, never UNITS_UNSPECIFIED
+	Units Units `protobuf:"varint,4,opt,name=units,proto3,enum=weather.v1alpha2.Units" json:"units,omitempty"`
+	// set when providers failed and an expired cached forecast was returned instead
+	Stale bool `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	// how long ago the forecast was fetched from providers, zero for fresh ones
+	Age           *durationpb.Duration `protobuf:"bytes,6,opt,name=age,proto3" json:"age,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastResponse) Reset() {
+	*x = GetForecastResponse{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastResponse) ProtoMessage() {}
+
+func (x *GetForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetForecastResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetForecastResponse) GetHourly() []*HourlyForecast {
+	if x != nil {
+		return x.Hourly
+	}
+	return nil
+}
+
+func (x *GetForecastResponse) GetDaily() []*DailyForecast {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *GetForecastResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetForecastResponse) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+func (x *GetForecastResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GetForecastResponse) GetAge() *durationpb.Duration {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+type GetCurrentBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// at most 1000 items, cities repeated across items are fetched once
+	Items         []*GetCurrentRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentBatchRequest) Reset() {
+	*x = GetCurrentBatchRequest{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentBatchRequest) ProtoMessage() {}
+
+func (x *GetCurrentBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCurrentBatchRequest) GetItems() []*GetCurrentRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetCurrentBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one result per requested item, in the same order
+	Results       []*GetCurrentBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentBatchResponse) Reset() {
+	*x = GetCurrentBatchResponse{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentBatchResponse) ProtoMessage() {}
+
+func (x *GetCurrentBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCurrentBatchResponse) GetResults() []*GetCurrentBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetCurrentBatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetCurrentBatchResult_Current
+	//	*GetCurrentBatchResult_Error
+	Result        isGetCurrentBatchResult_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentBatchResult) Reset() {
+	*x = GetCurrentBatchResult{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentBatchResult) ProtoMessage() {}
+
+func (x *GetCurrentBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentBatchResult.ProtoReflect.Descriptor instead.
+func (*GetCurrentBatchResult) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetCurrentBatchResult) GetResult() isGetCurrentBatchResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetCurrentBatchResult) GetCurrent() *GetCurrentResponse {
+	if x != nil {
+		if x, ok := x.Result.(*GetCurrentBatchResult_Current); ok {
+			return x.Current
+		}
+	}
+	return nil
+}
+
+func (x *GetCurrentBatchResult) GetError() *BatchError {
+	if x != nil {
+		if x, ok := x.Result.(*GetCurrentBatchResult_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isGetCurrentBatchResult_Result interface {
+	isGetCurrentBatchResult_Result()
+}
+
+type GetCurrentBatchResult_Current struct {
+	Current *GetCurrentResponse `protobuf:"bytes,1,opt,name=current,proto3,oneof"`
+}
+
+type GetCurrentBatchResult_Error struct {
+	Error *BatchError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*GetCurrentBatchResult_Current) isGetCurrentBatchResult_Result() {}
+
+func (*GetCurrentBatchResult_Error) isGetCurrentBatchResult_Result() {}
+
+// failure of a single batch item, mirrors the status GetCurrent would have returned for it
+type BatchError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// google.rpc.Code value
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// "did you mean" names for NOT_FOUND
+	Suggestions   []string `protobuf:"bytes,3,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchError) Reset() {
+	*x = BatchError{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchError) ProtoMessage() {}
+
+func (x *BatchError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchError.ProtoReflect.Descriptor instead.
+func (*BatchError) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchError) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// readings observed in [from, to), at most 31 days apart
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// defaults to now when omitted
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Units         Units                  `protobuf:"varint,4,opt,name=units,proto3,enum=weather.v1alpha2.Units" json:"units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetHistoryRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+type Observation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// source is the configured name of the provider, observed_at the start of the interval for downsampled readings
+	Weather *Weather `protobuf:"bytes,1,opt,name=weather,proto3" json:"weather,omitempty"`
+	// 1 for readings as fetched, or how many readings a downsampled one averages
+	Samples       int32 `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Observation) Reset() {
+	*x = Observation{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Observation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Observation) ProtoMessage() {}
+
+func (x *Observation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Observation.ProtoReflect.Descriptor instead.
+func (*Observation) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Observation) GetWeather() *Weather {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *Observation) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// oldest first, readings observed at the same time ordered by provider
+	Observations []*Observation `protobuf:"bytes,1,rep,name=observations,proto3" json:"observations,omitempty"`
+	Location     *Location      `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// units the weather is expressed in, never UNITS_UNSPECIFIED
+	Units         Units `protobuf:"varint,3,opt,name=units,proto3,enum=weather.v1alpha2.Units" json:"units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetHistoryResponse) GetObservations() []*Observation {
+	if x != nil {
+		return x.Observations
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+type GetAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertsRequest) Reset() {
+	*x = GetAlertsRequest{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertsRequest) ProtoMessage() {}
+
+func (x *GetAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertsRequest.ProtoReflect.Descriptor instead.
+func (*GetAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAlertsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type Alert struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Severity AlertSeverity          `protobuf:"varint,1,opt,name=severity,proto3,enum=weather.v1alpha2.AlertSeverity" json:"severity,omitempty"`
+	// kind of the alert, e.g. Flood Warning
+	Event     string                 `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Headline  string                 `protobuf:"bytes,3,opt,name=headline,proto3" json:"headline,omitempty"`
+	Effective *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective,proto3" json:"effective,omitempty"`
+	// unset when the issuer gave no end
+	Expires *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	// names of the areas the alert covers, empty when the provider does not list them
+	Areas         []string `protobuf:"bytes,6,rep,name=areas,proto3" json:"areas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Alert) GetSeverity() AlertSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return AlertSeverity_ALERT_SEVERITY_UNSPECIFIED
+}
+
+func (x *Alert) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Alert) GetHeadline() string {
+	if x != nil {
+		return x.Headline
+	}
+	return ""
+}
+
+func (x *Alert) GetEffective() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Effective
+	}
+	return nil
+}
+
+func (x *Alert) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+func (x *Alert) GetAreas() []string {
+	if x != nil {
+		return x.Areas
+	}
+	return nil
+}
+
+type GetAlertsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// alerts in effect, by when they took effect, empty when there are none
+	Alerts   []*Alert  `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	Location *Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// name of the provider that answered, or of all of them joined with commas under the consensus strategy
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// set when providers failed and expired cached alerts were returned instead
+	Stale bool `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	// how long ago the alerts were fetched from providers, zero for fresh ones
+	Age           *durationpb.Duration `protobuf:"bytes,5,opt,name=age,proto3" json:"age,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertsResponse) Reset() {
+	*x = GetAlertsResponse{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertsResponse) ProtoMessage() {}
+
+func (x *GetAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertsResponse.ProtoReflect.Descriptor instead.
+func (*GetAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *GetAlertsResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetAlertsResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetAlertsResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GetAlertsResponse) GetAge() *durationpb.Duration {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+type GetAirQualityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAirQualityRequest) Reset() {
+	*x = GetAirQualityRequest{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAirQualityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAirQualityRequest) ProtoMessage() {}
+
+func (x *GetAirQualityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAirQualityRequest.ProtoReflect.Descriptor instead.
+func (*GetAirQualityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetAirQualityRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+// concentrations in µg/m³
+type AirQuality struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// US EPA air quality index of the concentrations, 0-500
+	Aqi   int32   `protobuf:"varint,1,opt,name=aqi,proto3" json:"aqi,omitempty"`
+	Pm2_5 float64 `protobuf:"fixed64,2,opt,name=pm2_5,json=pm25,proto3" json:"pm2_5,omitempty"`
+	Pm10  float64 `protobuf:"fixed64,3,opt,name=pm10,proto3" json:"pm10,omitempty"`
+	O3    float64 `protobuf:"fixed64,4,opt,name=o3,proto3" json:"o3,omitempty"`
+	No2   float64 `protobuf:"fixed64,5,opt,name=no2,proto3" json:"no2,omitempty"`
+	// grains/m³ by plant, e.g. birch, empty when the provider has no pollen counts for the location
+	Pollen        map[string]float64     `protobuf:"bytes,6,rep,name=pollen,proto3" json:"pollen,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	ObservedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AirQuality) Reset() {
+	*x = AirQuality{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AirQuality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AirQuality) ProtoMessage() {}
+
+func (x *AirQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AirQuality.ProtoReflect.Descriptor instead.
+func (*AirQuality) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AirQuality) GetAqi() int32 {
+	if x != nil {
+		return x.Aqi
+	}
+	return 0
+}
+
+func (x *AirQuality) GetPm2_5() float64 {
+	if x != nil {
+		return x.Pm2_5
+	}
+	return 0
+}
+
+func (x *AirQuality) GetPm10() float64 {
+	if x != nil {
+		return x.Pm10
+	}
+	return 0
+}
+
+func (x *AirQuality) GetO3() float64 {
+	if x != nil {
+		return x.O3
+	}
+	return 0
+}
+
+func (x *AirQuality) GetNo2() float64 {
+	if x != nil {
+		return x.No2
+	}
+	return 0
+}
+
+func (x *AirQuality) GetPollen() map[string]float64 {
+	if x != nil {
+		return x.Pollen
+	}
+	return nil
+}
+
+func (x *AirQuality) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+type GetAirQualityResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AirQuality *AirQuality            `protobuf:"bytes,1,opt,name=air_quality,json=airQuality,proto3" json:"air_quality,omitempty"`
+	Location   *Location              `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// name of the provider that answered, or of all of them joined with commas under the consensus strategy
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// set when providers failed and an expired cached value was returned instead
+	Stale bool `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	// how long ago the value was fetched from providers, zero for a fresh one
+	Age           *durationpb.Duration `protobuf:"bytes,5,opt,name=age,proto3" json:"age,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAirQualityResponse) Reset() {
+	*x = GetAirQualityResponse{}
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAirQualityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAirQualityResponse) ProtoMessage() {}
+
+func (x *GetAirQualityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_weath_v1alpha2_weather_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAirQualityResponse.ProtoReflect.Descriptor instead.
+func (*GetAirQualityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_weath_v1alpha2_weather_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAirQualityResponse) GetAirQuality() *AirQuality {
+	if x != nil {
+		return x.AirQuality
+	}
+	return nil
+}
+
+func (x *GetAirQualityResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetAirQualityResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetAirQualityResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GetAirQualityResponse) GetAge() *durationpb.Duration {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+var File_proto_weath_v1alpha2_weather_proto protoreflect.FileDescriptor
+
+const file_proto_weath_v1alpha2_weather_proto_rawDesc = "" +
+	"\n" +
+	"\"proto/weath/v1alpha2/weather.proto\x12\x10weather.v1alpha2\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa4\x01\n" +
+	"\x11GetCurrentRequest\x12\x14\n" +
+	"\x04city\x18\x01 \x01(\tH\x00R\x04city\x12A\n" +
+	"\vcoordinates\x18\x02 \x01(\v2\x1d.weather.v1alpha2.CoordinatesH\x00R\vcoordinates\x12-\n" +
+	"\x05units\x18\x03 \x01(\x0e2\x17.weather.v1alpha2.UnitsR\x05unitsB\a\n" +
+	"\x05query\"1\n" +
+	"\vCoordinates\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"\xa2\x03\n" +
+	"\aWeather\x12 \n" +
+	"\vtemperature\x18\x01 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x02 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"wind_speed\x18\x04 \x01(\x01R\twindSpeed\x12%\n" +
+	"\x0ewind_direction\x18\x05 \x01(\x01R\rwindDirection\x12\x1a\n" +
+	"\bpressure\x18\x06 \x01(\x01R\bpressure\x12$\n" +
+	"\rprecipitation\x18\a \x01(\x01R\rprecipitation\x12\x1f\n" +
+	"\vcloud_cover\x18\b \x01(\x01R\n" +
+	"cloudCover\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\t \x01(\x01R\n" +
+	"visibility\x12\x19\n" +
+	"\buv_index\x18\n" +
+	" \x01(\x01R\auvIndex\x12;\n" +
+	"\vobserved_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedAt\x12\x16\n" +
+	"\x06source\x18\f \x01(\tR\x06source\"\xf3\x01\n" +
+	"\x12GetCurrentResponse\x123\n" +
+	"\aweather\x18\x01 \x01(\v2\x19.weather.v1alpha2.WeatherR\aweather\x126\n" +
+	"\blocation\x18\x02 \x01(\v2\x1a.weather.v1alpha2.LocationR\blocation\x12-\n" +
+	"\x05units\x18\x03 \x01(\x0e2\x17.weather.v1alpha2.UnitsR\x05units\x12\x14\n" +
+	"\x05stale\x18\x04 \x01(\bR\x05stale\x12+\n" +
+	"\x03age\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x03age\"\x88\x01\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x10\n" +
+	"\x03lat\x18\x04 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x05 \x01(\x01R\x03lon\x12\x1a\n" +
+	"\btimezone\x18\x06 \x01(\tR\btimezone\"3\n" +
+	"\x0fCitySuggestions\x12 \n" +
+	"\vsuggestions\x18\x01 \x03(\tR\vsuggestions\"k\n" +
+	"\x12GetForecastRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\x12-\n" +
+	"\x05units\x18\x03 \x01(\x0e2\x17.weather.v1alpha2.UnitsR\x05units\"\xa0\x01\n" +
+	"\x0eHourlyForecast\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x03 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xcf\x01\n" +
+	"\rDailyForecast\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12'\n" +
+	"\x0fmin_temperature\x18\x02 \x01(\x01R\x0eminTemperature\x12'\n" +
+	"\x0fmax_temperature\x18\x03 \x01(\x01R\x0emaxTemperature\x12\x1a\n" +
+	"\bhumidity\x18\x04 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xb0\x02\n" +
+	"\x13GetForecastResponse\x128\n" +
+	"\x06hourly\x18\x01 \x03(\v2 .weather.v1alpha2.HourlyForecastR\x06hourly\x125\n" +
+	"\x05daily\x18\x02 \x03(\v2\x1f.weather.v1alpha2.DailyForecastR\x05daily\x126\n" +
+	"\blocation\x18\x03 \x01(\v2\x1a.weather.v1alpha2.LocationR\blocation\x12-\n" +
+	"\x05units\x18\x04 \x01(\x0e2\x17.weather.v1alpha2.UnitsR\x05units\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\x12+\n" +
+	"\x03age\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x03age\"S\n" +
+	"\x16GetCurrentBatchRequest\x129\n" +
+	"\x05items\x18\x01 \x03(\v2#.weather.v1alpha2.GetCurrentRequestR\x05items\"\\\n" +
+	"\x17GetCurrentBatchResponse\x12A\n" +
+	"\aresults\x18\x01 \x03(\v2'.weather.v1alpha2.GetCurrentBatchResultR\aresults\"\x99\x01\n" +
+	"\x15GetCurrentBatchResult\x12@\n" +
+	"\acurrent\x18\x01 \x01(\v2$.weather.v1alpha2.GetCurrentResponseH\x00R\acurrent\x124\n" +
+	"\x05error\x18\x02 \x01(\v2\x1c.weather.v1alpha2.BatchErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\\\n" +
+	"\n" +
+	"BatchError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
+	"\vsuggestions\x18\x03 \x03(\tR\vsuggestions\"\xb2\x01\n" +
+	"\x11GetHistoryRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12-\n" +
+	"\x05units\x18\x04 \x01(\x0e2\x17.weather.v1alpha2.UnitsR\x05units\"\\\n" +
+	"\vObservation\x123\n" +
+	"\aweather\x18\x01 \x01(\v2\x19.weather.v1alpha2.WeatherR\aweather\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x05R\asamples\"\xbe\x01\n" +
+	"\x12GetHistoryResponse\x12A\n" +
+	"\fobservations\x18\x01 \x03(\v2\x1d.weather.v1alpha2.ObservationR\fobservations\x126\n" +
+	"\blocation\x18\x02 \x01(\v2\x1a.weather.v1alpha2.LocationR\blocation\x12-\n" +
+	"\x05units\x18\x03 \x01(\x0e2\x17.weather.v1alpha2.UnitsR\x05units\"&\n" +
+	"\x10GetAlertsRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"\xfc\x01\n" +
+	"\x05Alert\x12;\n" +
+	"\bseverity\x18\x01 \x01(\x0e2\x1f.weather.v1alpha2.AlertSeverityR\bseverity\x12\x14\n" +
+	"\x05event\x18\x02 \x01(\tR\x05event\x12\x1a\n" +
+	"\bheadline\x18\x03 \x01(\tR\bheadline\x128\n" +
+	"\teffective\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\teffective\x124\n" +
+	"\aexpires\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\x12\x14\n" +
+	"\x05areas\x18\x06 \x03(\tR\x05areas\"\xd7\x01\n" +
+	"\x11GetAlertsResponse\x12/\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x17.weather.v1alpha2.AlertR\x06alerts\x126\n" +
+	"\blocation\x18\x02 \x01(\v2\x1a.weather.v1alpha2.LocationR\blocation\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x14\n" +
+	"\x05stale\x18\x04 \x01(\bR\x05stale\x12+\n" +
+	"\x03age\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x03age\"*\n" +
+	"\x14GetAirQualityRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"\xa3\x02\n" +
+	"\n" +
+	"AirQuality\x12\x10\n" +
+	"\x03aqi\x18\x01 \x01(\x05R\x03aqi\x12\x13\n" +
+	"\x05pm2_5\x18\x02 \x01(\x01R\x04pm25\x12\x12\n" +
+	"\x04pm10\x18\x03 \x01(\x01R\x04pm10\x12\x0e\n" +
+	"\x02o3\x18\x04 \x01(\x01R\x02o3\x12\x10\n" +
+	"\x03no2\x18\x05 \x01(\x01R\x03no2\x12@\n" +
+	"\x06pollen\x18\x06 \x03(\v2(.weather.v1alpha2.AirQuality.PollenEntryR\x06pollen\x12;\n" +
+	"\vobserved_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedAt\x1a9\n" +
+	"\vPollenEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xe9\x01\n" +
+	"\x15GetAirQualityResponse\x12=\n" +
+	"\vair_quality\x18\x01 \x01(\v2\x1c.weather.v1alpha2.AirQualityR\n" +
+	"airQuality\x126\n" +
+	"\blocation\x18\x02 \x01(\v2\x1a.weather.v1alpha2.LocationR\blocation\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x14\n" +
+	"\x05stale\x18\x04 \x01(\bR\x05stale\x12+\n" +
+	"\x03age\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x03age*D\n" +
+	"\x05Units\x12\x15\n" +
+	"\x11UNITS_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fUNITS_METRIC\x10\x01\x12\x12\n" +
+	"\x0eUNITS_IMPERIAL\x10\x02*\x9d\x01\n" +
+	"\rAlertSeverity\x12\x1e\n" +
+	"\x1aALERT_SEVERITY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ALERT_SEVERITY_MINOR\x10\x01\x12\x1b\n" +
+	"\x17ALERT_SEVERITY_MODERATE\x10\x02\x12\x19\n" +
+	"\x15ALERT_SEVERITY_SEVERE\x10\x03\x12\x1a\n" +
+	"\x16ALERT_SEVERITY_EXTREME\x10\x042\x9b\x05\n" +
+	"\x0eWeatherService\x12W\n" +
+	"\n" +
+	"GetCurrent\x12#.weather.v1alpha2.GetCurrentRequest\x1a$.weather.v1alpha2.GetCurrentResponse\x12Z\n" +
+	"\vGetForecast\x12$.weather.v1alpha2.GetForecastRequest\x1a%.weather.v1alpha2.GetForecastResponse\x12f\n" +
+	"\x0fGetCurrentBatch\x12(.weather.v1alpha2.GetCurrentBatchRequest\x1a).weather.v1alpha2.GetCurrentBatchResponse\x12[\n" +
+	"\fWatchCurrent\x12#.weather.v1alpha2.GetCurrentRequest\x1a$.weather.v1alpha2.GetCurrentResponse0\x01\x12W\n" +
+	"\n" +
+	"GetHistory\x12#.weather.v1alpha2.GetHistoryRequest\x1a$.weather.v1alpha2.GetHistoryResponse\x12T\n" +
+	"\tGetAlerts\x12\".weather.v1alpha2.GetAlertsRequest\x1a#.weather.v1alpha2.GetAlertsResponse\x12`\n" +
+	"\rGetAirQuality\x12&.weather.v1alpha2.GetAirQualityRequest\x1a'.weather.v1alpha2.GetAirQualityResponseBrZpgithub.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2;weatherv1alpha2b\x06proto3"
+
+var (
+	file_proto_weath_v1alpha2_weather_proto_rawDescOnce sync.Once
+	file_proto_weath_v1alpha2_weather_proto_rawDescData []byte
+)
+
+func file_proto_weath_v1alpha2_weather_proto_rawDescGZIP() []byte {
+	file_proto_weath_v1alpha2_weather_proto_rawDescOnce.Do(func() {
+		file_proto_weath_v1alpha2_weather_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_weath_v1alpha2_weather_proto_rawDesc), len(file_proto_weath_v1alpha2_weather_proto_rawDesc)))
+	})
+	return file_proto_weath_v1alpha2_weather_proto_rawDescData
+}
+
+var file_proto_weath_v1alpha2_weather_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_weath_v1alpha2_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_proto_weath_v1alpha2_weather_proto_goTypes = []any{
+	(Units)(0),                      // 0: weather.v1alpha2.Units
+	(AlertSeverity)(0),              // 1: weather.v1alpha2.AlertSeverity
+	(*GetCurrentRequest)(nil),       // 2: weather.v1alpha2.GetCurrentRequest
+	(*Coordinates)(nil),             // 3: weather.v1alpha2.Coordinates
+	(*Weather)(nil),                 // 4: weather.v1alpha2.Weather
+	(*GetCurrentResponse)(nil),      // 5: weather.v1alpha2.GetCurrentResponse
+	(*Location)(nil),                // 6: weather.v1alpha2.Location
+	(*CitySuggestions)(nil),         // 7: weather.v1alpha2.CitySuggestions
+	(*GetForecastRequest)(nil),      // 8: weather.v1alpha2.GetForecastRequest
+	(*HourlyForecast)(nil),          // 9: weather.v1alpha2.HourlyForecast
+	(*DailyForecast)(nil),           // 10: weather.v1alpha2.DailyForecast
+	(*GetForecastResponse)(nil),     // 11: weather.v1alpha2.GetForecastResponse
+	(*GetCurrentBatchRequest)(nil),  // 12: weather.v1alpha2.GetCurrentBatchRequest
+	(*GetCurrentBatchResponse)(nil), // 13: weather.v1alpha2.GetCurrentBatchResponse
+	(*GetCurrentBatchResult)(nil),   // 14: weather.v1alpha2.GetCurrentBatchResult
+	(*BatchError)(nil),              // 15: weather.v1alpha2.BatchError
+	(*GetHistoryRequest)(nil),       // 16: weather.v1alpha2.GetHistoryRequest
+	(*Observation)(nil),             // 17: weather.v1alpha2.Observation
+	(*GetHistoryResponse)(nil),      // 18: weather.v1alpha2.GetHistoryResponse
+	(*GetAlertsRequest)(nil),        // 19: weather.v1alpha2.GetAlertsRequest
+	(*Alert)(nil),                   // 20: weather.v1alpha2.Alert
+	(*GetAlertsResponse)(nil),       // 21: weather.v1alpha2.GetAlertsResponse
+	(*GetAirQualityRequest)(nil),    // 22: weather.v1alpha2.GetAirQualityRequest
+	(*AirQuality)(nil),              // 23: weather.v1alpha2.AirQuality
+	(*GetAirQualityResponse)(nil),   // 24: weather.v1alpha2.GetAirQualityResponse
+	nil,                             // 25: weather.v1alpha2.AirQuality.PollenEntry
+	(*timestamppb.Timestamp)(nil),   // 26: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 27: google.protobuf.Duration
+}
+var file_proto_weath_v1alpha2_weather_proto_depIdxs = []int32{
+	3,  // 0: weather.v1alpha2.GetCurrentRequest.coordinates:type_name -> weather.v1alpha2.Coordinates
+	0,  // 1: weather.v1alpha2.GetCurrentRequest.units:type_name -> weather.v1alpha2.Units
+	26, // 2: weather.v1alpha2.Weather.observed_at:type_name -> google.protobuf.Timestamp
+	4,  // 3: weather.v1alpha2.GetCurrentResponse.weather:type_name -> weather.v1alpha2.Weather
+	6,  // 4: weather.v1alpha2.GetCurrentResponse.location:type_name -> weather.v1alpha2.Location
+	0,  // 5: weather.v1alpha2.GetCurrentResponse.units:type_name -> weather.v1alpha2.Units
+	27, // 6: weather.v1alpha2.GetCurrentResponse.age:type_name -> google.protobuf.Duration
+	0,  // 7: weather.v1alpha2.GetForecastRequest.units:type_name -> weather.v1alpha2.Units
+	26, // 8: weather.v1alpha2.HourlyForecast.time:type_name -> google.protobuf.Timestamp
+	26, // 9: weather.v1alpha2.DailyForecast.date:type_name -> google.protobuf.Timestamp
+	9,  // 10: weather.v1alpha2.GetForecastResponse.hourly:type_name -> weather.v1alpha2.HourlyForecast
+	10, // 11: weather.v1alpha2.GetForecastResponse.daily:type_name -> weather.v1alpha2.DailyForecast
+	6,  // 12: weather.v1alpha2.GetForecastResponse.location:type_name -> weather.v1alpha2.Location
+	0,  // 13: weather.v1alpha2.GetForecastResponse.units:type_name -> weather.v1alpha2.Units
+	27, // 14: weather.v1alpha2.GetForecastResponse.age:type_name -> google.protobuf.Duration
+	2,  // 15: weather.v1alpha2.GetCurrentBatchRequest.items:type_name -> weather.v1alpha2.GetCurrentRequest
+	14, // 16: weather.v1alpha2.GetCurrentBatchResponse.results:type_name -> weather.v1alpha2.GetCurrentBatchResult
+	5,  // 17: weather.v1alpha2.GetCurrentBatchResult.current:type_name -> weather.v1alpha2.GetCurrentResponse
+	15, // 18: weather.v1alpha2.GetCurrentBatchResult.error:type_name -> weather.v1alpha2.BatchError
+	26, // 19: weather.v1alpha2.GetHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	26, // 20: weather.v1alpha2.GetHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	0,  // 21: weather.v1alpha2.GetHistoryRequest.units:type_name -> weather.v1alpha2.Units
+	4,  // 22: weather.v1alpha2.Observation.weather:type_name -> weather.v1alpha2.Weather
+	17, // 23: weather.v1alpha2.GetHistoryResponse.observations:type_name -> weather.v1alpha2.Observation
+	6,  // 24: weather.v1alpha2.GetHistoryResponse.location:type_name -> weather.v1alpha2.Location
+	0,  // 25: weather.v1alpha2.GetHistoryResponse.units:type_name -> weather.v1alpha2.Units
+	1,  // 26: weather.v1alpha2.Alert.severity:type_name -> weather.v1alpha2.AlertSeverity
+	26, // 27: weather.v1alpha2.Alert.effective:type_name -> google.protobuf.Timestamp
+	26, // 28: weather.v1alpha2.Alert.expires:type_name -> google.protobuf.Timestamp
+	20, // 29: weather.v1alpha2.GetAlertsResponse.alerts:type_name -> weather.v1alpha2.Alert
+	6,  // 30: weather.v1alpha2.GetAlertsResponse.location:type_name -> weather.v1alpha2.Location
+	27, // 31: weather.v1alpha2.GetAlertsResponse.age:type_name -> google.protobuf.Duration
+	25, // 32: weather.v1alpha2.AirQuality.pollen:type_name -> weather.v1alpha2.AirQuality.PollenEntry
+	26, // 33: weather.v1alpha2.AirQuality.observed_at:type_name -> google.protobuf.Timestamp
+	23, // 34: weather.v1alpha2.GetAirQualityResponse.air_quality:type_name -> weather.v1alpha2.AirQuality
+	6,  // 35: weather.v1alpha2.GetAirQualityResponse.location:type_name -> weather.v1alpha2.Location
+	27, // 36: weather.v1alpha2.GetAirQualityResponse.age:type_name -> google.protobuf.Duration
+	2,  // 37: weather.v1alpha2.WeatherService.GetCurrent:input_type -> weather.v1alpha2.GetCurrentRequest
+	8,  // 38: weather.v1alpha2.WeatherService.GetForecast:input_type -> weather.v1alpha2.GetForecastRequest
+	12, // 39: weather.v1alpha2.WeatherService.GetCurrentBatch:input_type -> weather.v1alpha2.GetCurrentBatchRequest
+	2,  // 40: weather.v1alpha2.WeatherService.WatchCurrent:input_type -> weather.v1alpha2.GetCurrentRequest
+	16, // 41: weather.v1alpha2.WeatherService.GetHistory:input_type -> weather.v1alpha2.GetHistoryRequest
+	19, // 42: weather.v1alpha2.WeatherService.GetAlerts:input_type -> weather.v1alpha2.GetAlertsRequest
+	22, // 43: weather.v1alpha2.WeatherService.GetAirQuality:input_type -> weather.v1alpha2.GetAirQualityRequest
+	5,  // 44: weather.v1alpha2.WeatherService.GetCurrent:output_type -> weather.v1alpha2.GetCurrentResponse
+	11, // 45: weather.v1alpha2.WeatherService.GetForecast:output_type -> weather.v1alpha2.GetForecastResponse
+	13, // 46: weather.v1alpha2.WeatherService.GetCurrentBatch:output_type -> weather.v1alpha2.GetCurrentBatchResponse
+	5,  // 47: weather.v1alpha2.WeatherService.WatchCurrent:output_type -> weather.v1alpha2.GetCurrentResponse
+	18, // 48: weather.v1alpha2.WeatherService.GetHistory:output_type -> weather.v1alpha2.GetHistoryResponse
+	21, // 49: weather.v1alpha2.WeatherService.GetAlerts:output_type -> weather.v1alpha2.GetAlertsResponse
+	24, // 50: weather.v1alpha2.WeatherService.GetAirQuality:output_type -> weather.v1alpha2.GetAirQualityResponse
+	44, // [44:51] is the sub-list for method output_type
+	37, // [37:44] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
+}
+
+func init() { file_proto_weath_v1alpha2_weather_proto_init() }
+func file_proto_weath_v1alpha2_weather_proto_init() {
+	if File_proto_weath_v1alpha2_weather_proto != nil {
+		return
+	}
+	file_proto_weath_v1alpha2_weather_proto_msgTypes[0].OneofWrappers = []any{
+		(*GetCurrentRequest_City)(nil),
+		(*GetCurrentRequest_Coordinates)(nil),
+	}
+	file_proto_weath_v1alpha2_weather_proto_msgTypes[12].OneofWrappers = []any{
+		(*GetCurrentBatchResult_Current)(nil),
+		(*GetCurrentBatchResult_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_weath_v1alpha2_weather_proto_rawDesc), len(file_proto_weath_v1alpha2_weather_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_weath_v1alpha2_weather_proto_goTypes,
+		DependencyIndexes: file_proto_weath_v1alpha2_weather_proto_depIdxs,
+		EnumInfos:         file_proto_weath_v1alpha2_weather_proto_enumTypes,
+		MessageInfos:      file_proto_weath_v1alpha2_weather_proto_msgTypes,
+	}.Build()
+	File_proto_weath_v1alpha2_weather_proto = out.File
+	file_proto_weath_v1alpha2_weather_proto_goTypes = nil
+	file_proto_weath_v1alpha2_weather_proto_depIdxs = nil
+}
@@ -0,0 +1,2 @@
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS units;
+DROP TYPE IF EXISTS Units;
@@ -0,0 +1,3 @@
+CREATE TYPE Units AS ENUM ('metric', 'imperial');
+
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS units Units NOT NULL DEFAULT 'metric';
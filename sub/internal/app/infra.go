@@ -7,7 +7,7 @@ import (
 	"log"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/messaging"
-	pbweath "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	pbweath "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/config"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/domain"
 	brokernotify "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/notifiers/broker"
@@ -28,7 +28,7 @@ const (
 
 type (
 	weatherRepo interface {
-		GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+		GetCurrentBatch(ctx context.Context, queries []domain.WeatherQuery) ([]domain.WeatherResult, error)
 	}
 
 	subscriptionRepo interface {
@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Frequency string
 
@@ -9,17 +13,47 @@ const (
 	FreqHourly Frequency = "hourly"
 )
 
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"
+	UnitsImperial Units = "imperial"
+)
+
 type Subscription struct {
 	ID        uuid.UUID
 	Email     string
 	Frequency string
 	City      string
+	Units     string
 	Activated bool
 	Token     uuid.UUID
 }
 
+// WeatherQuery asks for current weather in a city, expressed in the given units.
+type WeatherQuery struct {
+	City  string
+	Units Units
+}
+
+// WeatherResult is the outcome of one query of a batch, either weather or an error.
+type WeatherResult struct {
+	Weather Weather
+	Err     error
+}
+
 type Weather struct {
-	Temperature float64
-	Humidity    float64
-	Description string
+	Temperature   float64
+	Humidity      float64
+	Description   string
+	WindSpeed     float64
+	WindDirection float64
+	Pressure      float64
+	Precipitation float64
+	CloudCover    float64
+	Visibility    float64
+	UVIndex       float64
+	ObservedAt    time.Time
+	Source        string
+	Units         Units
 }
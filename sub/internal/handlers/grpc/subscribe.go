@@ -27,6 +27,7 @@ func (s *SubGRPCServer) Subscribe(_ context.Context, req *pb.SubscribeRequest) (
 		Email:     req.Email,
 		Frequency: req.Frequency,
 		City:      req.City,
+		Units:     req.Units,
 	})
 	if errors.Is(err, domain.ErrSubAlreadyExists) {
 		return nil, status.Errorf(codes.AlreadyExists, "Email already subscribed")
@@ -53,8 +54,14 @@ func validateSubscribeRequest(req *pb.SubscribeRequest) error {
 
 	switch domain.Frequency(req.Frequency) {
 	case domain.FreqDaily, domain.FreqHourly:
-		return nil
 	default:
 		return errors.New("frequency must be either 'daily' or 'hourly'")
 	}
+
+	switch domain.Units(req.Units) {
+	case "", domain.UnitsMetric, domain.UnitsImperial:
+		return nil
+	default:
+		return errors.New("units must be either 'metric' or 'imperial'")
+	}
 }
@@ -54,6 +54,23 @@ func TestSubGRPCServer_Subscribe(t *testing.T) {
 		assert.Equal(t, "Successfully subscribed", resp.Message)
 	})
 
+	t.Run("InvalidUnits", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewSubGRPCServer(&mockSubService{})
+
+		// Act
+		_, err := srv.Subscribe(context.Background(), &pb.SubscribeRequest{
+			Email:     "test@example.com",
+			City:      "Kyiv",
+			Frequency: "daily",
+			Units:     "kelvin",
+		})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
 	t.Run("EmptyRequest", func(t *testing.T) {
 		// Arrange
 		srv := handlers.NewSubGRPCServer(&mockSubService{})
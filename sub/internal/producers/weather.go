@@ -25,9 +25,19 @@ func (p *WeatherNotifyCommandProducer) Produce(sub domain.Subscription, weath do
 		Email: sub.Email,
 		Token: sub.Token.String(),
 		Weather: messaging.Weather{
-			Temperature: weath.Temperature,
-			Humidity:    weath.Humidity,
-			Description: weath.Description,
+			Temperature:   weath.Temperature,
+			Humidity:      weath.Humidity,
+			Description:   weath.Description,
+			WindSpeed:     weath.WindSpeed,
+			WindDirection: weath.WindDirection,
+			Pressure:      weath.Pressure,
+			Precipitation: weath.Precipitation,
+			CloudCover:    weath.CloudCover,
+			Visibility:    weath.Visibility,
+			UVIndex:       weath.UVIndex,
+			ObservedAt:    weath.ObservedAt,
+			Source:        weath.Source,
+			Units:         string(weath.Units),
 		},
 	}
 	body, err := json.Marshal(event)
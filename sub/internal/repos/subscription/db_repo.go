@@ -26,13 +26,14 @@ func NewDBRepo(db *sql.DB) *DBRepo {
 
 func (r *DBRepo) Create(subscription domain.Subscription) error {
 	_, err := r.db.Exec(`
-		INSERT INTO subscriptions (id, email, frequency, city, activated, token)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO subscriptions (id, email, frequency, city, units, activated, token)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		`,
 		subscription.ID,
 		subscription.Email,
 		subscription.Frequency,
 		subscription.City,
+		subscription.Units,
 		subscription.Activated,
 		subscription.Token,
 	)
@@ -84,7 +85,10 @@ func (r *DBRepo) DeleteByToken(token uuid.UUID) error {
 }
 
 func (r *DBRepo) GetActivatedByFreq(freq domain.Frequency) ([]domain.Subscription, error) {
-	rows, err := r.db.Query("SELECT * FROM subscriptions WHERE activated = true AND frequency = $1", freq)
+	rows, err := r.db.Query(`
+		SELECT id, email, frequency, city, units, activated, token
+		FROM subscriptions WHERE activated = true AND frequency = $1
+		`, freq)
 	if err != nil {
 		log.Printf("subscription repo: select: %v\n", err)
 		return nil, fmt.Errorf("subscription repo: %w", domain.ErrInternal)
@@ -102,6 +106,7 @@ func (r *DBRepo) GetActivatedByFreq(freq domain.Frequency) ([]domain.Subscriptio
 			&subscription.Email,
 			&subscription.Frequency,
 			&subscription.City,
+			&subscription.Units,
 			&subscription.Activated,
 			&subscription.Token,
 		); err != nil {
@@ -40,15 +40,16 @@ func TestCreateSubscription_Success(t *testing.T) {
 		Email:     "test@example.com",
 		Frequency: string(domain.FreqDaily),
 		City:      "Kyiv",
+		Units:     string(domain.UnitsImperial),
 		Activated: false,
 		Token:     uuid.New(),
 	}
 	mock.ExpectExec(
 		regexp.QuoteMeta(
-			`INSERT INTO subscriptions (id, email, frequency, city, activated, token)`+
-				` VALUES ($1, $2, $3, $4, $5, $6)`),
+			`INSERT INTO subscriptions (id, email, frequency, city, units, activated, token)`+
+				` VALUES ($1, $2, $3, $4, $5, $6, $7)`),
 	).
-		WithArgs(sub.ID, sub.Email, sub.Frequency, sub.City, sub.Activated, sub.Token).
+		WithArgs(sub.ID, sub.Email, sub.Frequency, sub.City, sub.Units, sub.Activated, sub.Token).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Act
@@ -70,6 +71,7 @@ func TestCreateSubscription_EmailExists(t *testing.T) {
 		Email:     "exists@example.com",
 		Frequency: string(domain.FreqDaily),
 		City:      "Lviv",
+		Units:     string(domain.UnitsMetric),
 		Activated: false,
 		Token:     uuid.New(),
 	}
@@ -77,11 +79,11 @@ func TestCreateSubscription_EmailExists(t *testing.T) {
 
 	mock.ExpectExec(
 		regexp.QuoteMeta(
-			`INSERT INTO subscriptions (id, email, frequency, city, activated, token) `+
-				` VALUES ($1, $2, $3, $4, $5, $6)`,
+			`INSERT INTO subscriptions (id, email, frequency, city, units, activated, token) `+
+				` VALUES ($1, $2, $3, $4, $5, $6, $7)`,
 		),
 	).
-		WithArgs(sub.ID, sub.Email, sub.Frequency, sub.City, sub.Activated, sub.Token).
+		WithArgs(sub.ID, sub.Email, sub.Frequency, sub.City, sub.Units, sub.Activated, sub.Token).
 		WillReturnError(pqErr)
 
 	// Act
@@ -188,12 +190,15 @@ func TestGetActivatedSubscriptionsByFreq_Success(t *testing.T) {
 
 	freq := domain.FreqDaily
 
-	rows := sqlmock.NewRows([]string{"id", "email", "frequency", "city", "activated", "token"}).
-		AddRow(uuid.New(), "user1@example.com", freq, "Kyiv", true, uuid.New()).
-		AddRow(uuid.New(), "user2@example.com", freq, "Lviv", true, uuid.New())
+	rows := sqlmock.NewRows([]string{"id", "email", "frequency", "city", "units", "activated", "token"}).
+		AddRow(uuid.New(), "user1@example.com", freq, "Kyiv", "metric", true, uuid.New()).
+		AddRow(uuid.New(), "user2@example.com", freq, "Lviv", "imperial", true, uuid.New())
 
 	mock.ExpectQuery(
-		regexp.QuoteMeta(`SELECT * FROM subscriptions WHERE activated = true AND frequency = $1`),
+		regexp.QuoteMeta(
+			`SELECT id, email, frequency, city, units, activated, token` +
+				` FROM subscriptions WHERE activated = true AND frequency = $1`,
+		),
 	).
 		WithArgs(freq).
 		WillReturnRows(rows)
@@ -204,6 +209,7 @@ func TestGetActivatedSubscriptionsByFreq_Success(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	assert.Len(t, subs, 2)
+	assert.Equal(t, string(domain.UnitsImperial), subs[1].Units)
 }
 
 func TestGetActivatedSubscriptionsByFreq_QueryError(t *testing.T) {
@@ -215,7 +221,10 @@ func TestGetActivatedSubscriptionsByFreq_QueryError(t *testing.T) {
 	repo := subr.NewDBRepo(db)
 	freq := domain.FreqDaily
 	mock.ExpectQuery(
-		regexp.QuoteMeta(`SELECT * FROM subscriptions WHERE activated = true AND frequency = $1`),
+		regexp.QuoteMeta(
+			`SELECT id, email, frequency, city, units, activated, token` +
+				` FROM subscriptions WHERE activated = true AND frequency = $1`,
+		),
 	).
 		WithArgs(freq).
 		WillReturnError(errors.New("query error"))
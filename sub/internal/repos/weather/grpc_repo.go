@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"log"
 
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/domain"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// batchChunkSize is how many queries go in one call, well below the 1000 the weather service accepts,
+// so a failed call costs few results.
+const batchChunkSize = 100
+
 type GRPCRepo struct {
 	client pb.WeatherServiceClient
 }
@@ -19,26 +23,95 @@ func NewGRPCRepo(client pb.WeatherServiceClient) *GRPCRepo {
 	return &GRPCRepo{client: client}
 }
 
-func (s *GRPCRepo) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	req := pb.GetCurrentRequest{
-		City: city,
+// GetCurrentBatch fetches current weather for all queries, results are in the order of queries.
+// Queries are sent in chunks of batchChunkSize, the queries of a failed call get its error as their result
+// and the other chunks are still sent; an error is returned only if every call fails.
+func (s *GRPCRepo) GetCurrentBatch(ctx context.Context, queries []domain.WeatherQuery) ([]domain.WeatherResult, error) {
+	results := make([]domain.WeatherResult, 0, len(queries))
+	var lastErr error
+	failed := 0
+	for start := 0; start < len(queries); start += batchChunkSize {
+		chunk := queries[start:min(start+batchChunkSize, len(queries))]
+		chunkResults, err := s.getCurrentChunk(ctx, chunk)
+		if err != nil {
+			lastErr = err
+			failed += len(chunk)
+			for range chunk {
+				results = append(results, domain.WeatherResult{Err: err})
+			}
+			continue
+		}
+		results = append(results, chunkResults...)
+	}
+	if failed == len(queries) && lastErr != nil {
+		return nil, lastErr
+	}
+	return results, nil
+}
+
+// getCurrentChunk sends a single batch call for chunk.
+func (s *GRPCRepo) getCurrentChunk(ctx context.Context, chunk []domain.WeatherQuery) ([]domain.WeatherResult, error) {
+	req := &pb.GetCurrentBatchRequest{Items: make([]*pb.GetCurrentRequest, 0, len(chunk))}
+	for _, q := range chunk {
+		req.Items = append(req.Items, &pb.GetCurrentRequest{
+			Query: &pb.GetCurrentRequest_City{City: q.City},
+			Units: domainToPBUnits(q.Units),
+		})
 	}
-	resp, err := s.client.GetCurrent(ctx, &req)
+
+	resp, err := s.client.GetCurrentBatch(ctx, req)
 	if err != nil {
 		st, ok := status.FromError(err)
 		if !ok {
 			log.Println(fmt.Errorf("grpc adapter: %v", err))
-			return domain.Weather{}, fmt.Errorf("grpc adapter: %w", domain.ErrInternal)
+			return nil, fmt.Errorf("grpc adapter: %w", domain.ErrInternal)
 		}
 
 		log.Println(fmt.Errorf("grpc adapter: %s", st.Message()))
-		return domain.Weather{}, fmt.Errorf("grpc adapter: %w", gRPCToDomainError(st.Code()))
+		return nil, fmt.Errorf("grpc adapter: %w", gRPCToDomainError(st.Code()))
+	}
+	if len(resp.Results) != len(chunk) {
+		log.Printf("grpc adapter: expected %d batch results, got %d\n", len(chunk), len(resp.Results))
+		return nil, fmt.Errorf("grpc adapter: %w", domain.ErrInternal)
+	}
+	results := make([]domain.WeatherResult, 0, len(chunk))
+	for i, r := range resp.Results {
+		results = append(results, pbToDomainResult(r, chunk[i].Units))
+	}
+	return results, nil
+}
+
+func pbToDomainResult(r *pb.GetCurrentBatchResult, units domain.Units) domain.WeatherResult {
+	if batchErr := r.GetError(); batchErr != nil {
+		code := codes.Code(batchErr.GetCode())
+		return domain.WeatherResult{Err: fmt.Errorf("grpc adapter: %s: %w", batchErr.GetMessage(), gRPCToDomainError(code))}
+	}
+	weather := r.GetCurrent().GetWeather()
+	if weather == nil {
+		return domain.WeatherResult{Err: fmt.Errorf("grpc adapter: %w", domain.ErrInternal)}
+	}
+	return domain.WeatherResult{Weather: domain.Weather{
+		Temperature:   weather.GetTemperature(),
+		Humidity:      weather.GetHumidity(),
+		Description:   weather.GetDescription(),
+		WindSpeed:     weather.GetWindSpeed(),
+		WindDirection: weather.GetWindDirection(),
+		Pressure:      weather.GetPressure(),
+		Precipitation: weather.GetPrecipitation(),
+		CloudCover:    weather.GetCloudCover(),
+		Visibility:    weather.GetVisibility(),
+		UVIndex:       weather.GetUvIndex(),
+		ObservedAt:    weather.GetObservedAt().AsTime(),
+		Source:        weather.GetSource(),
+		Units:         units,
+	}}
+}
+
+func domainToPBUnits(units domain.Units) pb.Units {
+	if units == domain.UnitsImperial {
+		return pb.Units_UNITS_IMPERIAL
 	}
-	return domain.Weather{
-		Humidity:    float64(resp.Humidity),
-		Temperature: float64(resp.Temperature),
-		Description: resp.Description,
-	}, nil
+	return pb.Units_UNITS_METRIC
 }
 
 func gRPCToDomainError(code codes.Code) error {
@@ -0,0 +1,82 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/domain"
+	weathr "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/repos/weather"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockWeatherClient struct {
+	pb.WeatherServiceClient
+	calls   int
+	failing map[int]bool
+}
+
+func (m *mockWeatherClient) GetCurrentBatch(
+	ctx context.Context, req *pb.GetCurrentBatchRequest, opts ...grpc.CallOption,
+) (*pb.GetCurrentBatchResponse, error) {
+	m.calls++
+	if m.failing[m.calls] {
+		return nil, status.Error(codes.Unavailable, "weather unavailable")
+	}
+	resp := &pb.GetCurrentBatchResponse{Results: make([]*pb.GetCurrentBatchResult, len(req.Items))}
+	for i := range req.Items {
+		resp.Results[i] = &pb.GetCurrentBatchResult{Result: &pb.GetCurrentBatchResult_Current{
+			Current: &pb.GetCurrentResponse{Weather: &pb.Weather{Temperature: 20}},
+		}}
+	}
+	return resp, nil
+}
+
+func newQueries(n int) []domain.WeatherQuery {
+	queries := make([]domain.WeatherQuery, n)
+	for i := range queries {
+		queries[i] = domain.WeatherQuery{City: fmt.Sprintf("city-%d", i)}
+	}
+	return queries
+}
+
+func TestGRPCRepo_GetCurrentBatch_FailedChunkKeepsOtherResults(t *testing.T) {
+	// Arrange
+	client := &mockWeatherClient{failing: map[int]bool{2: true}}
+	repo := weathr.NewGRPCRepo(client)
+
+	// Act
+	results, err := repo.GetCurrentBatch(context.Background(), newQueries(250))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 250)
+	assert.Equal(t, 3, client.calls)
+	for i, r := range results {
+		if i >= 100 && i < 200 {
+			assert.ErrorIs(t, r.Err, domain.ErrWeatherUnavailable)
+			continue
+		}
+		require.NoError(t, r.Err)
+		assert.Equal(t, 20.0, r.Weather.Temperature)
+	}
+}
+
+func TestGRPCRepo_GetCurrentBatch_AllChunksFail(t *testing.T) {
+	// Arrange
+	client := &mockWeatherClient{failing: map[int]bool{1: true, 2: true}}
+	repo := weathr.NewGRPCRepo(client)
+
+	// Act
+	_, err := repo.GetCurrentBatch(context.Background(), newQueries(150))
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+}
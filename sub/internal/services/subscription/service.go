@@ -19,6 +19,7 @@ type SubscriptionInput struct {
 	Email     string
 	Frequency string
 	City      string
+	Units     string
 }
 
 type SubscriptionService struct {
@@ -31,11 +32,15 @@ func NewSubscriptionService(repo SubscriptionRepo, mailer confirmationMailer) *S
 }
 
 func (s *SubscriptionService) Subscribe(subInput SubscriptionInput) error {
+	if subInput.Units == "" {
+		subInput.Units = string(domain.UnitsMetric)
+	}
 	subscription := domain.Subscription{
 		ID:        uuid.New(),
 		Email:     subInput.Email,
 		Frequency: subInput.Frequency,
 		City:      subInput.City,
+		Units:     subInput.Units,
 		Activated: false,
 		Token:     uuid.New(),
 	}
@@ -14,9 +14,11 @@ import (
 
 type mockSubscriptionRepo struct {
 	createErr error
+	created   domain.Subscription
 }
 
 func (m *mockSubscriptionRepo) Create(sub domain.Subscription) error {
+	m.created = sub
 	return m.createErr
 }
 
@@ -67,3 +69,34 @@ func TestSubscriptionService_Subscribe(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriptionService_Subscribe_Units(t *testing.T) {
+	tests := []struct {
+		name     string
+		units    string
+		expected string
+	}{
+		{"default", "", string(domain.UnitsMetric)},
+		{"imperial", string(domain.UnitsImperial), string(domain.UnitsImperial)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := &mockSubscriptionRepo{}
+			service := subsvc.NewSubscriptionService(repo, &mockMailer{})
+
+			// Act
+			err := service.Subscribe(subsvc.SubscriptionInput{
+				Email:     "test@example.com",
+				Frequency: "daily",
+				City:      "Kyiv",
+				Units:     tt.units,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, repo.created.Units)
+		})
+	}
+}
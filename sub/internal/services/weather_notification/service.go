@@ -17,7 +17,7 @@ type weatherMailer interface {
 }
 
 type weatherRepo interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+	GetCurrentBatch(ctx context.Context, queries []domain.WeatherQuery) ([]domain.WeatherResult, error)
 }
 
 type WeatherNotificationService struct {
@@ -44,15 +44,35 @@ func (s *WeatherNotificationService) SendByFreq(freq domain.Frequency) {
 		log.Println(fmt.Errorf("weather notification service: %v ", err))
 		return
 	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	// subscribers of the same city and units share one query
+	queryIdx := make(map[domain.WeatherQuery]int)
+	queries := make([]domain.WeatherQuery, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		q := domain.WeatherQuery{City: sub.City, Units: domain.Units(sub.Units)}
+		if _, ok := queryIdx[q]; !ok {
+			queryIdx[q] = len(queries)
+			queries = append(queries, q)
+		}
+	}
+	results, err := s.weatherRepo.GetCurrentBatch(context.Background(), queries)
+	if err != nil {
+		log.Println(fmt.Errorf("weather notification service: %v ", err))
+		return
+	}
+
 	for _, sub := range subscriptions {
-		weather, err := s.weatherRepo.GetCurrent(context.Background(), sub.City)
-		if err != nil {
+		result := results[queryIdx[domain.WeatherQuery{City: sub.City, Units: domain.Units(sub.Units)}]]
+		if result.Err != nil {
 			err = fmt.Errorf("weather notification service: failed to get weather for %s, err:%v ",
-				sub.City, err)
+				sub.City, result.Err)
 			log.Println(err)
 			continue
 		}
-		if err := s.weatherMailer.SendCurrent(sub, weather); err != nil {
+		if err := s.weatherMailer.SendCurrent(sub, result.Weather); err != nil {
 			err = fmt.Errorf("weather notification service: failed to send email to %s, err:%v ",
 				sub.Email, err)
 			log.Println(err)
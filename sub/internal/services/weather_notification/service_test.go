@@ -0,0 +1,93 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/domain"
+	weathnotify "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/sub/internal/services/weather_notification"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSubsRepo struct {
+	subs []domain.Subscription
+	err  error
+}
+
+func (m *mockSubsRepo) GetActivatedByFreq(freq domain.Frequency) ([]domain.Subscription, error) {
+	return m.subs, m.err
+}
+
+type mockWeatherRepo struct {
+	calls   int
+	queries []domain.WeatherQuery
+	results map[domain.WeatherQuery]domain.WeatherResult
+}
+
+func (m *mockWeatherRepo) GetCurrentBatch(ctx context.Context, queries []domain.WeatherQuery) ([]domain.WeatherResult, error) {
+	m.calls++
+	m.queries = queries
+	results := make([]domain.WeatherResult, len(queries))
+	for i, q := range queries {
+		results[i] = m.results[q]
+	}
+	return results, nil
+}
+
+type mockWeatherMailer struct {
+	sent map[string]domain.Weather
+}
+
+func (m *mockWeatherMailer) SendCurrent(sub domain.Subscription, weather domain.Weather) error {
+	m.sent[sub.Email] = weather
+	return nil
+}
+
+func TestWeatherNotificationService_SendByFreq(t *testing.T) {
+	// Arrange
+	kyivMetric := domain.WeatherQuery{City: "Kyiv", Units: domain.UnitsMetric}
+	kyivImperial := domain.WeatherQuery{City: "Kyiv", Units: domain.UnitsImperial}
+	nowhere := domain.WeatherQuery{City: "Nowhere", Units: domain.UnitsMetric}
+	subsRepo := &mockSubsRepo{subs: []domain.Subscription{
+		{Email: "a@example.com", City: "Kyiv", Units: "metric"},
+		{Email: "b@example.com", City: "Kyiv", Units: "metric"},
+		{Email: "c@example.com", City: "Kyiv", Units: "imperial"},
+		{Email: "d@example.com", City: "Nowhere", Units: "metric"},
+	}}
+	weatherRepo := &mockWeatherRepo{results: map[domain.WeatherQuery]domain.WeatherResult{
+		kyivMetric:   {Weather: domain.Weather{Temperature: 20}},
+		kyivImperial: {Weather: domain.Weather{Temperature: 68}},
+		nowhere:      {Err: domain.ErrCityNotFound},
+	}}
+	mailer := &mockWeatherMailer{sent: make(map[string]domain.Weather)}
+	service := weathnotify.NewWeatherNotificationService(subsRepo, mailer, weatherRepo)
+
+	// Act
+	service.SendByFreq(domain.FreqDaily)
+
+	// Assert
+	assert.Equal(t, 1, weatherRepo.calls, "weather should be fetched with a single batch call")
+	assert.Equal(t, []domain.WeatherQuery{kyivMetric, kyivImperial, nowhere}, weatherRepo.queries)
+	assert.Equal(t, map[string]domain.Weather{
+		"a@example.com": {Temperature: 20},
+		"b@example.com": {Temperature: 20},
+		"c@example.com": {Temperature: 68},
+	}, mailer.sent)
+}
+
+func TestWeatherNotificationService_SendByFreq_RepoError(t *testing.T) {
+	// Arrange
+	weatherRepo := &mockWeatherRepo{}
+	mailer := &mockWeatherMailer{sent: make(map[string]domain.Weather)}
+	service := weathnotify.NewWeatherNotificationService(&mockSubsRepo{err: errors.New("db down")}, mailer, weatherRepo)
+
+	// Act
+	service.SendByFreq(domain.FreqDaily)
+
+	// Assert
+	assert.Zero(t, weatherRepo.calls)
+	assert.Empty(t, mailer.sent)
+}
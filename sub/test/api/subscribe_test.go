@@ -73,6 +73,11 @@ func TestSubscribeSuccessFlow(t *testing.T) {
 	require.NoError(t, err, "Failed to query subscription activation status: %v", err)
 	t.Logf("Subscription activated status: %v", activated)
 	require.False(t, activated, "Expected subscription to be not activated, got activated = true")
+
+	var units string
+	err = DB.QueryRow("SELECT units FROM subscriptions WHERE email = $1", toEmail).Scan(&units)
+	require.NoError(t, err, "Failed to query subscription units: %v", err)
+	require.Equal(t, "metric", units, "Expected units to default to metric, got %s", units)
 }
 
 func TestSubscribeDuplicateFlow(t *testing.T) {
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS weather_observations_downsampled;
+DROP TABLE IF EXISTS weather_observations;
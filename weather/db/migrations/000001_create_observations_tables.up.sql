@@ -0,0 +1,40 @@
+-- metric columns are NULL when the provider did not report them
+CREATE TABLE IF NOT EXISTS weather_observations (
+    location_key VARCHAR(64) NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    temperature DOUBLE PRECISION,
+    humidity DOUBLE PRECISION,
+    description VARCHAR(255) NOT NULL,
+    wind_speed DOUBLE PRECISION,
+    wind_direction DOUBLE PRECISION,
+    pressure DOUBLE PRECISION,
+    precipitation DOUBLE PRECISION,
+    cloud_cover DOUBLE PRECISION,
+    visibility DOUBLE PRECISION,
+    uv_index DOUBLE PRECISION,
+    PRIMARY KEY (location_key, provider, observed_at)
+);
+
+CREATE INDEX IF NOT EXISTS weather_observations_observed_at_idx ON weather_observations (observed_at);
+
+-- readings older than the raw retention, averaged per provider over one downsampling interval
+CREATE TABLE IF NOT EXISTS weather_observations_downsampled (
+    location_key VARCHAR(64) NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    temperature DOUBLE PRECISION,
+    humidity DOUBLE PRECISION,
+    description VARCHAR(255) NOT NULL,
+    wind_speed DOUBLE PRECISION,
+    wind_direction DOUBLE PRECISION,
+    pressure DOUBLE PRECISION,
+    precipitation DOUBLE PRECISION,
+    cloud_cover DOUBLE PRECISION,
+    visibility DOUBLE PRECISION,
+    uv_index DOUBLE PRECISION,
+    samples INTEGER NOT NULL,
+    PRIMARY KEY (location_key, provider, observed_at)
+);
+
+CREATE INDEX IF NOT EXISTS weather_observations_downsampled_observed_at_idx ON weather_observations_downsampled (observed_at);
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"net"
@@ -9,8 +10,11 @@ import (
 	"os"
 	"time"
 
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/metrics"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/gazetteer"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
@@ -29,17 +33,25 @@ var (
 )
 
 type appMetrics struct {
-	weather *metrics.WeatherMetrics
+	weather   *metrics.WeatherMetrics
+	providers *metrics.ProviderMetrics
 }
 
 type App struct {
 	cfg *config.Config
 
-	redisClient *redis.Client
-	httpSrv     *http.Server
-	grpcSrv     *grpc.Server
-	reposLogger *log.Logger
-	metrics     appMetrics
+	redisClient      *redis.Client
+	db               *sql.DB
+	cacheInvalidator *cache.RedisInvalidator
+	warmupService    *services.WarmupService
+	adminService     *services.AdminService
+	historyService   *services.HistoryService
+	historyDone      chan struct{}
+	httpSrv          *http.Server
+	grpcSrv          *grpc.Server
+	reposLogger      *log.Logger
+	metrics          appMetrics
+	gazetteer        *gazetteer.Gazetteer
 }
 
 func New(cfg *config.Config) *App {
@@ -60,6 +72,14 @@ func (a *App) Run(ctx context.Context) error {
 
 	// metrics
 	a.metrics.weather = metrics.NewWeatherMetrics(appMetricsRegister)
+	a.metrics.providers = metrics.NewProviderMetrics(appMetricsRegister)
+
+	// gazetteer
+	a.gazetteer, err = a.setupGazetteer()
+	if err != nil {
+		return err
+	}
+	log.Println("Gazetteer loaded")
 
 	// redis
 	a.redisClient = redis.NewClient(&redis.Options{
@@ -67,6 +87,32 @@ func (a *App) Run(ctx context.Context) error {
 		Password: a.cfg.Redis.Pass,
 	})
 	log.Println("Redis connected")
+	a.cacheInvalidator, err = cache.NewRedisInvalidator(a.redisClient, cacheInvalidationChannel)
+	if err != nil {
+		return err
+	}
+	go a.cacheInvalidator.Listen(ctx)
+
+	// weather history, set up before the providers, which record their readings in it
+	if a.cfg.History.Enabled {
+		a.historyService, err = a.setupHistory()
+		if err != nil {
+			return err
+		}
+		a.historyDone = make(chan struct{})
+		go func() {
+			a.historyService.Run(ctx)
+			close(a.historyDone)
+		}()
+		log.Printf("Weather history started, kept for %v, downsampled every %v after that",
+			a.cfg.History.Retention, a.cfg.History.DownsampleInterval)
+	}
+
+	// grpc api, set up first because the admin http api works on its providers and cache
+	a.grpcSrv, err = a.setupGRPCSrv()
+	if err != nil {
+		return err
+	}
 
 	// http api
 	router := a.setupRouter()
@@ -82,12 +128,11 @@ func (a *App) Run(ctx context.Context) error {
 	}()
 	log.Printf("HTTP api started on port %s", a.cfg.HTTPSrv.Port)
 
-	// grpc api
+	// grpc serving
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%s", a.cfg.GRPCSrv.Host, a.cfg.GRPCSrv.Port))
 	if err != nil {
 		return err
 	}
-	a.grpcSrv = a.setupGRPCSrv()
 	go func() {
 		err = a.grpcSrv.Serve(lis)
 		if err != nil {
@@ -95,6 +140,12 @@ func (a *App) Run(ctx context.Context) error {
 		}
 	}()
 
+	// cache warm-up
+	if a.warmupService != nil {
+		go a.warmupService.Run(ctx)
+		log.Printf("Cache warm-up started, %v before every hour", a.cfg.Warmup.Lead)
+	}
+
 	// wait on shutdown signal
 	<-ctx.Done()
 
@@ -134,6 +185,27 @@ func (a *App) shutdown(timeoutCtx context.Context) error {
 		}
 	}
 
+	// weather history, which writes the readings still queued once ctx is done
+	if a.historyDone != nil {
+		select {
+		case <-timeoutCtx.Done():
+			log.Printf("shutdown history timeout: %v", timeoutCtx.Err())
+		case <-a.historyDone:
+			log.Println("Weather history stopped")
+		}
+	}
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			wrapped := fmt.Errorf("shutdown history db: %w", err)
+			log.Println(wrapped)
+			if shutdownErr == nil {
+				shutdownErr = wrapped
+			}
+		} else {
+			log.Println("History db closed")
+		}
+	}
+
 	// redis
 	if a.redisClient != nil {
 		if err := a.redisClient.Close(); err != nil {
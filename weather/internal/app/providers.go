@@ -0,0 +1,173 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/ratelimit"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/chain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/health"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
+)
+
+// providerKind is an adapter a configured provider can use.
+type providerKind struct {
+	needsKey bool
+	// whether the api has weather alerts and air quality, the ones without are skipped for such requests
+	alerts     bool
+	airQuality bool
+	newAPI     func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error)
+}
+
+// providerKinds registers the adapters by the kind providers are configured with.
+var providerKinds = map[string]providerKind{
+	provider.FreeWeatherName: {
+		needsKey:   true,
+		alerts:     true,
+		airQuality: true,
+		newAPI: func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error) {
+			return provider.NewFreeWeatherAPI(apiCfg(cfg), client), nil
+		},
+	},
+	provider.TomorrowIOName: {needsKey: true, newAPI: func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error) {
+		return provider.NewTomorrowAPI(apiCfg(cfg), client), nil
+	}},
+	provider.VisualCrossingName: {
+		needsKey: true,
+		alerts:   true,
+		newAPI: func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error) {
+			return provider.NewVisualCrossingAPI(apiCfg(cfg), client), nil
+		},
+	},
+	provider.OpenMeteoName: {needsKey: false, newAPI: func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error) {
+		return provider.NewOpenMeteoAPI(apiCfg(cfg), client), nil
+	}},
+	provider.OpenWeatherMapName: {
+		needsKey:   true,
+		airQuality: true,
+		newAPI: func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error) {
+			return provider.NewOpenWeatherMapAPI(apiCfg(cfg), client), nil
+		},
+	},
+	provider.JSONKind: {needsKey: false, newAPI: func(cfg config.ProviderConfig, client provider.HTTPClient) (weatherRepo, error) {
+		return provider.NewJSONAPI(apiCfg(cfg), jsonSpec(cfg.Name, cfg.JSON), client)
+	}},
+}
+
+func apiCfg(cfg config.ProviderConfig) provider.APICfg {
+	return provider.APICfg{APIKey: cfg.Key, APIURL: cfg.URL}
+}
+
+// jsonSpec translates the config of a "json" provider to the spec its api is read with.
+func jsonSpec(name string, cfg config.JSONProviderConfig) provider.JSONSpec {
+	return provider.JSONSpec{
+		Name: name,
+		Current: provider.JSONEndpoint{
+			Path:   cfg.Current.Path,
+			Fields: provider.JSONFields{Paths: cfg.Current.Fields, Scale: cfg.Current.Scale},
+		},
+		Forecast: provider.JSONForecastEndpoint{
+			Path: cfg.Forecast.Path,
+			Daily: provider.JSONList{
+				Path:   cfg.Forecast.Daily.List,
+				Fields: provider.JSONFields{Paths: cfg.Forecast.Daily.Fields, Scale: cfg.Forecast.Daily.Scale},
+			},
+			Hourly: provider.JSONList{
+				Path:   cfg.Forecast.Hourly.List,
+				Fields: provider.JSONFields{Paths: cfg.Forecast.Hourly.Fields, Scale: cfg.Forecast.Hourly.Scale},
+			},
+		},
+		Auth:     provider.JSONAuth{In: cfg.Auth.In, Name: cfg.Auth.Name, Prefix: cfg.Auth.Prefix},
+		NotFound: provider.JSONNotFound{Status: cfg.NotFound.Status, Path: cfg.NotFound.Path, Equals: cfg.NotFound.Equals},
+		Errors:   cfg.Errors,
+	}
+}
+
+// setupProviders returns the configured providers in the configured order, each wrapped with history
+// recording when it is enabled, logging, its quotas, retries, its own circuit breaker, an admin switch
+// and metrics, and their admin controls. Providers without alerts or air quality fail such requests
+// before all that.
+func (a *App) setupProviders() ([]chain.WeatherProvider, []services.AdminProvider, error) {
+	retryPolicy := decorator.RetryPolicy{
+		Retries:    a.cfg.Providers.Retries,
+		Backoff:    a.cfg.Providers.RetryBackoff,
+		MaxBackoff: providerRetryMaxBackoff,
+	}
+
+	repos := make([]chain.WeatherProvider, 0, len(a.cfg.Providers.List))
+	controls := make([]services.AdminProvider, 0, len(a.cfg.Providers.List))
+	for _, providerCfg := range a.cfg.Providers.List {
+		name := providerCfg.Name
+		kind, ok := providerKinds[providerCfg.Kind]
+		if !ok {
+			return nil, nil, fmt.Errorf("weather provider %q: unknown kind %q", name, providerCfg.Kind)
+		}
+		if kind.needsKey && providerCfg.Key == "" {
+			return nil, nil, fmt.Errorf("weather provider %q: %s needs an api key", name, providerCfg.Kind)
+		}
+		api, err := kind.newAPI(providerCfg, &http.Client{Timeout: providerCfg.RequestTimeout()})
+		if err != nil {
+			return nil, nil, fmt.Errorf("weather provider %q: %w", name, err)
+		}
+
+		breaker := newProviderBreaker(providerCfg.Breaker)
+		a.metrics.providers.ProviderBreakerState(name, cb.Closed)
+		breaker.OnStateChange(func(from, to cb.State) {
+			log.Printf("circuit breaker of %s: %s -> %s\n", name, from, to)
+			a.metrics.providers.ProviderBreakerState(name, to)
+		})
+		if a.historyService != nil {
+			api = decorator.NewRecordDecorator(api, name, a.historyService)
+		}
+		logged := decorator.NewLogDecorator(api, name, a.reposLogger)
+		limited := decorator.NewRateLimitDecorator(logged, name, a.metrics.providers)
+		if providerCfg.PerMinute > 0 {
+			limited.Limiter = ratelimit.NewTokenBucket(providerCfg.PerMinute)
+		}
+		if providerCfg.DailyQuota > 0 {
+			limited.Budget = ratelimit.NewRedisDailyBudget(a.redisClient, name, providerCfg.DailyQuota)
+		}
+		// every attempt counts against the quota, while the breaker stays outermost, so it sees one outcome
+		// per call however many attempts it took, and calls it rejects neither retry nor use up the quota
+		retried := decorator.NewRetryDecorator(limited, name, retryPolicy, a.metrics.providers)
+		status := health.NewStatus(providerHealthAlpha)
+		guarded := decorator.NewBreakerDecorator(decorator.NewStatusDecorator(retried, status), breaker)
+		switched := decorator.NewSwitchDecorator(guarded, name)
+		if !providerCfg.IsEnabled() {
+			log.Printf("weather provider %s is disabled until enabled through the admin API\n", name)
+			switched.Disable()
+		}
+		var repo chain.WeatherProvider = decorator.NewMetricsDecorator(switched, name, a.metrics.providers)
+		if !kind.alerts {
+			repo = decorator.NewNoAlertsDecorator(repo, name)
+		}
+		if !kind.airQuality {
+			repo = decorator.NewNoAirQualityDecorator(repo, name)
+		}
+		repos = append(repos, repo)
+		controls = append(controls, services.AdminProvider{Name: name, Breaker: breaker, Switch: switched, Stats: status})
+	}
+	return repos, controls, nil
+}
+
+// newProviderBreaker builds a provider circuit breaker, taking the defaults for what cfg leaves zero.
+func newProviderBreaker(cfg config.BreakerConfig) *cb.CircuitBreaker {
+	return cb.NewCircuitBreaker(
+		cmp.Or(cfg.Timeout, weatherCBTimeout),
+		cmp.Or(cfg.MaxFails, weatherCBLimit),
+		cmp.Or(cfg.Recover, weatherCBRecover),
+		cb.WithFailureRate(
+			cmp.Or(cfg.FailureRate, weatherCBFailureRate),
+			cmp.Or(cfg.MinCalls, weatherCBMinCalls),
+			cb.Window{Length: cmp.Or(cfg.Window, weatherCBWindow)},
+		),
+		cb.WithHalfOpenProbes(weatherCBProbes),
+		cb.WithSlowCallThreshold(cmp.Or(cfg.SlowCall, weatherCBSlowCall)),
+	)
+}
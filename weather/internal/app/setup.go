@@ -1,82 +1,253 @@
 package app
 
 import (
-	"net/http"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
 	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
-	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/hub"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	grpch "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	httph "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/http"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/chain"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
-	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/gazetteer"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/health"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/history"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
 	"github.com/gin-gonic/gin"
+	// registers the postgres driver the history is stored with
+	_ "github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
 
 const (
-	freeWeatherName    = "weatherapi.com"
-	tomorrowIOName     = "tomorrow.io"
-	visualCrossingName = "visualcrossing.com"
-
 	confirmSubTmplName    = "confirm_sub.html"
 	weatherRequestTimeout = 10 * time.Second
-	cacheTTL              = 5 * time.Minute
 
-	// CB = CircuitBreaker
+	// cached values are fresh for the soft TTL, then served while being refreshed until the hard TTL,
+	// and after that kept for staleRetention to be served only when providers fail
+	currentSoftTTL  = 5 * time.Minute
+	currentHardTTL  = 15 * time.Minute
+	forecastSoftTTL = 30 * time.Minute
+	forecastHardTTL = 2 * time.Hour
+	alertsSoftTTL   = 5 * time.Minute
+	alertsHardTTL   = 15 * time.Minute
+	airSoftTTL      = 15 * time.Minute
+	airHardTTL      = time.Hour
+	staleRetention  = 24 * time.Hour
+	// values kept in memory by each replica in front of Redis, per kind of value
+	localCacheSize = 10_000
+	// pub/sub channel replicas use to drop each other's outdated in-memory values
+	cacheInvalidationChannel = "weather:cache:invalidate"
+	// bump when the format of cached values changes, so old ones are not decoded
+	cacheSchemaVersion = 2
+	// how long a city providers don't know is answered from the cache
+	negativeCacheTTL = 10 * time.Minute
+
+	// how long a replica may hold the lock for loading a cache entry before others take over
+	cacheLockTTL = weatherRequestTimeout
+	// how many cache misses of a batch request are fetched from providers at once
+	batchParallelism = 8
+	// longest a whole batch request may take, a few rounds of misses that each run into weatherRequestTimeout
+	batchTimeout = 3 * weatherRequestTimeout
+	// how often watched locations are re-read, so expired cache entries get refreshed
+	watchPollInterval = time.Minute
+
+	// weight of the newest call in the rolling provider health scores
+	providerHealthAlpha = 0.2
+	// longest backoff between retries of a provider call
+	providerRetryMaxBackoff = 2 * time.Second
+
+	// readings are written to the history in batches of up to historyBatchSize, at least every historyFlushInterval
+	historyBatchSize     = 100
+	historyFlushInterval = 5 * time.Second
+	// how often history readings past their retention are downsampled or deleted
+	historyMaintenanceInterval = 10 * time.Minute
+	// longest a single history database operation may take, downsampling a backlog included
+	historyDBTimeout = time.Minute
+
+	// CB = CircuitBreaker, defaults for providers whose config does not tune their breaker
 	weatherCBTimeout = 5 * time.Minute
 	weatherCBLimit   = 10
 	weatherCBRecover = 5
+	// besides weatherCBLimit failures in a row, the breaker opens when half of the calls
+	// in the last minute failed, once there were at least weatherCBMinCalls of them
+	weatherCBFailureRate = 0.5
+	weatherCBMinCalls    = 20
+	weatherCBWindow      = time.Minute
+	// recovery is tested by one call at a time
+	weatherCBProbes = 1
+	// successful calls this slow count as failures, well below config.DefaultProviderTimeout,
+	// so a provider that slows down opens its breaker before its calls start timing out
+	weatherCBSlowCall = 2 * time.Second
 )
 
-func (a *App) setupWeatherRepo() *decorator.CacheDecorator {
-	freeWeathR := provider.NewFreeWeatherAPI(
-		provider.APICfg{APIKey: a.cfg.FreeWeather.Key, APIURL: a.cfg.FreeWeather.URL},
-		&http.Client{},
+const (
+	fallbackStrategy  = "fallback"
+	consensusStrategy = "consensus"
+)
+
+type weatherRepo interface {
+	GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error)
+	GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error)
+	GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error)
+	GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error)
+}
+
+func (a *App) setupWeatherRepo(weatherHub *hub.Hub[domain.Weather]) (*decorator.CacheDecorator, []services.AdminProvider, error) {
+	repos, controls, err := a.setupProviders()
+	if err != nil {
+		return nil, nil, err
+	}
+	tracked := make([]health.Provider, 0, len(controls))
+	for _, control := range controls {
+		tracked = append(tracked, health.Provider{Name: control.Name, Breaker: control.Breaker})
+	}
+
+	var weathChain weatherRepo
+	switch a.cfg.Providers.Strategy {
+	case fallbackStrategy:
+		fallbackChain := chain.NewProvidersFallbackChain(a.cfg.Providers.HedgeDelay, a.metrics.providers, nil, repos...)
+		if a.cfg.Providers.Adaptive {
+			fallbackChain.Ranker = health.NewTracker(providerHealthAlpha, a.metrics.providers, tracked...)
+		}
+		weathChain = fallbackChain
+	case consensusStrategy:
+		if a.cfg.Providers.ConsensusTimeout >= weatherRequestTimeout {
+			return nil, nil, fmt.Errorf("consensus timeout %v is not below the %v request timeout",
+				a.cfg.Providers.ConsensusTimeout, weatherRequestTimeout)
+		}
+		weathChain = chain.NewProvidersConsensusChain(a.cfg.Providers.ConsensusTimeout, a.metrics.providers, repos...)
+	default:
+		return nil, nil, fmt.Errorf("unknown providers strategy %q", a.cfg.Providers.Strategy)
+	}
+	publishingChain := decorator.NewPublishDecorator(weathChain, weatherHub)
+
+	weatherCache := cache.NewTiered(
+		cache.NewLRU[cache.Entry[domain.Weather]](localCacheSize, staleRetention),
+		cache.NewRedisCacheClient[cache.Entry[domain.Weather]](a.redisClient, staleRetention,
+			cache.WithNamespace("weather:current", cacheSchemaVersion), cache.WithNegativeTTL(negativeCacheTTL)),
+		a.cacheInvalidator, a.metrics.weather,
 	)
-	tomorrowWeathR := provider.NewTomorrowAPI(
-		provider.APICfg{APIKey: a.cfg.TomorrowWeather.Key, APIURL: a.cfg.TomorrowWeather.URL},
-		&http.Client{},
+	forecastCache := cache.NewTiered(
+		cache.NewLRU[cache.Entry[domain.Forecast]](localCacheSize, staleRetention),
+		cache.NewRedisCacheClient[cache.Entry[domain.Forecast]](a.redisClient, staleRetention,
+			cache.WithNamespace("weather:forecast", cacheSchemaVersion), cache.WithNegativeTTL(negativeCacheTTL)),
+		a.cacheInvalidator, a.metrics.weather,
 	)
-	vcWeathR := provider.NewVisualCrossingAPI(
-		provider.APICfg{APIKey: a.cfg.VisualCrossing.Key, APIURL: a.cfg.VisualCrossing.URL},
-		&http.Client{},
+	alertsCache := cache.NewTiered(
+		cache.NewLRU[cache.Entry[domain.Alerts]](localCacheSize, staleRetention),
+		cache.NewRedisCacheClient[cache.Entry[domain.Alerts]](a.redisClient, staleRetention,
+			cache.WithNamespace("weather:alerts", cacheSchemaVersion), cache.WithNegativeTTL(negativeCacheTTL)),
+		a.cacheInvalidator, a.metrics.weather,
 	)
+	airCache := cache.NewTiered(
+		cache.NewLRU[cache.Entry[domain.AirQuality]](localCacheSize, staleRetention),
+		cache.NewRedisCacheClient[cache.Entry[domain.AirQuality]](a.redisClient, staleRetention,
+			cache.WithNamespace("weather:air", cacheSchemaVersion), cache.WithNegativeTTL(negativeCacheTTL)),
+		a.cacheInvalidator, a.metrics.weather,
+	)
+	cachedRepoChain := decorator.NewCacheDecorator(
+		publishingChain, weatherCache, forecastCache, alertsCache, airCache, a.metrics.weather,
+		cache.NewRedisLocker(a.redisClient, cacheLockTTL),
+		decorator.CacheConfig{
+			Current:          decorator.CacheTTL{Soft: currentSoftTTL, Hard: currentHardTTL},
+			Forecast:         decorator.CacheTTL{Soft: forecastSoftTTL, Hard: forecastHardTTL},
+			Alerts:           decorator.CacheTTL{Soft: alertsSoftTTL, Hard: alertsHardTTL},
+			Air:              decorator.CacheTTL{Soft: airSoftTTL, Hard: airHardTTL},
+			BatchParallelism: batchParallelism,
+			BatchItemTimeout: weatherRequestTimeout,
+			BatchTimeout:     batchTimeout,
+			RefreshTimeout:   weatherRequestTimeout,
+		},
+	)
+	return cachedRepoChain, controls, nil
+}
 
-	logFreeWeathR := decorator.NewLogDecorator(freeWeathR, freeWeatherName, a.reposLogger)
-	logTomorrowR := decorator.NewLogDecorator(tomorrowWeathR, tomorrowIOName, a.reposLogger)
-	logVcWeathR := decorator.NewLogDecorator(vcWeathR, visualCrossingName, a.reposLogger)
-
-	breakerFreeWeathR := decorator.NewBreakerDecorator(logFreeWeathR,
-		cb.NewCircuitBreaker(weatherCBTimeout, weatherCBLimit, weatherCBRecover))
-	breakerTomorrowR := decorator.NewBreakerDecorator(logTomorrowR,
-		cb.NewCircuitBreaker(weatherCBTimeout, weatherCBLimit, weatherCBRecover))
-	breakerVcWeathR := decorator.NewBreakerDecorator(logVcWeathR,
-		cb.NewCircuitBreaker(weatherCBTimeout, weatherCBLimit, weatherCBRecover))
-
-	weathChain := chain.NewProvidersFallbackChain(breakerFreeWeathR, breakerTomorrowR, breakerVcWeathR)
+func (a *App) setupHistory() (*services.HistoryService, error) {
+	db, err := sql.Open(a.cfg.DB.Driver, a.cfg.DB.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("open history db: %w", err)
+	}
+	a.db = db
+	return services.NewHistoryService(history.NewDBRepo(db), services.HistoryConfig{
+		Retention:            a.cfg.History.Retention,
+		DownsampleInterval:   a.cfg.History.DownsampleInterval,
+		DownsampledRetention: a.cfg.History.DownsampledRetention,
+		BatchSize:            historyBatchSize,
+		FlushInterval:        historyFlushInterval,
+		MaintenanceInterval:  historyMaintenanceInterval,
+		Timeout:              historyDBTimeout,
+	}), nil
+}
 
-	redisBackend := cache.NewRedisCacheClient[domain.Weather](a.redisClient, cacheTTL)
-	cachedRepoChain := decorator.NewCacheDecorator(weathChain, redisBackend, a.metrics.weather)
-	return cachedRepoChain
+func (a *App) setupGazetteer() (*gazetteer.Gazetteer, error) {
+	if a.cfg.Gazetteer.Path == "" {
+		return gazetteer.NewBundled()
+	}
+	f, err := os.Open(a.cfg.Gazetteer.Path)
+	if err != nil {
+		return nil, fmt.Errorf("open gazetteer: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Printf("close gazetteer: %v", err)
+		}
+	}()
+	return gazetteer.New(f)
 }
 
 func (a *App) setupRouter() *gin.Engine {
 	router := gin.Default()
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	if a.cfg.Admin.Token == "" {
+		log.Println("ADMIN_TOKEN is not set, admin API disabled")
+		return router
+	}
+	admin := router.Group("/admin", httph.NewAdminAuthMiddleware(a.cfg.Admin.Token))
+	admin.GET("/providers", httph.NewProvidersGETHandler(a.adminService))
+	admin.POST("/providers/:name/breaker/:state", httph.NewBreakerPOSTHandler(a.adminService))
+	admin.POST("/providers/:name/enable", httph.NewProviderSwitchPOSTHandler(a.adminService, true))
+	admin.POST("/providers/:name/disable", httph.NewProviderSwitchPOSTHandler(a.adminService, false))
+	admin.DELETE("/cache", httph.NewCacheDELETEHandler(a.adminService, weatherRequestTimeout))
 	return router
 }
 
-func (a *App) setupGRPCSrv() *grpc.Server {
+func (a *App) setupGRPCSrv() (*grpc.Server, error) {
 	grpcServer := grpc.NewServer()
 
-	weatherRepo := a.setupWeatherRepo()
-	weatherService := services.NewWeatherService(weatherRepo)
+	weatherHub := hub.New[domain.Weather]()
+	cachedRepo, controls, err := a.setupWeatherRepo(weatherHub)
+	if err != nil {
+		return nil, err
+	}
+	a.adminService = services.NewAdminService(cachedRepo, a.gazetteer, a.cacheInvalidator, controls...)
+	demand := services.NewDemand()
+	weatherRepo := decorator.NewDemandDecorator(cachedRepo, demand)
+	if a.cfg.Warmup.Enabled {
+		a.warmupService = services.NewWarmupService(cachedRepo, demand, a.metrics.weather, services.WarmupConfig{
+			Lead: a.cfg.Warmup.Lead,
+			Size: a.cfg.Warmup.Size,
+			Rate: a.cfg.Warmup.Rate,
+		})
+	}
+	weatherService := services.NewWeatherService(weatherRepo, a.gazetteer)
+	watchService := services.NewWatchService(weatherRepo, weatherHub, watchPollInterval)
+	// a watched location is asked for once per watcher, its polls are no demand
+	watchService.PollRepo = cachedRepo
 
-	pb.RegisterWeatherServiceServer(grpcServer, grpch.NewWeatherGRPCServer(weatherService, weatherRequestTimeout))
-	return grpcServer
+	weatherServer := grpch.NewWeatherGRPCServer(weatherService, watchService, weatherRequestTimeout)
+	if a.historyService != nil {
+		weatherServer.History = a.historyService
+	}
+	pb.RegisterWeatherServiceServer(grpcServer, weatherServer)
+	return grpcServer, nil
 }
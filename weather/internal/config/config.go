@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -16,20 +18,32 @@ func (c RedisConfig) Addr() string {
 	return fmt.Sprintf("%s:%s", c.Host, c.Port)
 }
 
+// TomorrowWeatherConfig, FreeWeatherConfig and VisualCrossingConfig configure providers
+// through the environment when PROVIDERS_FILE is not set.
 type TomorrowWeatherConfig struct {
-	Key string `envconfig:"TOMORROW_WEATHER_API_KEY" required:"true"`
-	URL string `envconfig:"TOMORROW_API_BASE_URL" required:"true"`
+	Key string `envconfig:"TOMORROW_WEATHER_API_KEY"`
+	URL string `envconfig:"TOMORROW_API_BASE_URL"`
+	// Most calls a minute and a day, 0 means no limit
+	PerMinute  int `envconfig:"TOMORROW_WEATHER_PER_MINUTE" default:"10"`
+	DailyQuota int `envconfig:"TOMORROW_WEATHER_DAILY_QUOTA" default:"500"`
 }
 
 type FreeWeatherConfig struct {
-	Key string `envconfig:"FREE_WEATHER_API_KEY" required:"true"`
-	URL string `envconfig:"WEATHER_API_BASE_URL" required:"true"`
+	Key string `envconfig:"FREE_WEATHER_API_KEY"`
+	URL string `envconfig:"WEATHER_API_BASE_URL"`
+	// Most calls a minute and a day, 0 means no limit
+	PerMinute  int `envconfig:"FREE_WEATHER_PER_MINUTE" default:"60"`
+	DailyQuota int `envconfig:"FREE_WEATHER_DAILY_QUOTA" default:"30000"`
 }
 
 type VisualCrossingConfig struct {
-	Key string `envconfig:"VISUAL_CROSSING_API_KEY" required:"true"`
-	URL string `envconfig:"VISUAL_CROSSING_API_BASE_URL" required:"true"`
+	Key string `envconfig:"VISUAL_CROSSING_API_KEY"`
+	URL string `envconfig:"VISUAL_CROSSING_API_BASE_URL"`
+	// Most calls a minute and a day, 0 means no limit
+	PerMinute  int `envconfig:"VISUAL_CROSSING_PER_MINUTE" default:"30"`
+	DailyQuota int `envconfig:"VISUAL_CROSSING_DAILY_QUOTA" default:"1000"`
 }
+
 type HTTPConfig struct {
 	Port string `envconfig:"HTTP_PORT" required:"true"`
 	Host string `envconfig:"HTTP_HOST" required:"true"`
@@ -44,6 +58,76 @@ func (c GRPCConfig) Addr() string {
 	return c.Host + ":" + c.Port
 }
 
+type GazetteerConfig struct {
+	// Path to a GeoNames "cities" dump, the bundled city list is used when empty
+	Path string `envconfig:"GAZETTEER_PATH"`
+}
+
+type ProvidersConfig struct {
+	// YAML file listing the providers, replaces PROVIDERS_ORDER and the per provider variables when set
+	File string `envconfig:"PROVIDERS_FILE"`
+	// Providers of the chain in their configured order, read from File or the environment
+	List []ProviderConfig `ignored:"true"`
+	// How provider answers are combined, "fallback" takes the first successful one, "consensus" merges all of them
+	Strategy string `envconfig:"PROVIDERS_STRATEGY" default:"fallback"`
+	// How long the fallback strategy waits for a provider before asking the next one in parallel, 0 disables it
+	HedgeDelay time.Duration `envconfig:"PROVIDERS_HEDGE_DELAY" default:"1s"`
+	// How long the consensus strategy collects answers before merging the ones that arrived, 0 waits for the request deadline
+	ConsensusTimeout time.Duration `envconfig:"PROVIDERS_CONSENSUS_TIMEOUT" default:"3s"`
+	// Providers to use without File, the fallback strategy tries them in this order unless Adaptive is set
+	Order []string `envconfig:"PROVIDERS_ORDER" default:"weatherapi.com,tomorrow.io,visualcrossing.com"`
+	// Reorder providers by their live health score, the configured order only breaks ties
+	Adaptive bool `envconfig:"PROVIDERS_ADAPTIVE" default:"true"`
+	// How many times a provider call that failed for a transient reason is repeated, 0 disables retries
+	Retries int `envconfig:"PROVIDERS_RETRIES" default:"2"`
+	// Backoff before the first retry, doubled before every next one
+	RetryBackoff time.Duration `envconfig:"PROVIDERS_RETRY_BACKOFF" default:"100ms"`
+}
+
+type WarmupConfig struct {
+	// Refresh the cached weather of the most requested locations before every full hour
+	Enabled bool `envconfig:"WARMUP_ENABLED" default:"true"`
+	// How long before the full hour the refresh starts, keep it shorter than the 5m soft TTL of current weather
+	Lead time.Duration `envconfig:"WARMUP_LEAD" default:"3m"`
+	// Most locations refreshed per hour
+	Size int `envconfig:"WARMUP_SIZE" default:"200"`
+	// Most locations refreshed per second, to stay within provider quotas
+	Rate float64 `envconfig:"WARMUP_RATE" default:"2"`
+}
+
+// DBConfig is the Postgres database observations are stored in, required when history is enabled.
+type DBConfig struct {
+	Driver string `envconfig:"DB_DRIVER" default:"postgres"`
+	Host   string `envconfig:"DB_HOST"`
+	Port   string `envconfig:"DB_PORT"`
+	User   string `envconfig:"DB_USER"`
+	Pass   string `envconfig:"DB_PASSWORD"`
+	Name   string `envconfig:"DB_NAME"`
+}
+
+func (c DBConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		c.Host, c.Port, c.User, c.Pass, c.Name,
+	)
+}
+
+type HistoryConfig struct {
+	// Store every reading fetched from providers in the database
+	Enabled bool `envconfig:"HISTORY_ENABLED" default:"false"`
+	// How long readings are kept as they were fetched
+	Retention time.Duration `envconfig:"HISTORY_RETENTION" default:"168h"`
+	// Older readings are averaged into one per provider and interval, 0 deletes them instead
+	DownsampleInterval time.Duration `envconfig:"HISTORY_DOWNSAMPLE_INTERVAL" default:"1h"`
+	// How long downsampled readings are kept
+	DownsampledRetention time.Duration `envconfig:"HISTORY_DOWNSAMPLED_RETENTION" default:"8760h"`
+}
+
+type AdminConfig struct {
+	// Bearer token for the admin API, the API is disabled when empty
+	Token string `envconfig:"ADMIN_TOKEN"`
+}
+
 type Config struct {
 	GRPCSrv GRPCConfig
 	HTTPSrv HTTPConfig
@@ -52,6 +136,13 @@ type Config struct {
 	TomorrowWeather TomorrowWeatherConfig
 	FreeWeather     FreeWeatherConfig
 	VisualCrossing  VisualCrossingConfig
+	Providers       ProvidersConfig
+	Warmup          WarmupConfig
+	Admin           AdminConfig
+	History         HistoryConfig
+	DB              DBConfig
+
+	Gazetteer GazetteerConfig
 }
 
 func Load() (*Config, error) {
@@ -60,5 +151,39 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	var providers []ProviderConfig
+	var err error
+	if сfg.Providers.File != "" {
+		providers, err = loadProviders(сfg.Providers.File)
+	} else {
+		providers, err = сfg.legacyProviders()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeProviders(providers); err != nil {
+		return nil, err
+	}
+	сfg.Providers.List = providers
+
+	if сfg.History.Enabled {
+		if err := validateHistory(сfg.History, сfg.DB); err != nil {
+			return nil, err
+		}
+	}
+
 	return &сfg, nil
 }
+
+func validateHistory(history HistoryConfig, db DBConfig) error {
+	if db.Host == "" || db.Port == "" || db.User == "" || db.Name == "" {
+		return errors.New("history is enabled, but DB_HOST, DB_PORT, DB_USER or DB_NAME is not set")
+	}
+	if history.Retention <= 0 || history.DownsampledRetention <= 0 {
+		return errors.New("HISTORY_RETENTION and HISTORY_DOWNSAMPLED_RETENTION must be positive")
+	}
+	if history.DownsampleInterval < 0 || history.DownsampleInterval%time.Second != 0 {
+		return errors.New("HISTORY_DOWNSAMPLE_INTERVAL must be a whole number of seconds")
+	}
+	return nil
+}
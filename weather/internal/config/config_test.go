@@ -0,0 +1,67 @@
+//go:build unit
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setHistoryEnv(t *testing.T) {
+	t.Helper()
+	for key, value := range map[string]string{
+		"PROVIDERS_ORDER": "weatherapi.com", "WEATHER_API_BASE_URL": "https://weatherapi.example", "HISTORY_ENABLED": "true",
+		"DB_HOST": "localhost", "DB_PORT": "5432", "DB_USER": "postgres", "DB_PASSWORD": "pass", "DB_NAME": "weather",
+	} {
+		t.Setenv(key, value)
+	}
+}
+
+func TestLoad_History(t *testing.T) {
+	// Arrange
+	setRequiredEnv(t)
+	setHistoryEnv(t)
+	t.Setenv("HISTORY_DOWNSAMPLE_INTERVAL", "15m")
+
+	// Act
+	cfg, err := config.Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, cfg.History.Enabled)
+	assert.Equal(t, 7*24*time.Hour, cfg.History.Retention)
+	assert.Equal(t, 15*time.Minute, cfg.History.DownsampleInterval)
+	assert.Equal(t, "postgres", cfg.DB.Driver)
+	assert.Equal(t, "host=localhost port=5432 user=postgres password=pass dbname=weather sslmode=disable", cfg.DB.DSN())
+}
+
+func TestLoad_InvalidHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"NoDB", map[string]string{"DB_HOST": ""}},
+		{"NoRetention", map[string]string{"HISTORY_RETENTION": "0s"}},
+		{"FractionalInterval", map[string]string{"HISTORY_DOWNSAMPLE_INTERVAL": "1500ms"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			setRequiredEnv(t)
+			setHistoryEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			_, err := config.Load()
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProviderTimeout is the longest a single request to a provider may take unless its config says otherwise.
+const DefaultProviderTimeout = 5 * time.Second
+
+// ProviderConfig is one weather provider of the chain. Zero values fall back to the service defaults.
+type ProviderConfig struct {
+	// Unique name used in logs, metrics, quotas and the admin API
+	Name string `yaml:"name"`
+	// Adapter to use, e.g. "open-meteo.com", defaults to Name
+	Kind string `yaml:"kind"`
+	URL  string `yaml:"url"`
+	Key  string `yaml:"key"`
+	// Longest a single request to the provider may take, DefaultProviderTimeout when zero
+	Timeout time.Duration `yaml:"timeout"`
+	// Disabled providers stay in the chain and can be enabled through the admin API, enabled by default
+	Enabled *bool `yaml:"enabled"`
+	// Most calls a minute and a day, 0 means no limit
+	PerMinute  int           `yaml:"per_minute"`
+	DailyQuota int           `yaml:"daily_quota"`
+	Breaker    BreakerConfig `yaml:"breaker"`
+	// How to call and read the api, only for kind "json"
+	JSON JSONProviderConfig `yaml:"json"`
+}
+
+// IsEnabled reports whether the provider starts enabled.
+func (c ProviderConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// RequestTimeout returns the longest a single request to the provider may take.
+func (c ProviderConfig) RequestTimeout() time.Duration {
+	return cmp.Or(c.Timeout, DefaultProviderTimeout)
+}
+
+// BreakerConfig tunes the circuit breaker of one provider.
+type BreakerConfig struct {
+	// How long the breaker stays open before testing recovery
+	Timeout time.Duration `yaml:"timeout"`
+	// Failures in a row that open the breaker
+	MaxFails int `yaml:"max_fails"`
+	// Successful calls that close a half-open breaker
+	Recover int `yaml:"recover"`
+	// Share of failed calls in Window that opens the breaker, once there were MinCalls of them
+	FailureRate float64       `yaml:"failure_rate"`
+	MinCalls    int           `yaml:"min_calls"`
+	Window      time.Duration `yaml:"window"`
+	// Successful calls this slow count as failures, must be below the provider's request timeout
+	SlowCall time.Duration `yaml:"slow_call"`
+}
+
+// JSONProviderConfig describes an api of kind "json", which is read by field mappings instead of an adapter.
+type JSONProviderConfig struct {
+	Current  JSONEndpointConfig `yaml:"current"`
+	Forecast JSONForecastConfig `yaml:"forecast"`
+	Auth     JSONAuthConfig     `yaml:"auth"`
+	NotFound JSONNotFoundConfig `yaml:"not_found"`
+	// Status code to error class: not_found, quota_exceeded, unavailable or internal
+	Errors map[int]string `yaml:"errors"`
+}
+
+type JSONEndpointConfig struct {
+	// Appended to the url, {lat}, {lon} and {key} are replaced, and {days} for the forecast
+	Path string `yaml:"path"`
+	// Field name to dotted JSON path, e.g. temperature: data.0.temp
+	Fields map[string]string `yaml:"fields"`
+	// Factor a number field is multiplied by, e.g. wind_speed: 0.27778 for km/h
+	Scale map[string]float64 `yaml:"scale"`
+}
+
+type JSONForecastConfig struct {
+	Path   string         `yaml:"path"`
+	Daily  JSONListConfig `yaml:"daily"`
+	Hourly JSONListConfig `yaml:"hourly"`
+}
+
+type JSONListConfig struct {
+	// Dotted JSON path of the array, its items are read with Fields
+	List   string             `yaml:"list"`
+	Fields map[string]string  `yaml:"fields"`
+	Scale  map[string]float64 `yaml:"scale"`
+}
+
+type JSONAuthConfig struct {
+	// Where the key goes: query, header, or empty when the path has a {key} placeholder or there is no key
+	In     string `yaml:"in"`
+	Name   string `yaml:"name"`
+	Prefix string `yaml:"prefix"`
+}
+
+// JSONNotFoundConfig recognises answers for unknown locations: their status is one of Status, or any status
+// when it is empty, and the value at Path equals Equals, or exists when Equals is empty.
+type JSONNotFoundConfig struct {
+	Status []int  `yaml:"status"`
+	Path   string `yaml:"path"`
+	Equals string `yaml:"equals"`
+}
+
+// envReference is a ${VAR} reference to an environment variable.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+type providersFile struct {
+	Providers []ProviderConfig `yaml:"providers"`
+}
+
+// loadProviders reads the provider list from a YAML file. ${VAR} references in keys and urls are
+// expanded from the environment, so keys don't have to be stored in the file, and any other $ is
+// left as it is. A breaker slow_call
+// that is not below the provider's timeout is rejected, as such calls time out before they are slow.
+func loadProviders(path string) ([]ProviderConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read providers file: %w", err)
+	}
+	var file providersFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	// a misspelled key would otherwise be dropped silently
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parse providers file %s: %w", path, err)
+	}
+	for i := range file.Providers {
+		provider := &file.Providers[i]
+		provider.Key = expandEnv(provider.Key)
+		provider.URL = expandEnv(provider.URL)
+		if slowCall := provider.Breaker.SlowCall; slowCall != 0 && slowCall >= provider.RequestTimeout() {
+			return nil, fmt.Errorf("providers file %s: breaker slow_call %v of %q is not below its %v timeout",
+				path, slowCall, provider.Name, provider.RequestTimeout())
+		}
+	}
+	return file.Providers, nil
+}
+
+// expandEnv replaces the ${VAR} references in s with the values of the variables.
+func expandEnv(s string) string {
+	return envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(envReference.FindStringSubmatch(ref)[1])
+	})
+}
+
+// legacyProviders builds the provider list from PROVIDERS_ORDER and the per provider variables.
+func (c *Config) legacyProviders() ([]ProviderConfig, error) {
+	providers := make([]ProviderConfig, 0, len(c.Providers.Order))
+	for _, name := range c.Providers.Order {
+		var provider ProviderConfig
+		switch name {
+		case "weatherapi.com":
+			provider = ProviderConfig{
+				URL: c.FreeWeather.URL, Key: c.FreeWeather.Key,
+				PerMinute: c.FreeWeather.PerMinute, DailyQuota: c.FreeWeather.DailyQuota,
+			}
+		case "tomorrow.io":
+			provider = ProviderConfig{
+				URL: c.TomorrowWeather.URL, Key: c.TomorrowWeather.Key,
+				PerMinute: c.TomorrowWeather.PerMinute, DailyQuota: c.TomorrowWeather.DailyQuota,
+			}
+		case "visualcrossing.com":
+			provider = ProviderConfig{
+				URL: c.VisualCrossing.URL, Key: c.VisualCrossing.Key,
+				PerMinute: c.VisualCrossing.PerMinute, DailyQuota: c.VisualCrossing.DailyQuota,
+			}
+		default:
+			return nil, fmt.Errorf("provider %q can only be configured in PROVIDERS_FILE", name)
+		}
+		provider.Name = name
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+// normalizeProviders checks the provider list and defaults the kind of every provider to its name.
+func normalizeProviders(providers []ProviderConfig) error {
+	if len(providers) == 0 {
+		return errors.New("no weather providers configured")
+	}
+	seen := make(map[string]bool, len(providers))
+	for i := range providers {
+		provider := &providers[i]
+		if provider.Name == "" {
+			return fmt.Errorf("weather provider #%d has no name", i+1)
+		}
+		if seen[provider.Name] {
+			return fmt.Errorf("weather provider %q is configured twice", provider.Name)
+		}
+		seen[provider.Name] = true
+		if provider.URL == "" {
+			return fmt.Errorf("weather provider %q has no url", provider.Name)
+		}
+		if provider.Kind == "" {
+			provider.Kind = provider.Name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+//go:build unit
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	for key, value := range map[string]string{
+		"REDIS_HOST": "localhost", "REDIS_PORT": "6379", "REDIS_PASSWORD": "pass",
+		"HTTP_HOST": "localhost", "HTTP_PORT": "8084", "GRPC_HOST": "localhost", "GRPC_PORT": "50101",
+	} {
+		t.Setenv(key, value)
+	}
+}
+
+func TestLoad_ProvidersFile(t *testing.T) {
+	// Arrange
+	setRequiredEnv(t)
+	t.Setenv("OWM_KEY", "secret")
+	path := filepath.Join(t.TempDir(), "providers.yaml")
+	content := `providers:
+  - name: open-meteo.com
+    url: https://api.open-meteo.com/v1
+    timeout: 3s
+  - name: owm-backup
+    kind: openweathermap.org
+    url: https://api.openweathermap.org
+    key: ${OWM_KEY}-$literal$
+    enabled: false
+    daily_quota: 1000
+    breaker:
+      max_fails: 3
+      window: 30s
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv("PROVIDERS_FILE", path)
+
+	// Act
+	cfg, err := config.Load()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, cfg.Providers.List, 2)
+	meteo, owm := cfg.Providers.List[0], cfg.Providers.List[1]
+	assert.Equal(t, "open-meteo.com", meteo.Kind, "kind defaults to the name")
+	assert.Equal(t, 3*time.Second, meteo.Timeout)
+	assert.True(t, meteo.IsEnabled())
+	assert.Equal(t, "openweathermap.org", owm.Kind)
+	assert.Equal(t, "secret-$literal$", owm.Key, "only ${VAR} references are expanded")
+	assert.False(t, owm.IsEnabled())
+	assert.Equal(t, 1000, owm.DailyQuota)
+	assert.Equal(t, 3, owm.Breaker.MaxFails)
+	assert.Equal(t, 30*time.Second, owm.Breaker.Window)
+}
+
+func TestLoad_JSONProvider(t *testing.T) {
+	// Arrange
+	setRequiredEnv(t)
+	path := filepath.Join(t.TempDir(), "providers.yaml")
+	content := `providers:
+  - name: regional
+    kind: json
+    url: https://api.example.com
+    json:
+      current:
+        path: /now?lat={lat}&lon={lon}
+        fields:
+          temperature: data.temp
+          wind_speed: data.wind
+        scale:
+          wind_speed: 0.27778
+      forecast:
+        path: /forecast?lat={lat}&lon={lon}&days={days}
+        daily:
+          list: data.days
+          fields:
+            date: date
+      not_found:
+        status: [404]
+      errors:
+        402: quota_exceeded
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv("PROVIDERS_FILE", path)
+
+	// Act
+	cfg, err := config.Load()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, cfg.Providers.List, 1)
+	spec := cfg.Providers.List[0].JSON
+	assert.Equal(t, "/now?lat={lat}&lon={lon}", spec.Current.Path)
+	assert.Equal(t, map[string]string{"temperature": "data.temp", "wind_speed": "data.wind"}, spec.Current.Fields)
+	assert.Equal(t, 0.27778, spec.Current.Scale["wind_speed"])
+	assert.Equal(t, "data.days", spec.Forecast.Daily.List)
+	assert.Equal(t, []int{404}, spec.NotFound.Status)
+	assert.Equal(t, map[int]string{402: "quota_exceeded"}, spec.Errors)
+}
+
+func TestLoad_LegacyProviders(t *testing.T) {
+	// Arrange
+	setRequiredEnv(t)
+	t.Setenv("PROVIDERS_ORDER", "visualcrossing.com,weatherapi.com")
+	t.Setenv("VISUAL_CROSSING_API_KEY", "vc-key")
+	t.Setenv("VISUAL_CROSSING_API_BASE_URL", "https://vc.example")
+	t.Setenv("WEATHER_API_BASE_URL", "https://weatherapi.example")
+
+	// Act
+	cfg, err := config.Load()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, cfg.Providers.List, 2)
+	assert.Equal(t, config.ProviderConfig{
+		Name: "visualcrossing.com", Kind: "visualcrossing.com", URL: "https://vc.example", Key: "vc-key",
+		PerMinute: 30, DailyQuota: 1000,
+	}, cfg.Providers.List[0])
+	assert.Equal(t, "weatherapi.com", cfg.Providers.List[1].Name)
+}
+
+func TestLoad_InvalidProviders(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"Empty", "providers: []\n"},
+		{"NoName", "providers:\n  - url: https://a.example\n"},
+		{"NoURL", "providers:\n  - name: open-meteo.com\n"},
+		{"Duplicate", "providers:\n  - name: a\n    url: https://a.example\n  - name: a\n    url: https://b.example\n"},
+		{"BadYAML", "providers: {\n"},
+		{"UnknownKey", "providers:\n  - name: a\n    url: https://a.example\n    kye: secret\n"},
+		{"SlowCallAtTimeout", "providers:\n  - name: a\n    url: https://a.example\n    timeout: 3s\n    breaker:\n      slow_call: 3s\n"},
+		{"SlowCallAboveDefaultTimeout", "providers:\n  - name: a\n    url: https://a.example\n    breaker:\n      slow_call: 6s\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			setRequiredEnv(t)
+			path := filepath.Join(t.TempDir(), "providers.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			t.Setenv("PROVIDERS_FILE", path)
+
+			// Act
+			_, err := config.Load()
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import "math"
+
+// aqiBreakpoint maps the concentrations from low to high onto the index range of one AQI category.
+type aqiBreakpoint struct {
+	low, high           float64
+	indexLow, indexHigh int
+}
+
+// EPA breakpoints, PM in µg/m³, O3 (8-hour) and NO2 (1-hour) in ppb.
+var (
+	pm25Breakpoints = []aqiBreakpoint{
+		{0, 9, 0, 50}, {9.1, 35.4, 51, 100}, {35.5, 55.4, 101, 150},
+		{55.5, 125.4, 151, 200}, {125.5, 225.4, 201, 300}, {225.5, 325.4, 301, 500},
+	}
+	pm10Breakpoints = []aqiBreakpoint{
+		{0, 54, 0, 50}, {55, 154, 51, 100}, {155, 254, 101, 150},
+		{255, 354, 151, 200}, {355, 424, 201, 300}, {425, 604, 301, 500},
+	}
+	o3Breakpoints = []aqiBreakpoint{
+		{0, 54, 0, 50}, {55, 70, 51, 100}, {71, 85, 101, 150},
+		{86, 105, 151, 200}, {106, 200, 201, 300},
+	}
+	no2Breakpoints = []aqiBreakpoint{
+		{0, 53, 0, 50}, {54, 100, 51, 100}, {101, 360, 101, 150},
+		{361, 649, 151, 200}, {650, 1249, 201, 300}, {1250, 2049, 301, 500},
+	}
+)
+
+const (
+	maxAQI = 500
+	// µg/m³ per ppb at 25 °C
+	o3PerPPB  = 1.96
+	no2PerPPB = 1.88
+	// EPA truncates PM2.5 to one decimal before looking it up
+	pm25Decimals = 10
+)
+
+// NewAirQuality builds the air quality of the concentrations a provider reported, nil for the ones
+// it did not, with their index.
+func NewAirQuality(pm25, pm10, o3, no2 *float64) AirQuality {
+	var a AirQuality
+	for _, c := range []struct {
+		field AirQualityFields
+		value *float64
+		dest  *float64
+	}{{FieldPM25, pm25, &a.PM25}, {FieldPM10, pm10, &a.PM10}, {FieldO3, o3, &a.O3}, {FieldNO2, no2, &a.NO2}} {
+		if c.value == nil {
+			a.Missing |= c.field
+			continue
+		}
+		*c.dest = *c.value
+	}
+	a.AQI = USAQI(a.PM25, a.PM10, a.O3, a.NO2)
+	return a
+}
+
+// USAQI returns the US EPA air quality index of concentrations in µg/m³, the highest index of
+// the pollutants. Providers report current concentrations rather than the 8 and 24-hour averages
+// the EPA defines the index on, so it is an approximation. Concentrations past the scale give 500.
+func USAQI(pm25, pm10, o3, no2 float64) int {
+	return max(
+		aqiOf(math.Floor(pm25*pm25Decimals)/pm25Decimals, pm25Breakpoints),
+		aqiOf(math.Floor(pm10), pm10Breakpoints),
+		aqiOf(math.Floor(o3/o3PerPPB), o3Breakpoints),
+		aqiOf(math.Floor(no2/no2PerPPB), no2Breakpoints),
+	)
+}
+
+func aqiOf(concentration float64, breakpoints []aqiBreakpoint) int {
+	if concentration <= 0 {
+		return 0
+	}
+	for _, bp := range breakpoints {
+		if concentration <= bp.high {
+			ratio := (concentration - bp.low) / (bp.high - bp.low)
+			return bp.indexLow + int(math.Round(ratio*float64(bp.indexHigh-bp.indexLow)))
+		}
+	}
+	return maxAQI
+}
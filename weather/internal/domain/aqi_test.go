@@ -0,0 +1,35 @@
+//go:build unit
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUSAQI(t *testing.T) {
+	tests := []struct {
+		name                string
+		pm25, pm10, o3, no2 float64
+		want                int
+	}{
+		{"CleanAir", 0, 0, 0, 0, 0},
+		{"GoodUpperBound", 9, 54, 0, 0, 50},
+		{"ModeratePM25", 12.3, 20.1, 60, 15, 57},
+		{"UnhealthyForSensitivePM25", 40, 60, 80, 30, 112},
+		{"PM10Dominates", 5, 154, 0, 0, 100},
+		{"OzoneInMicrograms", 0, 0, 150, 0, 119},
+		{"PastTheScale", 400, 0, 0, 0, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			aqi := domain.USAQI(tt.pm25, tt.pm10, tt.o3, tt.no2)
+
+			// Assert
+			assert.Equal(t, tt.want, aqi)
+		})
+	}
+}
@@ -1,7 +1,291 @@
 package domain
 
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Units is the measurement system values are presented in.
+// Weather is fetched and cached in metric and converted on the way out.
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"
+	UnitsImperial Units = "imperial"
+)
+
+// Weather holds current conditions in metric units.
 type Weather struct {
+	Temperature   float64 // °C
+	Humidity      float64 // %
+	Description   string
+	WindSpeed     float64 // m/s
+	WindDirection float64 // degrees the wind blows from
+	Pressure      float64 // hPa
+	Precipitation float64 // mm/h
+	CloudCover    float64 // %
+	Visibility    float64 // km
+	UVIndex       float64
+	ObservedAt    time.Time
+	Source        string        // provider that answered
+	Missing       WeatherFields // numeric fields the provider did not report, they are zero
+
+	Age   time.Duration // since it was fetched from providers, zero for a fresh reading
+	Stale bool          // providers failed and an expired cached reading was served instead
+}
+
+// WeatherFields is a set of the numeric fields of Weather.
+type WeatherFields uint16
+
+const (
+	FieldTemperature WeatherFields = 1 << iota
+	FieldHumidity
+	FieldWindSpeed
+	FieldWindDirection
+	FieldPressure
+	FieldPrecipitation
+	FieldCloudCover
+	FieldVisibility
+	FieldUVIndex
+
+	AllWeatherFields = FieldUVIndex<<1 - 1
+)
+
+// Has reports whether every field of fields is in the set.
+func (f WeatherFields) Has(fields WeatherFields) bool {
+	return f&fields == fields
+}
+
+// Aged returns the reading marked as served from cache, fetched age ago.
+func (w Weather) Aged(age time.Duration, stale bool) Weather {
+	w.Age = age
+	w.Stale = stale
+	return w
+}
+
+// CurrentQuery is one item of a batch lookup of current weather.
+type CurrentQuery struct {
+	Location Location
+	Units    Units
+}
+
+// CurrentResult is the outcome of one item of a batch lookup, either weather or an error.
+type CurrentResult struct {
+	Weather Weather
+	Err     error
+}
+
+type HourlyForecast struct {
+	Time        time.Time
 	Temperature float64
 	Humidity    float64
 	Description string
 }
+
+type DailyForecast struct {
+	Date           time.Time
+	MinTemperature float64
+	MaxTemperature float64
+	Humidity       float64
+	Description    string
+}
+
+type Forecast struct {
+	Hourly []HourlyForecast
+	Daily  []DailyForecast
+
+	Age   time.Duration // since it was fetched from providers, zero for a fresh forecast
+	Stale bool          // providers failed and an expired cached forecast was served instead
+}
+
+// Aged returns the forecast marked as served from cache, fetched age ago.
+func (f Forecast) Aged(age time.Duration, stale bool) Forecast {
+	f.Age = age
+	f.Stale = stale
+	return f
+}
+
+// AlertSeverity is how severe the issuer of a weather alert rates it, on the CAP scale.
+type AlertSeverity string
+
+const (
+	AlertSeverityUnknown  AlertSeverity = "unknown"
+	AlertSeverityMinor    AlertSeverity = "minor"
+	AlertSeverityModerate AlertSeverity = "moderate"
+	AlertSeveritySevere   AlertSeverity = "severe"
+	AlertSeverityExtreme  AlertSeverity = "extreme"
+)
+
+// ParseAlertSeverity maps a severity as providers spell it, e.g. "Severe", to the domain,
+// anything it doesn't know to AlertSeverityUnknown.
+func ParseAlertSeverity(s string) AlertSeverity {
+	switch severity := AlertSeverity(strings.ToLower(strings.TrimSpace(s))); severity {
+	case AlertSeverityMinor, AlertSeverityModerate, AlertSeveritySevere, AlertSeverityExtreme:
+		return severity
+	default:
+		return AlertSeverityUnknown
+	}
+}
+
+// Alert is a weather warning issued by a government agency for an area.
+type Alert struct {
+	Event     string // e.g. Flood Warning
+	Headline  string
+	Severity  AlertSeverity
+	Effective time.Time
+	Expires   time.Time // zero when the issuer gave no end
+	Areas     []string
+}
+
+// Expired reports whether the alert is no longer in effect at t.
+func (a Alert) Expired(t time.Time) bool {
+	return !a.Expires.IsZero() && !a.Expires.After(t)
+}
+
+// Alerts are the weather alerts in effect for a location.
+type Alerts struct {
+	Alerts []Alert
+	Source string // provider that answered
+
+	Age   time.Duration // since they were fetched from providers, zero for fresh alerts
+	Stale bool          // providers failed and expired cached alerts were served instead
+}
+
+// Aged returns the alerts marked as served from cache, fetched age ago.
+func (a Alerts) Aged(age time.Duration, stale bool) Alerts {
+	a.Age = age
+	a.Stale = stale
+	return a
+}
+
+// AirQuality holds the air pollution near a location, concentrations in µg/m³.
+type AirQuality struct {
+	AQI  int // US EPA air quality index of the concentrations, 0-500
+	PM25 float64
+	PM10 float64
+	O3   float64
+	NO2  float64
+	// concentrations the provider did not report, they are zero and left out of the index
+	Missing AirQualityFields
+	// grains/m³ by plant, e.g. birch, nil when the provider has no pollen counts for the location
+	Pollen     map[string]float64
+	ObservedAt time.Time
+	Source     string // provider that answered
+
+	Age   time.Duration // since it was fetched from providers, zero for a fresh value
+	Stale bool          // providers failed and an expired cached value was served instead
+}
+
+// AirQualityFields is a set of the concentrations of AirQuality.
+type AirQualityFields uint8
+
+const (
+	FieldPM25 AirQualityFields = 1 << iota
+	FieldPM10
+	FieldO3
+	FieldNO2
+
+	AllAirQualityFields = FieldNO2<<1 - 1
+)
+
+// Has reports whether every field of fields is in the set.
+func (f AirQualityFields) Has(fields AirQualityFields) bool {
+	return f&fields == fields
+}
+
+// Aged returns the air quality marked as served from cache, fetched age ago.
+func (a AirQuality) Aged(age time.Duration, stale bool) AirQuality {
+	a.Age = age
+	a.Stale = stale
+	return a
+}
+
+// Observation is a stored reading of one provider for a location. Readings past the raw retention
+// are merged into one per downsampling interval, Samples is how many readings it averages.
+type Observation struct {
+	LocationKey string
+	Weather     Weather
+	Samples     int
+}
+
+// ProviderStats counts the calls that reached a weather provider.
+type ProviderStats struct {
+	Calls       int
+	Failures    int
+	LastError   string
+	LastErrorAt time.Time
+	// moving average of call latency
+	Latency time.Duration
+}
+
+// ProviderStatus is the state of a weather provider as shown to admins.
+type ProviderStatus struct {
+	Name    string
+	Enabled bool
+	Breaker string
+	ProviderStats
+}
+
+// Location is either a gazetteer entry or, when ID is zero, an arbitrary point
+// that may carry the name of the nearest known city. A location with ByName set is a city
+// the gazetteer does not know, it has no coordinates and providers look it up by name.
+type Location struct {
+	ID       int64
+	Name     string
+	Country  string
+	Lat      float64
+	Lon      float64
+	Timezone string
+	ByName   bool
+}
+
+// coordinatesKeyPrecision rounds ad-hoc coordinates to ~1 km, so nearby requests share a cache entry.
+const coordinatesKeyPrecision = 100
+
+// Key is a canonical identifier of the location, suitable for cache keys.
+func (l Location) Key() string {
+	if l.ByName {
+		return "geo:name:" + l.Query()
+	}
+	if l.ID != 0 {
+		return fmt.Sprintf("geo:%d", l.ID)
+	}
+	return fmt.Sprintf("geo:%.2f,%.2f", roundCoordinate(l.Lat), roundCoordinate(l.Lon))
+}
+
+// Coordinates formats the location as "lat,lon", the query every provider accepts.
+func (l Location) Coordinates() string {
+	return fmt.Sprintf("%.4f,%.4f", l.Lat, l.Lon)
+}
+
+// Query is what providers are asked for: the name of a location known only by name, its coordinates otherwise.
+func (l Location) Query() string {
+	if !l.ByName {
+		return l.Coordinates()
+	}
+	if l.Country == "" {
+		return l.Name
+	}
+	return l.Name + "," + l.Country
+}
+
+func (l Location) String() string {
+	if l.Name == "" {
+		return l.Coordinates()
+	}
+	if l.Country == "" {
+		return l.Name
+	}
+	return fmt.Sprintf("%s, %s", l.Name, l.Country)
+}
+
+func roundCoordinate(deg float64) float64 {
+	rounded := math.Round(deg*coordinatesKeyPrecision) / coordinatesKeyPrecision
+	if rounded == 0 {
+		// avoid "-0.00" so both sides of the equator/meridian share a key
+		return 0
+	}
+	return rounded
+}
@@ -1,10 +1,39 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrInternal           = errors.New("internal error")
 	ErrCityNotFound       = errors.New("city not found")
 	ErrWeatherUnavailable = errors.New("weather api is unavailable")
 	ErrProviderUnreliable = errors.New("weather provider is unreliable")
+	ErrQuotaExceeded      = errors.New("weather provider quota exceeded")
+	ErrProviderNotFound   = errors.New("weather provider not found")
+	ErrUnsupported        = errors.New("weather provider does not support the request")
+	// ErrProviderDisabled is returned for providers switched off by an admin, it matches ErrProviderUnreliable
+	ErrProviderDisabled = fmt.Errorf("weather provider is disabled: %w", ErrProviderUnreliable)
+	// ErrProviderAuth is returned when a provider rejects its api key, it matches ErrInternal,
+	// so it is neither retried nor counted against the provider's breaker
+	ErrProviderAuth = fmt.Errorf("weather provider rejected the api key: %w", ErrInternal)
+	// ErrAlertsUnsupported and ErrAirQualityUnsupported are returned by providers without the data, they match ErrUnsupported
+	ErrAlertsUnsupported     = fmt.Errorf("weather provider has no alerts: %w", ErrUnsupported)
+	ErrAirQualityUnsupported = fmt.Errorf("weather provider has no air quality data: %w", ErrUnsupported)
 )
+
+// LocationNotFoundError is returned when a query can't be resolved to a known location.
+// It matches ErrCityNotFound and carries close names the user may have meant.
+type LocationNotFoundError struct {
+	Query       string
+	Suggestions []string
+}
+
+func (e *LocationNotFoundError) Error() string {
+	return fmt.Sprintf("location %q not found", e.Query)
+}
+
+func (e *LocationNotFoundError) Unwrap() error {
+	return ErrCityNotFound
+}
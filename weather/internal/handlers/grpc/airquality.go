@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *WeathGRPCServer) GetAirQuality(ctx context.Context, req *pb.GetAirQualityRequest) (*pb.GetAirQualityResponse, error) {
+	city := req.City
+	if city == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "city is empty")
+	}
+
+	loc, err := s.weathSvc.Resolve(city)
+	if err != nil {
+		return nil, domainToStatusError("air quality", err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+	airQuality, err := s.weathSvc.GetAirQuality(ctxWithTimeout, loc)
+	if err != nil {
+		return nil, domainToStatusError("air quality", err)
+	}
+
+	return &pb.GetAirQualityResponse{
+		AirQuality: &pb.AirQuality{
+			Aqi:        int32(airQuality.AQI),
+			Pm2_5:      airQuality.PM25,
+			Pm10:       airQuality.PM10,
+			O3:         airQuality.O3,
+			No2:        airQuality.NO2,
+			Pollen:     airQuality.Pollen,
+			ObservedAt: timestamppb.New(airQuality.ObservedAt),
+		},
+		Location: locationToPB(loc),
+		Source:   airQuality.Source,
+		Stale:    airQuality.Stale,
+		Age:      durationpb.New(airQuality.Age),
+	}, nil
+}
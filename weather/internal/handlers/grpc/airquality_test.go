@@ -0,0 +1,79 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWeatherGRPCServer_GetAirQuality(t *testing.T) {
+	city := "Kyiv"
+	observedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetAirQualityFn: func(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+				require.Equal(t, city, loc.Name)
+				return domain.AirQuality{
+					AQI: 57, PM25: 12.3, PM10: 20.1, O3: 60, NO2: 15,
+					Pollen:     map[string]float64{"birch": 25.5},
+					ObservedAt: observedAt,
+					Source:     "weatherapi.com",
+					Age:        time.Minute,
+				}, nil
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		resp, err := srv.GetAirQuality(context.Background(), &pb.GetAirQualityRequest{City: city})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(57), resp.AirQuality.Aqi)
+		assert.Equal(t, 12.3, resp.AirQuality.Pm2_5)
+		assert.Equal(t, 20.1, resp.AirQuality.Pm10)
+		assert.Equal(t, map[string]float64{"birch": 25.5}, resp.AirQuality.Pollen)
+		assert.Equal(t, observedAt, resp.AirQuality.ObservedAt.AsTime())
+		assert.Equal(t, "weatherapi.com", resp.Source)
+		assert.Equal(t, time.Minute, resp.Age.AsDuration())
+		assert.Equal(t, city, resp.Location.Name)
+	})
+
+	t.Run("EmptyCity", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetAirQuality(context.Background(), &pb.GetAirQualityRequest{})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetAirQualityFn: func(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+				return domain.AirQuality{}, domain.ErrAirQualityUnsupported
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetAirQuality(context.Background(), &pb.GetAirQualityRequest{City: city})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, grpcCode(err))
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *WeathGRPCServer) GetAlerts(ctx context.Context, req *pb.GetAlertsRequest) (*pb.GetAlertsResponse, error) {
+	city := req.City
+	if city == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "city is empty")
+	}
+
+	loc, err := s.weathSvc.Resolve(city)
+	if err != nil {
+		return nil, domainToStatusError("alerts", err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+	alerts, err := s.weathSvc.GetAlerts(ctxWithTimeout, loc)
+	if err != nil {
+		return nil, domainToStatusError("alerts", err)
+	}
+
+	resp := &pb.GetAlertsResponse{
+		Alerts:   make([]*pb.Alert, 0, len(alerts.Alerts)),
+		Location: locationToPB(loc),
+		Source:   alerts.Source,
+		Stale:    alerts.Stale,
+		Age:      durationpb.New(alerts.Age),
+	}
+	for _, alert := range alerts.Alerts {
+		a := &pb.Alert{
+			Severity:  alertSeverityToPB(alert.Severity),
+			Event:     alert.Event,
+			Headline:  alert.Headline,
+			Effective: timestamppb.New(alert.Effective),
+			Areas:     alert.Areas,
+		}
+		if !alert.Expires.IsZero() {
+			a.Expires = timestamppb.New(alert.Expires)
+		}
+		resp.Alerts = append(resp.Alerts, a)
+	}
+	return resp, nil
+}
+
+func alertSeverityToPB(severity domain.AlertSeverity) pb.AlertSeverity {
+	switch severity {
+	case domain.AlertSeverityMinor:
+		return pb.AlertSeverity_ALERT_SEVERITY_MINOR
+	case domain.AlertSeverityModerate:
+		return pb.AlertSeverity_ALERT_SEVERITY_MODERATE
+	case domain.AlertSeveritySevere:
+		return pb.AlertSeverity_ALERT_SEVERITY_SEVERE
+	case domain.AlertSeverityExtreme:
+		return pb.AlertSeverity_ALERT_SEVERITY_EXTREME
+	default:
+		return pb.AlertSeverity_ALERT_SEVERITY_UNSPECIFIED
+	}
+}
@@ -0,0 +1,84 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWeatherGRPCServer_GetAlerts(t *testing.T) {
+	city := "Kyiv"
+	effective := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetAlertsFn: func(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+				require.Equal(t, city, loc.Name)
+				return domain.Alerts{
+					Alerts: []domain.Alert{
+						{
+							Event: "Heat", Headline: "Heat warning", Severity: domain.AlertSeveritySevere,
+							Effective: effective, Expires: effective.Add(6 * time.Hour), Areas: []string{"Kyiv"},
+						},
+						{Event: "Wind", Effective: effective},
+					},
+					Source: "weatherapi.com",
+				}, nil
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		resp, err := srv.GetAlerts(context.Background(), &pb.GetAlertsRequest{City: city})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, resp.Alerts, 2)
+		assert.Equal(t, pb.AlertSeverity_ALERT_SEVERITY_SEVERE, resp.Alerts[0].Severity)
+		assert.Equal(t, "Heat warning", resp.Alerts[0].Headline)
+		assert.Equal(t, effective, resp.Alerts[0].Effective.AsTime())
+		assert.Equal(t, effective.Add(6*time.Hour), resp.Alerts[0].Expires.AsTime())
+		assert.Equal(t, []string{"Kyiv"}, resp.Alerts[0].Areas)
+		assert.Equal(t, pb.AlertSeverity_ALERT_SEVERITY_UNSPECIFIED, resp.Alerts[1].Severity)
+		assert.Nil(t, resp.Alerts[1].Expires, "alerts without an end have no expiry")
+		assert.Equal(t, "weatherapi.com", resp.Source)
+		assert.Equal(t, city, resp.Location.Name)
+	})
+
+	t.Run("EmptyCity", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetAlerts(context.Background(), &pb.GetAlertsRequest{})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetAlertsFn: func(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+				return domain.Alerts{}, domain.ErrAlertsUnsupported
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetAlerts(context.Background(), &pb.GetAlertsRequest{City: city})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, grpcCode(err))
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxBatchItems = 1000
+
+func (s *WeathGRPCServer) GetCurrentBatch(ctx context.Context, req *pb.GetCurrentBatchRequest) (*pb.GetCurrentBatchResponse, error) {
+	if len(req.Items) == 0 || len(req.Items) > maxBatchItems {
+		return nil, status.Errorf(codes.InvalidArgument, "batch must contain between 1 and %d items", maxBatchItems)
+	}
+
+	results := make([]*pb.GetCurrentBatchResult, len(req.Items))
+	queries := make([]domain.CurrentQuery, 0, len(req.Items))
+	// queryItems[j] is the index of the request item queries[j] was built from
+	queryItems := make([]int, 0, len(req.Items))
+	for i, item := range req.Items {
+		units, err := unitsFromPB(item.Units)
+		if err != nil {
+			results[i] = batchErrorResult(err)
+			continue
+		}
+		loc, err := s.resolveCurrentQuery(item)
+		if err != nil {
+			results[i] = batchErrorResult(err)
+			continue
+		}
+		queries = append(queries, domain.CurrentQuery{Location: loc, Units: units})
+		queryItems = append(queryItems, i)
+	}
+
+	// a batch loads its misses a few at a time, so a single request timeout would run out for large ones,
+	// the cache gives every miss a timeout of its own instead
+	for j, r := range s.weathSvc.GetCurrentBatch(ctx, queries) {
+		i := queryItems[j]
+		if r.Err != nil {
+			results[i] = batchErrorResult(domainToStatusError("current weather batch", r.Err))
+			continue
+		}
+		results[i] = &pb.GetCurrentBatchResult{
+			Result: &pb.GetCurrentBatchResult_Current{Current: currentToPB(r.Weather, queries[j].Location, queries[j].Units)},
+		}
+	}
+	return &pb.GetCurrentBatchResponse{Results: results}, nil
+}
+
+// batchErrorResult turns the status error GetCurrent would have returned for an item into its batch result.
+func batchErrorResult(err error) *pb.GetCurrentBatchResult {
+	st := status.Convert(err)
+	batchErr := &pb.BatchError{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Details() {
+		if suggestions, ok := detail.(*pb.CitySuggestions); ok {
+			batchErr.Suggestions = suggestions.Suggestions
+		}
+	}
+	return &pb.GetCurrentBatchResult{Result: &pb.GetCurrentBatchResult_Error{Error: batchErr}}
+}
@@ -0,0 +1,86 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func cityItem(city string) *pb.GetCurrentRequest {
+	return &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_City{City: city}}
+}
+
+func TestWeatherGRPCServer_GetCurrentBatch(t *testing.T) {
+	t.Run("PerItemResults", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			ResolveFn: func(city string) (domain.Location, error) {
+				if city == "Kyivv" {
+					return domain.Location{}, &domain.LocationNotFoundError{Query: city, Suggestions: []string{"Kyiv, UA"}}
+				}
+				return domain.Location{Name: city}, nil
+			},
+			GetBatchFn: func(ctx context.Context, queries []domain.CurrentQuery) []domain.CurrentResult {
+				require.Len(t, queries, 2)
+				assert.Equal(t, "Kyiv", queries[0].Location.Name)
+				assert.Equal(t, domain.UnitsImperial, queries[1].Units)
+				return []domain.CurrentResult{
+					{Weather: domain.Weather{Temperature: 20}},
+					{Err: domain.ErrWeatherUnavailable},
+				}
+			},
+		}, nil, 2*time.Millisecond)
+		lviv := cityItem("Lviv")
+		lviv.Units = pb.Units_UNITS_IMPERIAL
+
+		// Act
+		resp, err := srv.GetCurrentBatch(context.Background(), &pb.GetCurrentBatchRequest{
+			Items: []*pb.GetCurrentRequest{cityItem("Kyiv"), cityItem("Kyivv"), lviv},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, 20.0, resp.Results[0].GetCurrent().GetWeather().GetTemperature())
+		assert.Equal(t, "Kyiv", resp.Results[0].GetCurrent().GetLocation().GetName())
+		assert.Equal(t, int32(codes.NotFound), resp.Results[1].GetError().GetCode())
+		assert.Equal(t, []string{"Kyiv, UA"}, resp.Results[1].GetError().GetSuggestions())
+		assert.Equal(t, int32(codes.Unavailable), resp.Results[2].GetError().GetCode())
+	})
+
+	t.Run("InvalidItem", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		resp, err := srv.GetCurrentBatch(context.Background(), &pb.GetCurrentBatchRequest{
+			Items: []*pb.GetCurrentRequest{cityItem(""), cityItem("Kyiv")},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.InvalidArgument), resp.Results[0].GetError().GetCode())
+		assert.NotNil(t, resp.Results[1].GetCurrent())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetCurrentBatch(context.Background(), &pb.GetCurrentBatchRequest{})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+}
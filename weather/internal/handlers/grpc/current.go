@@ -2,49 +2,83 @@ package handlers
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"log"
+	"math"
 
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	maxLatitude  = 90
+	maxLongitude = 180
 )
 
 func (s *WeathGRPCServer) GetCurrent(ctx context.Context, req *pb.GetCurrentRequest) (*pb.GetCurrentResponse, error) {
-	city := req.City
-	if city == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "city is empty")
+	units, err := unitsFromPB(req.Units)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.resolveCurrentQuery(req)
+	if err != nil {
+		return nil, err
 	}
 
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.requestTimeout)
 	defer cancel()
-	weather, err := s.weathSvc.GetCurrent(ctxWithTimeout, city)
-	if errors.Is(err, domain.ErrCityNotFound) {
-		log.Println(fmt.Errorf("current weather grpc handler: %v", err))
-		return nil, status.Errorf(codes.NotFound, "city not found")
-	}
-	if errors.Is(err, domain.ErrInternal) {
-		log.Println(fmt.Errorf("current weather grpc handler: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to get weather")
-	}
-	if errors.Is(err, domain.ErrWeatherUnavailable) {
-		log.Println(fmt.Errorf("current weather grpc handler: %v", err))
-		return nil, status.Errorf(codes.Unavailable, "weather unavailable")
-	}
-	if errors.Is(err, domain.ErrProviderUnreliable) {
-		log.Println(fmt.Errorf("current weather grpc handler: %v", err))
-		return nil, status.Errorf(codes.Unavailable, "weather provider is unreliable")
-	}
+	weather, err := s.weathSvc.GetCurrent(ctxWithTimeout, loc, units)
 	if err != nil {
-		log.Println(fmt.Errorf("current weather grpc handler: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to get weather")
+		return nil, domainToStatusError("current weather", err)
 	}
 
+	return currentToPB(weather, loc, units), nil
+}
+
+func currentToPB(weather domain.Weather, loc domain.Location, units domain.Units) *pb.GetCurrentResponse {
 	return &pb.GetCurrentResponse{
-		Temperature: float32(weather.Temperature),
-		Humidity:    float32(weather.Humidity),
-		Description: weather.Description,
-	}, nil
+		Weather:  weatherToPB(weather),
+		Location: locationToPB(loc),
+		Units:    unitsToPB(units),
+		Stale:    weather.Stale,
+		Age:      durationpb.New(weather.Age),
+	}
+}
+
+// resolveCurrentQuery maps either the city or the coordinates of the request to a location.
+func (s *WeathGRPCServer) resolveCurrentQuery(req *pb.GetCurrentRequest) (domain.Location, error) {
+	switch query := req.Query.(type) {
+	case *pb.GetCurrentRequest_City:
+		if query.City == "" {
+			return domain.Location{}, status.Errorf(codes.InvalidArgument, "city is empty")
+		}
+		loc, err := s.weathSvc.Resolve(query.City)
+		if err != nil {
+			return domain.Location{}, domainToStatusError("current weather", err)
+		}
+		return loc, nil
+	case *pb.GetCurrentRequest_Coordinates:
+		if query.Coordinates == nil {
+			return domain.Location{}, status.Errorf(codes.InvalidArgument, "coordinates are empty")
+		}
+		lat, lon := query.Coordinates.Lat, query.Coordinates.Lon
+		if !validCoordinates(lat, lon) {
+			return domain.Location{}, status.Errorf(codes.InvalidArgument, "coordinates are out of range")
+		}
+		return s.weathSvc.ResolveCoordinates(lat, lon), nil
+	default:
+		return domain.Location{}, status.Errorf(codes.InvalidArgument, "city or coordinates are required")
+	}
+}
+
+// validCoordinates reports whether lat and lon are finite and within range. NaN passes every range check
+// by comparing false, so it is rejected explicitly.
+func validCoordinates(lat, lon float64) bool {
+	for _, deg := range []float64{lat, lon} {
+		if math.IsNaN(deg) || math.IsInf(deg, 0) {
+			return false
+		}
+	}
+	return lat >= -maxLatitude && lat <= maxLatitude && lon >= -maxLongitude && lon <= maxLongitude
 }
@@ -5,10 +5,11 @@ package handlers_test
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
 	"github.com/stretchr/testify/assert"
@@ -18,16 +19,66 @@ import (
 )
 
 type mockWeatherService struct {
-	GetCurrentFn func(ctx context.Context, city string) (domain.Weather, error)
+	ResolveFn       func(city string) (domain.Location, error)
+	ResolveCoordsFn func(lat, lon float64) domain.Location
+	GetCurrentFn    func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error)
+	GetForecastFn   func(ctx context.Context, loc domain.Location, days int, units domain.Units) (domain.Forecast, error)
+	GetBatchFn      func(ctx context.Context, queries []domain.CurrentQuery) []domain.CurrentResult
+	GetAlertsFn     func(ctx context.Context, loc domain.Location) (domain.Alerts, error)
+	GetAirQualityFn func(ctx context.Context, loc domain.Location) (domain.AirQuality, error)
 }
 
-func (m *mockWeatherService) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (m *mockWeatherService) Resolve(city string) (domain.Location, error) {
+	if m.ResolveFn != nil {
+		return m.ResolveFn(city)
+	}
+	return domain.Location{Name: city}, nil
+}
+
+func (m *mockWeatherService) ResolveCoordinates(lat, lon float64) domain.Location {
+	if m.ResolveCoordsFn != nil {
+		return m.ResolveCoordsFn(lat, lon)
+	}
+	return domain.Location{Lat: lat, Lon: lon}
+}
+
+func (m *mockWeatherService) GetCurrent(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
 	if m.GetCurrentFn != nil {
-		return m.GetCurrentFn(ctx, city)
+		return m.GetCurrentFn(ctx, loc, units)
 	}
 	return domain.Weather{}, nil
 }
 
+func (m *mockWeatherService) GetForecast(
+	ctx context.Context, loc domain.Location, days int, units domain.Units,
+) (domain.Forecast, error) {
+	if m.GetForecastFn != nil {
+		return m.GetForecastFn(ctx, loc, days, units)
+	}
+	return domain.Forecast{}, nil
+}
+
+func (m *mockWeatherService) GetCurrentBatch(ctx context.Context, queries []domain.CurrentQuery) []domain.CurrentResult {
+	if m.GetBatchFn != nil {
+		return m.GetBatchFn(ctx, queries)
+	}
+	return make([]domain.CurrentResult, len(queries))
+}
+
+func (m *mockWeatherService) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	if m.GetAlertsFn != nil {
+		return m.GetAlertsFn(ctx, loc)
+	}
+	return domain.Alerts{}, nil
+}
+
+func (m *mockWeatherService) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	if m.GetAirQualityFn != nil {
+		return m.GetAirQualityFn(ctx, loc)
+	}
+	return domain.AirQuality{}, nil
+}
+
 func grpcCode(err error) codes.Code {
 	s, ok := status.FromError(err)
 	if !ok {
@@ -38,39 +89,188 @@ func grpcCode(err error) codes.Code {
 
 func TestWeatherGRPCServer_GetCurrent(t *testing.T) {
 	city := "Kyiv"
-	validReq := &pb.GetCurrentRequest{City: city}
+	validReq := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_City{City: city}}
 	expectedWeather := domain.Weather{
-		Temperature: 21.3,
-		Humidity:    50.0,
-		Description: "clear",
+		Temperature:   21.3,
+		Humidity:      50.0,
+		Description:   "clear",
+		WindSpeed:     3.5,
+		WindDirection: 200,
+		Pressure:      1015,
+		Precipitation: 0.2,
+		CloudCover:    40,
+		Visibility:    10,
+		UVIndex:       4,
+		ObservedAt:    time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Source:        "tomorrow.io",
 	}
 
 	t.Run("Success", func(t *testing.T) {
 		// Arrange
 		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
-			GetCurrentFn: func(ctx context.Context, c string) (domain.Weather, error) {
-				require.Equal(t, city, c)
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
+				require.Equal(t, city, loc.Name)
+				require.Equal(t, domain.UnitsMetric, units)
 				return expectedWeather, nil
 			},
-		}, 2*time.Millisecond)
+		}, nil, 2*time.Millisecond)
 
 		// Act
 		resp, err := srv.GetCurrent(context.Background(), validReq)
 
 		// Assert
 		require.NoError(t, err)
-		assert.Equal(t, float32(expectedWeather.Temperature), resp.Temperature)
-		assert.Equal(t, float32(expectedWeather.Humidity), resp.Humidity)
-		assert.Equal(t, expectedWeather.Description, resp.Description)
+		assert.Equal(t, expectedWeather.Temperature, resp.Weather.GetTemperature())
+		assert.Equal(t, expectedWeather.Humidity, resp.Weather.GetHumidity())
+		assert.Equal(t, expectedWeather.Description, resp.Weather.GetDescription())
+		assert.Equal(t, expectedWeather.WindSpeed, resp.Weather.GetWindSpeed())
+		assert.Equal(t, expectedWeather.WindDirection, resp.Weather.GetWindDirection())
+		assert.Equal(t, expectedWeather.Pressure, resp.Weather.GetPressure())
+		assert.Equal(t, expectedWeather.Precipitation, resp.Weather.GetPrecipitation())
+		assert.Equal(t, expectedWeather.CloudCover, resp.Weather.GetCloudCover())
+		assert.Equal(t, expectedWeather.Visibility, resp.Weather.GetVisibility())
+		assert.Equal(t, expectedWeather.UVIndex, resp.Weather.GetUvIndex())
+		assert.Equal(t, expectedWeather.ObservedAt, resp.Weather.GetObservedAt().AsTime())
+		assert.Equal(t, expectedWeather.Source, resp.Weather.GetSource())
+		assert.Equal(t, city, resp.Location.GetName())
+		assert.Equal(t, pb.Units_UNITS_METRIC, resp.GetUnits())
+	})
+
+	t.Run("Imperial", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
+				require.Equal(t, domain.UnitsImperial, units)
+				return expectedWeather, nil
+			},
+		}, nil, 2*time.Millisecond)
+		req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_City{City: city}, Units: pb.Units_UNITS_IMPERIAL}
+
+		// Act
+		resp, err := srv.GetCurrent(context.Background(), req)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, pb.Units_UNITS_IMPERIAL, resp.GetUnits())
+	})
+
+	t.Run("UnknownUnits", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_City{City: city}, Units: pb.Units(42)}
+
+		// Act
+		_, err := srv.GetCurrent(context.Background(), req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("Coordinates", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			ResolveCoordsFn: func(lat, lon float64) domain.Location {
+				return domain.Location{Name: "Kyiv", Country: "UA", Lat: lat, Lon: lon}
+			},
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
+				require.Equal(t, 50.4, loc.Lat)
+				require.Equal(t, 30.6, loc.Lon)
+				return expectedWeather, nil
+			},
+		}, nil, 2*time.Millisecond)
+		req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: 50.4, Lon: 30.6}}}
+
+		// Act
+		resp, err := srv.GetCurrent(context.Background(), req)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, expectedWeather.Temperature, resp.Weather.GetTemperature())
+		assert.Equal(t, "Kyiv", resp.Location.GetName())
+		assert.Equal(t, 50.4, resp.Location.GetLat())
+	})
+
+	t.Run("CoordinatesOutOfRange", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: 91, Lon: 30.6}}}
+
+		// Act
+		_, err := srv.GetCurrent(context.Background(), req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("CoordinatesNaN", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: math.NaN(), Lon: 30.6}}}
+
+		// Act
+		_, err := srv.GetCurrent(context.Background(), req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("CoordinatesInf", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: 50.4, Lon: math.Inf(1)}}}
+
+		// Act
+		_, err := srv.GetCurrent(context.Background(), req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("EmptyQuery", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetCurrent(context.Background(), &pb.GetCurrentRequest{})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("CityNotFoundWithSuggestions", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			ResolveFn: func(c string) (domain.Location, error) {
+				return domain.Location{}, &domain.LocationNotFoundError{Query: c, Suggestions: []string{"Kyiv, UA"}}
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetCurrent(context.Background(), &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_City{City: "Kyivv"}})
+
+		// Assert
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.NotFound, st.Code())
+		require.Len(t, st.Details(), 1)
+		suggestions, ok := st.Details()[0].(*pb.CitySuggestions)
+		require.True(t, ok)
+		assert.Equal(t, []string{"Kyiv, UA"}, suggestions.Suggestions)
 	})
 
 	t.Run("CityNotFound", func(t *testing.T) {
 		// Arrange
 		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
-			GetCurrentFn: func(ctx context.Context, c string) (domain.Weather, error) {
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
 				return domain.Weather{}, domain.ErrCityNotFound
 			},
-		}, 2*time.Millisecond)
+		}, nil, 2*time.Millisecond)
 
 		// Act
 		_, err := srv.GetCurrent(context.Background(), validReq)
@@ -83,10 +283,10 @@ func TestWeatherGRPCServer_GetCurrent(t *testing.T) {
 	t.Run("WeatherUnavailable", func(t *testing.T) {
 		// Arrange
 		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
-			GetCurrentFn: func(ctx context.Context, c string) (domain.Weather, error) {
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
 				return domain.Weather{}, domain.ErrWeatherUnavailable
 			},
-		}, 2*time.Millisecond)
+		}, nil, 2*time.Millisecond)
 
 		// Act
 		_, err := srv.GetCurrent(context.Background(), validReq)
@@ -99,10 +299,10 @@ func TestWeatherGRPCServer_GetCurrent(t *testing.T) {
 	t.Run("ProviderUnreliable", func(t *testing.T) {
 		// Arrange
 		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
-			GetCurrentFn: func(ctx context.Context, c string) (domain.Weather, error) {
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
 				return domain.Weather{}, domain.ErrProviderUnreliable
 			},
-		}, 2*time.Millisecond)
+		}, nil, 2*time.Millisecond)
 
 		// Act
 		_, err := srv.GetCurrent(context.Background(), validReq)
@@ -115,10 +315,10 @@ func TestWeatherGRPCServer_GetCurrent(t *testing.T) {
 	t.Run("InternalError", func(t *testing.T) {
 		// Arrange
 		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
-			GetCurrentFn: func(ctx context.Context, c string) (domain.Weather, error) {
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
 				return domain.Weather{}, domain.ErrInternal
 			},
-		}, 2*time.Millisecond)
+		}, nil, 2*time.Millisecond)
 
 		// Act
 		_, err := srv.GetCurrent(context.Background(), validReq)
@@ -130,10 +330,10 @@ func TestWeatherGRPCServer_GetCurrent(t *testing.T) {
 
 	t.Run("UnknownError", func(t *testing.T) {
 		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
-			GetCurrentFn: func(ctx context.Context, c string) (domain.Weather, error) {
+			GetCurrentFn: func(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
 				return domain.Weather{}, errors.New("unknown failure")
 			},
-		}, 2*time.Millisecond)
+		}, nil, 2*time.Millisecond)
 
 		_, err := srv.GetCurrent(context.Background(), validReq)
 		require.Error(t, err)
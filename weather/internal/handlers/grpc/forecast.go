@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultForecastDays = 3
+	maxForecastDays     = 7
+)
+
+func (s *WeathGRPCServer) GetForecast(ctx context.Context, req *pb.GetForecastRequest) (*pb.GetForecastResponse, error) {
+	city := req.City
+	if city == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "city is empty")
+	}
+	days := int(req.Days)
+	if days == 0 {
+		days = defaultForecastDays
+	}
+	if days < 1 || days > maxForecastDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be between 1 and %d", maxForecastDays)
+	}
+	units, err := unitsFromPB(req.Units)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.weathSvc.Resolve(city)
+	if err != nil {
+		return nil, domainToStatusError("forecast", err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+	forecast, err := s.weathSvc.GetForecast(ctxWithTimeout, loc, days, units)
+	if err != nil {
+		return nil, domainToStatusError("forecast", err)
+	}
+
+	resp := &pb.GetForecastResponse{
+		Hourly:   make([]*pb.HourlyForecast, 0, len(forecast.Hourly)),
+		Daily:    make([]*pb.DailyForecast, 0, len(forecast.Daily)),
+		Location: locationToPB(loc),
+		Units:    unitsToPB(units),
+		Stale:    forecast.Stale,
+		Age:      durationpb.New(forecast.Age),
+	}
+	for _, hour := range forecast.Hourly {
+		resp.Hourly = append(resp.Hourly, &pb.HourlyForecast{
+			Time:        timestamppb.New(hour.Time),
+			Temperature: hour.Temperature,
+			Humidity:    hour.Humidity,
+			Description: hour.Description,
+		})
+	}
+	for _, day := range forecast.Daily {
+		resp.Daily = append(resp.Daily, &pb.DailyForecast{
+			Date:           timestamppb.New(day.Date),
+			MinTemperature: day.MinTemperature,
+			MaxTemperature: day.MaxTemperature,
+			Humidity:       day.Humidity,
+			Description:    day.Description,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWeatherGRPCServer_GetForecast(t *testing.T) {
+	city := "Kyiv"
+	hourTime := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expectedForecast := domain.Forecast{
+		Hourly: []domain.HourlyForecast{
+			{Time: hourTime, Temperature: 21.5, Humidity: 40, Description: "clear"},
+		},
+		Daily: []domain.DailyForecast{
+			{Date: hourTime.Truncate(24 * time.Hour), MinTemperature: 12, MaxTemperature: 24, Humidity: 45, Description: "sunny"},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetForecastFn: func(ctx context.Context, loc domain.Location, days int, units domain.Units) (domain.Forecast, error) {
+				require.Equal(t, city, loc.Name)
+				require.Equal(t, 2, days)
+				return expectedForecast, nil
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		resp, err := srv.GetForecast(context.Background(), &pb.GetForecastRequest{City: city, Days: 2})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, resp.Hourly, 1)
+		require.Len(t, resp.Daily, 1)
+		assert.Equal(t, hourTime, resp.Hourly[0].Time.AsTime())
+		assert.Equal(t, 21.5, resp.Hourly[0].Temperature)
+		assert.Equal(t, 24.0, resp.Daily[0].MaxTemperature)
+		assert.Equal(t, "sunny", resp.Daily[0].Description)
+	})
+
+	t.Run("DefaultDays", func(t *testing.T) {
+		// Arrange
+		var requestedDays int
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetForecastFn: func(ctx context.Context, loc domain.Location, days int, units domain.Units) (domain.Forecast, error) {
+				requestedDays = days
+				return domain.Forecast{}, nil
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetForecast(context.Background(), &pb.GetForecastRequest{City: city})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, requestedDays)
+	})
+
+	t.Run("InvalidDays", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetForecast(context.Background(), &pb.GetForecastRequest{City: city, Days: 30})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("EmptyCity", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetForecast(context.Background(), &pb.GetForecastRequest{})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+
+	t.Run("CityNotFound", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{
+			GetForecastFn: func(ctx context.Context, loc domain.Location, days int, units domain.Units) (domain.Forecast, error) {
+				return domain.Forecast{}, domain.ErrCityNotFound
+			},
+		}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetForecast(context.Background(), &pb.GetForecastRequest{City: city})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, grpcCode(err))
+	})
+}
@@ -2,26 +2,138 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"time"
 
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type weatherService interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+	Resolve(city string) (domain.Location, error)
+	ResolveCoordinates(lat, lon float64) domain.Location
+	GetCurrent(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error)
+	GetForecast(ctx context.Context, loc domain.Location, days int, units domain.Units) (domain.Forecast, error)
+	GetCurrentBatch(ctx context.Context, queries []domain.CurrentQuery) []domain.CurrentResult
+	GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error)
+	GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error)
+}
+
+type watchService interface {
+	Watch(ctx context.Context, loc domain.Location, units domain.Units) (<-chan domain.Weather, error)
+}
+
+type historyService interface {
+	GetHistory(ctx context.Context, loc domain.Location, from, to time.Time, units domain.Units) ([]domain.Observation, error)
 }
 
 type WeathGRPCServer struct {
 	pb.UnimplementedWeatherServiceServer
 
 	weathSvc       weatherService
+	watchSvc       watchService
 	requestTimeout time.Duration
+
+	// History answers GetHistory, which is unimplemented while it is nil
+	History historyService
 }
 
-func NewWeatherGRPCServer(weathSvc weatherService, requestTimeout time.Duration) *WeathGRPCServer {
+func NewWeatherGRPCServer(weathSvc weatherService, watchSvc watchService, requestTimeout time.Duration) *WeathGRPCServer {
 	return &WeathGRPCServer{
 		weathSvc:       weathSvc,
+		watchSvc:       watchSvc,
 		requestTimeout: requestTimeout,
 	}
 }
+
+// domainToStatusError logs err on behalf of the handler and converts it to a gRPC status error.
+func domainToStatusError(handler string, err error) error {
+	log.Println(fmt.Errorf("%s grpc handler: %v", handler, err))
+	var notFound *domain.LocationNotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		return cityNotFoundStatusError(notFound.Suggestions)
+	case errors.Is(err, domain.ErrCityNotFound):
+		return status.Errorf(codes.NotFound, "city not found")
+	case errors.Is(err, domain.ErrInternal):
+		return status.Errorf(codes.Internal, "failed to get weather")
+	case errors.Is(err, domain.ErrWeatherUnavailable):
+		return status.Errorf(codes.Unavailable, "weather unavailable")
+	case errors.Is(err, domain.ErrProviderUnreliable):
+		return status.Errorf(codes.Unavailable, "weather provider is unreliable")
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return status.Errorf(codes.Unavailable, "weather provider quota exceeded")
+	case errors.Is(err, domain.ErrAlertsUnsupported):
+		return status.Errorf(codes.Unimplemented, "no weather provider has alerts")
+	case errors.Is(err, domain.ErrAirQualityUnsupported):
+		return status.Errorf(codes.Unimplemented, "no weather provider has air quality data")
+	default:
+		return status.Errorf(codes.Internal, "failed to get weather")
+	}
+}
+
+// cityNotFoundStatusError builds a NotFound status carrying "did you mean" suggestions in its details.
+func cityNotFoundStatusError(suggestions []string) error {
+	st := status.New(codes.NotFound, "city not found")
+	if len(suggestions) == 0 {
+		return st.Err()
+	}
+	withDetails, err := st.WithDetails(&pb.CitySuggestions{Suggestions: suggestions})
+	if err != nil {
+		log.Printf("grpc handler: failed to attach suggestions: %v\n", err)
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// unitsFromPB maps requested units to the domain, defaulting to metric when unspecified.
+func unitsFromPB(units pb.Units) (domain.Units, error) {
+	switch units {
+	case pb.Units_UNITS_UNSPECIFIED, pb.Units_UNITS_METRIC:
+		return domain.UnitsMetric, nil
+	case pb.Units_UNITS_IMPERIAL:
+		return domain.UnitsImperial, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown units %d", units)
+	}
+}
+
+func unitsToPB(units domain.Units) pb.Units {
+	if units == domain.UnitsImperial {
+		return pb.Units_UNITS_IMPERIAL
+	}
+	return pb.Units_UNITS_METRIC
+}
+
+func locationToPB(loc domain.Location) *pb.Location {
+	return &pb.Location{
+		Id:       loc.ID,
+		Name:     loc.Name,
+		Country:  loc.Country,
+		Lat:      loc.Lat,
+		Lon:      loc.Lon,
+		Timezone: loc.Timezone,
+	}
+}
+
+func weatherToPB(w domain.Weather) *pb.Weather {
+	return &pb.Weather{
+		Temperature:   w.Temperature,
+		Humidity:      w.Humidity,
+		Description:   w.Description,
+		WindSpeed:     w.WindSpeed,
+		WindDirection: w.WindDirection,
+		Pressure:      w.Pressure,
+		Precipitation: w.Precipitation,
+		CloudCover:    w.CloudCover,
+		Visibility:    w.Visibility,
+		UvIndex:       w.UVIndex,
+		ObservedAt:    timestamppb.New(w.ObservedAt),
+		Source:        w.Source,
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxHistoryDays is the longest time range a single history request may cover.
+const (
+	maxHistoryDays  = 31
+	maxHistoryRange = maxHistoryDays * 24 * time.Hour
+)
+
+func (s *WeathGRPCServer) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	if s.History == nil {
+		return nil, status.Errorf(codes.Unimplemented, "weather history is disabled")
+	}
+	if req.City == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "city is empty")
+	}
+	if req.From == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "from is required")
+	}
+	from, to := req.From.AsTime(), time.Now().UTC()
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	if !from.Before(to) {
+		return nil, status.Errorf(codes.InvalidArgument, "from must be before to")
+	}
+	if to.Sub(from) > maxHistoryRange {
+		return nil, status.Errorf(codes.InvalidArgument, "time range must not exceed %d days", maxHistoryDays)
+	}
+	units, err := unitsFromPB(req.Units)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.weathSvc.Resolve(req.City)
+	if err != nil {
+		return nil, domainToStatusError("history", err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+	observations, err := s.History.GetHistory(ctxWithTimeout, loc, from, to, units)
+	if err != nil {
+		return nil, domainToStatusError("history", err)
+	}
+
+	resp := &pb.GetHistoryResponse{
+		Observations: make([]*pb.Observation, 0, len(observations)),
+		Location:     locationToPB(loc),
+		Units:        unitsToPB(units),
+	}
+	for _, o := range observations {
+		resp.Observations = append(resp.Observations, &pb.Observation{
+			Weather: weatherToPB(o.Weather),
+			Samples: int32(o.Samples),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,152 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mockHistoryService struct {
+	GetHistoryFn func(ctx context.Context, loc domain.Location, from, to time.Time, units domain.Units) ([]domain.Observation, error)
+}
+
+func (m *mockHistoryService) GetHistory(
+	ctx context.Context, loc domain.Location, from, to time.Time, units domain.Units,
+) ([]domain.Observation, error) {
+	if m.GetHistoryFn != nil {
+		return m.GetHistoryFn(ctx, loc, from, to, units)
+	}
+	return nil, nil
+}
+
+func TestWeatherGRPCServer_GetHistory(t *testing.T) {
+	city := "Kyiv"
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		srv.History = &mockHistoryService{
+			GetHistoryFn: func(
+				ctx context.Context, loc domain.Location, gotFrom, gotTo time.Time, units domain.Units,
+			) ([]domain.Observation, error) {
+				require.Equal(t, city, loc.Name)
+				require.Equal(t, from, gotFrom)
+				require.Equal(t, to, gotTo)
+				require.Equal(t, domain.UnitsImperial, units)
+				return []domain.Observation{
+					{Weather: domain.Weather{Temperature: 70, Source: "weatherapi.com", ObservedAt: from}, Samples: 12},
+					{Weather: domain.Weather{Temperature: 72, Source: "tomorrow.io", ObservedAt: from.Add(time.Hour)}, Samples: 1},
+				}, nil
+			},
+		}
+
+		// Act
+		resp, err := srv.GetHistory(context.Background(), &pb.GetHistoryRequest{
+			City: city, From: timestamppb.New(from), To: timestamppb.New(to), Units: pb.Units_UNITS_IMPERIAL,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, resp.Observations, 2)
+		assert.Equal(t, 70.0, resp.Observations[0].Weather.Temperature)
+		assert.Equal(t, "weatherapi.com", resp.Observations[0].Weather.Source)
+		assert.Equal(t, from, resp.Observations[0].Weather.ObservedAt.AsTime())
+		assert.Equal(t, int32(12), resp.Observations[0].Samples)
+		assert.Equal(t, city, resp.Location.Name)
+		assert.Equal(t, pb.Units_UNITS_IMPERIAL, resp.Units)
+	})
+
+	t.Run("DefaultsToNow", func(t *testing.T) {
+		// Arrange
+		var requestedTo time.Time
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		srv.History = &mockHistoryService{
+			GetHistoryFn: func(
+				ctx context.Context, loc domain.Location, from, to time.Time, units domain.Units,
+			) ([]domain.Observation, error) {
+				requestedTo = to
+				return nil, nil
+			},
+		}
+
+		// Act
+		_, err := srv.GetHistory(context.Background(), &pb.GetHistoryRequest{
+			City: city, From: timestamppb.New(time.Now().Add(-time.Hour)),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), requestedTo, time.Minute)
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		tests := []struct {
+			name string
+			req  *pb.GetHistoryRequest
+		}{
+			{"NoCity", &pb.GetHistoryRequest{From: timestamppb.New(from), To: timestamppb.New(to)}},
+			{"NoFrom", &pb.GetHistoryRequest{City: city, To: timestamppb.New(to)}},
+			{"Reversed", &pb.GetHistoryRequest{City: city, From: timestamppb.New(to), To: timestamppb.New(from)}},
+			{"TooLong", &pb.GetHistoryRequest{City: city, From: timestamppb.New(from), To: timestamppb.New(from.AddDate(0, 2, 0))}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+				srv.History = &mockHistoryService{}
+
+				// Act
+				_, err := srv.GetHistory(context.Background(), tt.req)
+
+				// Assert
+				require.Error(t, err)
+				assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+			})
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+
+		// Act
+		_, err := srv.GetHistory(context.Background(), &pb.GetHistoryRequest{City: city, From: timestamppb.New(from)})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, grpcCode(err))
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, nil, 2*time.Millisecond)
+		srv.History = &mockHistoryService{
+			GetHistoryFn: func(
+				ctx context.Context, loc domain.Location, from, to time.Time, units domain.Units,
+			) ([]domain.Observation, error) {
+				return nil, domain.ErrInternal
+			},
+		}
+
+		// Act
+		_, err := srv.GetHistory(context.Background(), &pb.GetHistoryRequest{
+			City: city, From: timestamppb.New(from), To: timestamppb.New(to),
+		})
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, grpcCode(err))
+	})
+}
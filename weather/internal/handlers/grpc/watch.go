@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"google.golang.org/grpc/status"
+)
+
+// WatchCurrent streams readings until the client goes away. Sending blocks on gRPC flow control,
+// meanwhile the watch service keeps only the latest reading, so slow clients skip stale ones.
+func (s *WeathGRPCServer) WatchCurrent(req *pb.GetCurrentRequest, stream pb.WeatherService_WatchCurrentServer) error {
+	units, err := unitsFromPB(req.Units)
+	if err != nil {
+		return err
+	}
+	loc, err := s.resolveCurrentQuery(req)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	updates, err := s.watchSvc.Watch(ctx, loc, units)
+	if err != nil {
+		return domainToStatusError("watch current", err)
+	}
+	for weather := range updates {
+		if err := stream.Send(currentToPB(weather, loc, units)); err != nil {
+			return err
+		}
+	}
+	return status.FromContextError(ctx.Err()).Err()
+}
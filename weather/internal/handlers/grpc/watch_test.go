@@ -0,0 +1,104 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type mockWatchService struct {
+	WatchFn func(ctx context.Context, loc domain.Location, units domain.Units) (<-chan domain.Weather, error)
+}
+
+func (m *mockWatchService) Watch(ctx context.Context, loc domain.Location, units domain.Units) (<-chan domain.Weather, error) {
+	return m.WatchFn(ctx, loc, units)
+}
+
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.GetCurrentResponse
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(resp *pb.GetCurrentResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func TestWeatherGRPCServer_WatchCurrent(t *testing.T) {
+	req := &pb.GetCurrentRequest{Query: &pb.GetCurrentRequest_City{City: "Kyiv"}, Units: pb.Units_UNITS_IMPERIAL}
+
+	t.Run("StreamsUntilCancelled", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		updates := make(chan domain.Weather, 2)
+		updates <- domain.Weather{Temperature: 68}
+		updates <- domain.Weather{Temperature: 70}
+		watchSvc := &mockWatchService{
+			WatchFn: func(ctx context.Context, loc domain.Location, units domain.Units) (<-chan domain.Weather, error) {
+				assert.Equal(t, "Kyiv", loc.Name)
+				assert.Equal(t, domain.UnitsImperial, units)
+				go func() {
+					<-ctx.Done()
+					close(updates)
+				}()
+				return updates, nil
+			},
+		}
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, watchSvc, 2*time.Millisecond)
+		stream := &fakeWatchStream{ctx: ctx}
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		// Act
+		err := srv.WatchCurrent(req, stream)
+
+		// Assert
+		assert.Equal(t, codes.Canceled, grpcCode(err))
+		require.Len(t, stream.sent, 2)
+		assert.Equal(t, 68.0, stream.sent[0].GetWeather().GetTemperature())
+		assert.Equal(t, 70.0, stream.sent[1].GetWeather().GetTemperature())
+		assert.Equal(t, pb.Units_UNITS_IMPERIAL, stream.sent[1].GetUnits())
+		assert.Equal(t, "Kyiv", stream.sent[1].GetLocation().GetName())
+	})
+
+	t.Run("WeatherUnavailable", func(t *testing.T) {
+		// Arrange
+		watchSvc := &mockWatchService{
+			WatchFn: func(ctx context.Context, loc domain.Location, units domain.Units) (<-chan domain.Weather, error) {
+				return nil, domain.ErrWeatherUnavailable
+			},
+		}
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, watchSvc, 2*time.Millisecond)
+
+		// Act
+		err := srv.WatchCurrent(req, &fakeWatchStream{ctx: context.Background()})
+
+		// Assert
+		assert.Equal(t, codes.Unavailable, grpcCode(err))
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		// Arrange
+		srv := handlers.NewWeatherGRPCServer(&mockWeatherService{}, &mockWatchService{}, 2*time.Millisecond)
+
+		// Act
+		err := srv.WatchCurrent(&pb.GetCurrentRequest{}, &fakeWatchStream{ctx: context.Background()})
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, grpcCode(err))
+	})
+}
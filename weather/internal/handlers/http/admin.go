@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type adminService interface {
+	Providers() []domain.ProviderStatus
+	SetBreaker(ctx context.Context, name string, open bool) error
+	SetEnabled(ctx context.Context, name string, enabled bool) error
+	PurgeCache(ctx context.Context, city string) (int, error)
+}
+
+type providerStatusResp struct {
+	Name        string     `json:"name"`
+	Enabled     bool       `json:"enabled"`
+	Breaker     string     `json:"breaker"`
+	Calls       int        `json:"calls"`
+	Failures    int        `json:"failures"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	LatencyMs   int64      `json:"latency_ms"`
+}
+
+// NewAdminAuthMiddleware rejects requests that don't carry token as a bearer token.
+func NewAdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func NewProvidersGETHandler(service adminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		statuses := service.Providers()
+		resp := make([]providerStatusResp, 0, len(statuses))
+		for _, status := range statuses {
+			item := providerStatusResp{
+				Name:      status.Name,
+				Enabled:   status.Enabled,
+				Breaker:   status.Breaker,
+				Calls:     status.Calls,
+				Failures:  status.Failures,
+				LastError: status.LastError,
+				LatencyMs: status.Latency.Milliseconds(),
+			}
+			if !status.LastErrorAt.IsZero() {
+				item.LastErrorAt = &status.LastErrorAt
+			}
+			resp = append(resp, item)
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// NewBreakerPOSTHandler forces the breaker of the provider in the path to the state in the path: open or closed.
+// The other replicas follow.
+func NewBreakerPOSTHandler(service adminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := c.Param("state")
+		if state != "open" && state != "closed" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "state must be open or closed"})
+			return
+		}
+		err := service.SetBreaker(c.Request.Context(), c.Param("name"), state == "open")
+		respondAdmin(c, err)
+	}
+}
+
+// NewProviderSwitchPOSTHandler enables or disables the provider in the path on every replica.
+func NewProviderSwitchPOSTHandler(service adminService, enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := service.SetEnabled(c.Request.Context(), c.Param("name"), enabled)
+		respondAdmin(c, err)
+	}
+}
+
+// NewCacheDELETEHandler purges the cache of the city in the query, or the whole cache without one.
+func NewCacheDELETEHandler(service adminService, requestTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+		defer cancel()
+		purged, err := service.PurgeCache(ctx, c.Query("city"))
+		if errors.Is(err, domain.ErrCityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "city not found"})
+			return
+		}
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to purge cache"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"purged": purged})
+	}
+}
+
+func respondAdmin(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrProviderNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "provider not found"})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,134 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	handlers "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/handlers/http"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "secret"
+
+type mockAdminService struct {
+	breakers map[string]bool
+}
+
+func (m *mockAdminService) Providers() []domain.ProviderStatus {
+	return []domain.ProviderStatus{{
+		Name:          "weatherapi.com",
+		Enabled:       true,
+		Breaker:       "closed",
+		ProviderStats: domain.ProviderStats{Calls: 3, Failures: 1, LastError: "boom", Latency: 250 * time.Millisecond},
+	}}
+}
+
+func (m *mockAdminService) SetBreaker(ctx context.Context, name string, open bool) error {
+	if name != "weatherapi.com" {
+		return domain.ErrProviderNotFound
+	}
+	m.breakers[name] = open
+	return nil
+}
+
+func (m *mockAdminService) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	return nil
+}
+
+func (m *mockAdminService) PurgeCache(ctx context.Context, city string) (int, error) {
+	return 0, nil
+}
+
+func newTestAdminRouter(service *mockAdminService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	admin := router.Group("/admin", handlers.NewAdminAuthMiddleware(testAdminToken))
+	admin.GET("/providers", handlers.NewProvidersGETHandler(service))
+	admin.POST("/providers/:name/breaker/:state", handlers.NewBreakerPOSTHandler(service))
+	return router
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{name: "NoToken", header: "", expectedStatus: http.StatusUnauthorized},
+		{name: "WrongToken", header: "Bearer guess", expectedStatus: http.StatusUnauthorized},
+		{name: "ValidToken", header: "Bearer " + testAdminToken, expectedStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := newTestAdminRouter(&mockAdminService{})
+			req := httptest.NewRequest(http.MethodGet, "/admin/providers", nil)
+			req.Header.Set("Authorization", tt.header)
+
+			// Act
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestProvidersGETHandler(t *testing.T) {
+	// Arrange
+	router := newTestAdminRouter(&mockAdminService{})
+	req := httptest.NewRequest(http.MethodGet, "/admin/providers", nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	// Act
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body []map[string]any
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(t, body, 1)
+	assert.Equal(t, "weatherapi.com", body[0]["name"])
+	assert.Equal(t, "boom", body[0]["last_error"])
+	assert.InDelta(t, 250, body[0]["latency_ms"], 0)
+	assert.NotContains(t, body[0], "last_error_at")
+}
+
+func TestBreakerPOSTHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "Open", path: "/admin/providers/weatherapi.com/breaker/open", expectedStatus: http.StatusNoContent},
+		{name: "InvalidState", path: "/admin/providers/weatherapi.com/breaker/ajar", expectedStatus: http.StatusBadRequest},
+		{name: "UnknownProvider", path: "/admin/providers/example.com/breaker/closed", expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := &mockAdminService{breakers: make(map[string]bool)}
+			router := newTestAdminRouter(service)
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+			// Act
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
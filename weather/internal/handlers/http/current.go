@@ -12,7 +12,8 @@ import (
 )
 
 type weatherService interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+	Resolve(city string) (domain.Location, error)
+	GetCurrent(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error)
 }
 
 type weatherResp struct {
@@ -24,13 +25,29 @@ type weatherResp struct {
 func NewWeatherGETHandler(service weatherService, requestTimeout time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		city := c.Query("city")
-		if city == "" {
+		units := domain.Units(c.DefaultQuery("units", string(domain.UnitsMetric)))
+		if city == "" || (units != domain.UnitsMetric && units != domain.UnitsImperial) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 			return
 		}
+		loc, err := service.Resolve(city)
+		var notFound *domain.LocationNotFoundError
+		if errors.As(err, &notFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "city not found", "suggestions": notFound.Suggestions})
+			return
+		}
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather for given city"})
+			return
+		}
 		ctxWithTimeout, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
 		defer cancel()
-		weatherEnt, err := service.GetCurrent(ctxWithTimeout, city)
+		weatherEnt, err := service.GetCurrent(ctxWithTimeout, loc, units)
+		if errors.As(err, &notFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "city not found", "suggestions": notFound.Suggestions})
+			return
+		}
 		if errors.Is(err, domain.ErrCityNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "city not found"})
 			return
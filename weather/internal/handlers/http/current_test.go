@@ -4,6 +4,7 @@ package handlers_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -21,8 +22,15 @@ type mockWeatherRepo struct {
 	mock.Mock
 }
 
-func (m *mockWeatherRepo) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	args := m.Called(ctx, city)
+func (m *mockWeatherRepo) Resolve(city string) (domain.Location, error) {
+	if city == "Bagatkino" {
+		return domain.Location{}, &domain.LocationNotFoundError{Query: city}
+	}
+	return domain.Location{Name: city}, nil
+}
+
+func (m *mockWeatherRepo) GetCurrent(ctx context.Context, loc domain.Location, _ domain.Units) (domain.Weather, error) {
+	args := m.Called(ctx, loc)
 	weather, ok := args.Get(0).(domain.Weather)
 	if !ok {
 		return domain.Weather{}, fmt.Errorf("mock: expected models.Weather, got %T", weather)
@@ -81,9 +89,9 @@ func TestWeatherHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockRepo := new(mockWeatherRepo)
-			if tt.city != "" {
+			if tt.city != "" && !errors.Is(tt.mockError, domain.ErrCityNotFound) {
 				mockRepo.
-					On("GetCurrent", mock.Anything, tt.city).
+					On("GetCurrent", mock.Anything, domain.Location{Name: tt.city}).
 					Return(tt.mockReturn, tt.mockError)
 			}
 			router := gin.New()
@@ -104,7 +112,11 @@ func TestWeatherHandler(t *testing.T) {
 type timeoutErrRepo struct {
 }
 
-func (t *timeoutErrRepo) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (t *timeoutErrRepo) Resolve(city string) (domain.Location, error) {
+	return domain.Location{Name: city}, nil
+}
+
+func (t *timeoutErrRepo) GetCurrent(ctx context.Context, loc domain.Location, _ domain.Units) (domain.Weather, error) {
 	select {
 	case <-time.After(time.Second):
 		return domain.Weather{}, nil
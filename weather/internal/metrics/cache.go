@@ -15,6 +15,11 @@ type WeatherMetrics struct {
 	cacheHits     prometheus.Counter
 	cacheMisses   prometheus.Counter
 	accessLatency prometheus.Histogram
+	loads         *prometheus.CounterVec
+	stale         *prometheus.CounterVec
+	tierHits      *prometheus.CounterVec
+	tierMisses    *prometheus.CounterVec
+	warmups       *prometheus.CounterVec
 }
 
 func NewWeatherMetrics(reg prometheus.Registerer) *WeatherMetrics {
@@ -33,15 +38,45 @@ func NewWeatherMetrics(reg prometheus.Registerer) *WeatherMetrics {
 		Help:    "Duration of weather cache requests in seconds",
 		Buckets: prometheus.DefBuckets,
 	})
+	loads := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_cache_loads",
+		Help: "Number of cache misses by how they were served: leader, coalesced or replica",
+	}, []string{"role"})
+
+	stale := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_cache_stale",
+		Help: "Number of expired values served, while revalidating or because providers failed",
+	}, []string{"reason"})
+
+	tierHits := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_cache_tier_hits",
+		Help: "Number of cache hits per tier: local or remote",
+	}, []string{"tier"})
+
+	tierMisses := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_cache_tier_misses",
+		Help: "Number of cache misses per tier: local or remote",
+	}, []string{"tier"})
+
+	warmups := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_cache_warmups",
+		Help: "Number of locations handled by the cache warm-up by result: refreshed, fresh or failed",
+	}, []string{"result"})
+
 	registerWeatherMetricsOnce.Do(func() {
 		log.Println("Registering weather cache metrics")
-		reg.MustRegister(cacheHits, cacheMisses, accessLatency)
+		reg.MustRegister(cacheHits, cacheMisses, accessLatency, loads, stale, tierHits, tierMisses, warmups)
 	})
 
 	return &WeatherMetrics{
 		cacheHits:     cacheHits,
 		cacheMisses:   cacheMisses,
 		accessLatency: accessLatency,
+		loads:         loads,
+		stale:         stale,
+		tierHits:      tierHits,
+		tierMisses:    tierMisses,
+		warmups:       warmups,
 	}
 }
 
@@ -57,3 +92,23 @@ func (m *WeatherMetrics) CacheMiss() {
 func (m *WeatherMetrics) CacheAccessLatency(seconds float64) {
 	m.accessLatency.Observe(seconds)
 }
+
+func (m *WeatherMetrics) CacheLoad(role string) {
+	m.loads.WithLabelValues(role).Inc()
+}
+
+func (m *WeatherMetrics) CacheStale(reason string) {
+	m.stale.WithLabelValues(reason).Inc()
+}
+
+func (m *WeatherMetrics) CacheTierHit(tier string) {
+	m.tierHits.WithLabelValues(tier).Inc()
+}
+
+func (m *WeatherMetrics) CacheTierMiss(tier string) {
+	m.tierMisses.WithLabelValues(tier).Inc()
+}
+
+func (m *WeatherMetrics) CacheWarmup(result string) {
+	m.warmups.WithLabelValues(result).Inc()
+}
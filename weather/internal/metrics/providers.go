@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"log"
+	"sync"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	registerProviderMetricsOnce sync.Once
+)
+
+// the last chain depth bucket, deeper answers fall into +Inf
+const maxChainDepth = 5
+
+type ProviderMetrics struct {
+	disagreement *prometheus.HistogramVec
+	outliers     *prometheus.CounterVec
+	hedges       prometheus.Counter
+	winners      *prometheus.CounterVec
+	rank         *prometheus.GaugeVec
+	score        *prometheus.GaugeVec
+	quota        *prometheus.GaugeVec
+	retries      *prometheus.CounterVec
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	breaker      *prometheus.GaugeVec
+	depth        prometheus.Histogram
+}
+
+func NewProviderMetrics(reg prometheus.Registerer) *ProviderMetrics {
+	disagreement := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "weather_provider_disagreement",
+		Help:    "Largest distance between a provider answer and the consensus value, per field",
+		Buckets: []float64{0.5, 1, 2, 3, 5, 10, 20, 45, 90},
+	}, []string{"field"})
+
+	outliers := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_provider_outliers",
+		Help: "Number of provider answers that were out of tolerance from the consensus value",
+	}, []string{"provider", "field"})
+
+	hedges := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "weather_provider_hedges",
+		Help: "Number of hedged provider requests fired because the running one was slow",
+	})
+
+	winners := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_provider_winners",
+		Help: "Number of fallback chain answers by the attempt that won: first, hedge or fallback",
+	}, []string{"attempt"})
+
+	rank := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "weather_provider_rank",
+		Help: "Position of the provider in the fallback chain, 1 is tried first",
+	}, []string{"provider"})
+
+	score := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "weather_provider_score",
+		Help: "Health score of the provider from success rate, latency and breaker state, 0 to 1",
+	}, []string{"provider"})
+
+	quota := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "weather_provider_quota_remaining",
+		Help: "Calls left within the provider quota, per window: minute or day",
+	}, []string{"provider", "window"})
+
+	retries := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_provider_retries",
+		Help: "Number of provider calls repeated after a transient failure",
+	}, []string{"provider"})
+
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_provider_requests",
+		Help: "Number of provider calls by outcome: ok, not_found, unavailable, internal, breaker_open, disabled, quota_exceeded or canceled",
+	}, []string{"provider", "outcome"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "weather_provider_request_duration",
+		Help:    "Duration of provider calls in seconds, retries included",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"provider"})
+
+	breaker := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "weather_provider_breaker_state",
+		Help: "State of the provider circuit breaker: 0 closed, 1 half-open, 2 open",
+	}, []string{"provider"})
+
+	depth := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "weather_provider_chain_depth",
+		Help:    "Position in the fallback chain of the provider that answered, 1 is tried first",
+		Buckets: prometheus.LinearBuckets(1, 1, maxChainDepth),
+	})
+
+	registerProviderMetricsOnce.Do(func() {
+		log.Println("Registering weather provider metrics")
+		reg.MustRegister(disagreement, outliers, hedges, winners, rank, score, quota, retries, requests, duration, breaker, depth)
+	})
+
+	return &ProviderMetrics{
+		disagreement: disagreement,
+		outliers:     outliers,
+		hedges:       hedges,
+		winners:      winners,
+		rank:         rank,
+		score:        score,
+		quota:        quota,
+		retries:      retries,
+		requests:     requests,
+		duration:     duration,
+		breaker:      breaker,
+		depth:        depth,
+	}
+}
+
+func (m *ProviderMetrics) ProviderDisagreement(field string, spread float64) {
+	m.disagreement.WithLabelValues(field).Observe(spread)
+}
+
+func (m *ProviderMetrics) ProviderOutlier(provider, field string) {
+	m.outliers.WithLabelValues(provider, field).Inc()
+}
+
+func (m *ProviderMetrics) ProviderHedge() {
+	m.hedges.Inc()
+}
+
+func (m *ProviderMetrics) ProviderWinner(attempt string) {
+	m.winners.WithLabelValues(attempt).Inc()
+}
+
+func (m *ProviderMetrics) ProviderRank(provider string, rank int, score float64) {
+	m.rank.WithLabelValues(provider).Set(float64(rank))
+	m.score.WithLabelValues(provider).Set(score)
+}
+
+func (m *ProviderMetrics) ProviderQuotaRemaining(provider, window string, remaining int) {
+	m.quota.WithLabelValues(provider, window).Set(float64(remaining))
+}
+
+func (m *ProviderMetrics) ProviderRetry(provider string) {
+	m.retries.WithLabelValues(provider).Inc()
+}
+
+func (m *ProviderMetrics) ProviderRequest(provider, outcome string, duration float64) {
+	m.requests.WithLabelValues(provider, outcome).Inc()
+	m.duration.WithLabelValues(provider).Observe(duration)
+}
+
+func (m *ProviderMetrics) ProviderBreakerState(provider string, state cb.State) {
+	m.breaker.WithLabelValues(provider).Set(float64(state))
+}
+
+func (m *ProviderMetrics) ProviderChainDepth(position int) {
+	m.depth.Observe(float64(position))
+}
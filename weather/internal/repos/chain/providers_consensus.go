@@ -0,0 +1,402 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const fullCircle = 360.0
+
+type consensusMetrics interface {
+	ProviderDisagreement(field string, spread float64)
+	ProviderOutlier(provider, field string)
+}
+
+// consensusField describes how a numeric weather field is merged and when a provider is too far off.
+type consensusField struct {
+	name  string
+	field domain.WeatherFields
+	get   func(w *domain.Weather) *float64
+	// tolerance is the largest distance from the consensus value that is not flagged as an outlier
+	tolerance float64
+	// circular fields are angles, so 350° and 10° are 20° apart
+	circular bool
+}
+
+var consensusFields = []consensusField{
+	{name: "temperature", field: domain.FieldTemperature, get: func(w *domain.Weather) *float64 { return &w.Temperature }, tolerance: 3},
+	{name: "humidity", field: domain.FieldHumidity, get: func(w *domain.Weather) *float64 { return &w.Humidity }, tolerance: 15},
+	{name: "wind_speed", field: domain.FieldWindSpeed, get: func(w *domain.Weather) *float64 { return &w.WindSpeed }, tolerance: 3},
+	{
+		name: "wind_direction", field: domain.FieldWindDirection, get: func(w *domain.Weather) *float64 { return &w.WindDirection },
+		tolerance: 45, circular: true,
+	},
+	{name: "pressure", field: domain.FieldPressure, get: func(w *domain.Weather) *float64 { return &w.Pressure }, tolerance: 5},
+	{
+		name: "precipitation", field: domain.FieldPrecipitation, get: func(w *domain.Weather) *float64 { return &w.Precipitation },
+		tolerance: 2,
+	},
+	{name: "cloud_cover", field: domain.FieldCloudCover, get: func(w *domain.Weather) *float64 { return &w.CloudCover }, tolerance: 25},
+	{name: "visibility", field: domain.FieldVisibility, get: func(w *domain.Weather) *float64 { return &w.Visibility }, tolerance: 5},
+	{name: "uv_index", field: domain.FieldUVIndex, get: func(w *domain.Weather) *float64 { return &w.UVIndex }, tolerance: 2},
+}
+
+// airQualityFields are the concentrations of AirQuality merged by median.
+var airQualityFields = []struct {
+	field domain.AirQualityFields
+	get   func(a *domain.AirQuality) *float64
+}{
+	{domain.FieldPM25, func(a *domain.AirQuality) *float64 { return &a.PM25 }},
+	{domain.FieldPM10, func(a *domain.AirQuality) *float64 { return &a.PM10 }},
+	{domain.FieldO3, func(a *domain.AirQuality) *float64 { return &a.O3 }},
+	{domain.FieldNO2, func(a *domain.AirQuality) *float64 { return &a.NO2 }},
+}
+
+// ProvidersConsensusChain asks all providers at once and merges their answers, so a single
+// provider that is badly off does not decide the result. Numeric fields are merged by median,
+// descriptions by majority. Providers behind an open breaker fail immediately and are left out.
+// Answers still outstanding after Timeout are left out too, so one slow provider does not hold up the rest.
+type ProvidersConsensusChain struct {
+	Repos   []WeatherProvider
+	Metrics consensusMetrics
+	// how long answers are collected before merging the ones that arrived, 0 waits until ctx is done
+	Timeout time.Duration
+}
+
+func NewProvidersConsensusChain(timeout time.Duration, metrics consensusMetrics, repos ...WeatherProvider) *ProvidersConsensusChain {
+	return &ProvidersConsensusChain{Repos: repos, Metrics: metrics, Timeout: timeout}
+}
+
+func (c *ProvidersConsensusChain) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	answers, err := gather(ctx, c.Timeout, c.Repos, func(repo WeatherProvider) (domain.Weather, error) {
+		return repo.GetCurrent(ctx, loc)
+	})
+	if err != nil {
+		return domain.Weather{}, err
+	}
+	return c.mergeWeather(loc, answers), nil
+}
+
+func (c *ProvidersConsensusChain) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	answers, err := gather(ctx, c.Timeout, c.Repos, func(repo WeatherProvider) (domain.Forecast, error) {
+		return repo.GetForecast(ctx, loc, days)
+	})
+	if err != nil {
+		return domain.Forecast{}, err
+	}
+	return mergeForecast(answers), nil
+}
+
+// GetAlerts returns the alerts of every provider that has them, an alert reported by several providers once.
+func (c *ProvidersConsensusChain) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	answers, err := gather(ctx, c.Timeout, c.Repos, func(repo WeatherProvider) (domain.Alerts, error) {
+		return repo.GetAlerts(ctx, loc)
+	})
+	if err != nil {
+		return domain.Alerts{}, err
+	}
+	return mergeAlerts(answers), nil
+}
+
+// GetAirQuality merges the air quality of every provider that has it. Outliers are not flagged,
+// pollution varies too much within a city for a distant station to be wrong.
+func (c *ProvidersConsensusChain) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	answers, err := gather(ctx, c.Timeout, c.Repos, func(repo WeatherProvider) (domain.AirQuality, error) {
+		return repo.GetAirQuality(ctx, loc)
+	})
+	if err != nil {
+		return domain.AirQuality{}, err
+	}
+	return mergeAirQuality(answers), nil
+}
+
+// mergeWeather builds the consensus reading, reports how far apart the providers were
+// and flags the providers whose values are out of tolerance. A field a provider did not report
+// is left out of its median and outliers, and is missing from the result if no provider reported it.
+func (c *ProvidersConsensusChain) mergeWeather(loc domain.Location, answers []domain.Weather) domain.Weather {
+	merged := domain.Weather{}
+	descriptions := make([]string, 0, len(answers))
+	sources := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		descriptions = append(descriptions, answer.Description)
+		sources = append(sources, answer.Source)
+		if answer.ObservedAt.After(merged.ObservedAt) {
+			merged.ObservedAt = answer.ObservedAt
+		}
+	}
+	merged.Description = majority(descriptions)
+	merged.Source = strings.Join(sources, ",")
+
+	for _, field := range consensusFields {
+		values := make([]float64, 0, len(answers))
+		// reporting[j] is the index of the answer values[j] comes from
+		reporting := make([]int, 0, len(answers))
+		for i := range answers {
+			if answers[i].Missing.Has(field.field) {
+				continue
+			}
+			values = append(values, *field.get(&answers[i]))
+			reporting = append(reporting, i)
+		}
+		if len(values) == 0 {
+			merged.Missing |= field.field
+			continue
+		}
+		consensus := median(values)
+		if field.circular {
+			consensus = circularMedian(values)
+		}
+		*field.get(&merged) = consensus
+
+		if len(values) < 2 {
+			continue
+		}
+		spread := 0.0
+		for j, value := range values {
+			dist := field.distance(value, consensus)
+			spread = math.Max(spread, dist)
+			if dist > field.tolerance {
+				source := answers[reporting[j]].Source
+				log.Printf("chain: %s reports %s %.1f for %s, consensus is %.1f\n",
+					source, field.name, value, loc, consensus)
+				c.Metrics.ProviderOutlier(source, field.name)
+			}
+		}
+		c.Metrics.ProviderDisagreement(field.name, spread)
+	}
+	return merged
+}
+
+func (f consensusField) distance(a, b float64) float64 {
+	dist := math.Abs(a - b)
+	if f.circular {
+		dist = math.Mod(dist, fullCircle)
+		return math.Min(dist, fullCircle-dist)
+	}
+	return dist
+}
+
+// mergeForecast merges hours and days that share a timestamp, keeping every timestamp any provider returned.
+func mergeForecast(answers []domain.Forecast) domain.Forecast {
+	hours := make(map[time.Time][]domain.HourlyForecast)
+	days := make(map[time.Time][]domain.DailyForecast)
+	for _, answer := range answers {
+		for _, hour := range answer.Hourly {
+			hours[hour.Time] = append(hours[hour.Time], hour)
+		}
+		for _, day := range answer.Daily {
+			days[day.Date] = append(days[day.Date], day)
+		}
+	}
+
+	merged := domain.Forecast{
+		Hourly: make([]domain.HourlyForecast, 0, len(hours)),
+		Daily:  make([]domain.DailyForecast, 0, len(days)),
+	}
+	for t, same := range hours {
+		hour := domain.HourlyForecast{Time: t}
+		hour.Temperature = medianOf(same, func(h domain.HourlyForecast) float64 { return h.Temperature })
+		hour.Humidity = medianOf(same, func(h domain.HourlyForecast) float64 { return h.Humidity })
+		hour.Description = majority(collect(same, func(h domain.HourlyForecast) string { return h.Description }))
+		merged.Hourly = append(merged.Hourly, hour)
+	}
+	for date, same := range days {
+		day := domain.DailyForecast{Date: date}
+		day.MinTemperature = medianOf(same, func(d domain.DailyForecast) float64 { return d.MinTemperature })
+		day.MaxTemperature = medianOf(same, func(d domain.DailyForecast) float64 { return d.MaxTemperature })
+		day.Humidity = medianOf(same, func(d domain.DailyForecast) float64 { return d.Humidity })
+		day.Description = majority(collect(same, func(d domain.DailyForecast) string { return d.Description }))
+		merged.Daily = append(merged.Daily, day)
+	}
+	sort.Slice(merged.Hourly, func(i, j int) bool { return merged.Hourly[i].Time.Before(merged.Hourly[j].Time) })
+	sort.Slice(merged.Daily, func(i, j int) bool { return merged.Daily[i].Date.Before(merged.Daily[j].Date) })
+	return merged
+}
+
+// alertKey identifies an alert across providers, which word everything else about it differently.
+type alertKey struct {
+	event     string
+	effective int64
+}
+
+// mergeAlerts joins the alerts of all answers, keeping the first answer's version of an alert
+// others report too.
+func mergeAlerts(answers []domain.Alerts) domain.Alerts {
+	var merged domain.Alerts
+	sources := make([]string, 0, len(answers))
+	seen := make(map[alertKey]bool)
+	for _, answer := range answers {
+		sources = append(sources, answer.Source)
+		for _, alert := range answer.Alerts {
+			key := alertKey{event: strings.ToLower(alert.Event), effective: alert.Effective.Unix()}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.Alerts = append(merged.Alerts, alert)
+		}
+	}
+	merged.Source = strings.Join(sources, ",")
+	return merged
+}
+
+// mergeAirQuality takes the median of every concentration and of the pollen count of every plant among
+// the providers that count it, and computes the index of the merged concentrations. A concentration
+// no provider reported is missing from the result.
+func mergeAirQuality(answers []domain.AirQuality) domain.AirQuality {
+	merged := domain.AirQuality{
+		Source: strings.Join(collect(answers, func(a domain.AirQuality) string { return a.Source }), ","),
+	}
+	for _, field := range airQualityFields {
+		values := make([]float64, 0, len(answers))
+		for i := range answers {
+			if !answers[i].Missing.Has(field.field) {
+				values = append(values, *field.get(&answers[i]))
+			}
+		}
+		if len(values) == 0 {
+			merged.Missing |= field.field
+			continue
+		}
+		*field.get(&merged) = median(values)
+	}
+	merged.AQI = domain.USAQI(merged.PM25, merged.PM10, merged.O3, merged.NO2)
+	pollen := make(map[string][]float64)
+	for _, answer := range answers {
+		for plant, count := range answer.Pollen {
+			pollen[plant] = append(pollen[plant], count)
+		}
+		if answer.ObservedAt.After(merged.ObservedAt) {
+			merged.ObservedAt = answer.ObservedAt
+		}
+	}
+	if len(pollen) > 0 {
+		merged.Pollen = make(map[string]float64, len(pollen))
+		for plant, counts := range pollen {
+			merged.Pollen[plant] = median(counts)
+		}
+	}
+	return merged
+}
+
+type indexedResult[T any] struct {
+	index int
+	value T
+	err   error
+}
+
+// gather calls all repos concurrently and returns the successful results that arrived before ctx was done
+// or timeout passed, in the order of repos. Calls still running after that finish in the background.
+func gather[T any](
+	ctx context.Context, timeout time.Duration, repos []WeatherProvider, call func(repo WeatherProvider) (T, error),
+) ([]T, error) {
+	results := make(chan indexedResult[T], len(repos))
+	for i, repo := range repos {
+		go func() {
+			value, err := call(repo)
+			results <- indexedResult[T]{index: i, value: value, err: err}
+		}()
+	}
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	var succeeded []indexedResult[T]
+	var lastError error
+wait:
+	for range repos {
+		select {
+		case <-ctx.Done():
+			log.Printf("chain: %v, merging %d answers\n", ctx.Err(), len(succeeded))
+			break wait
+		case <-deadline:
+			log.Printf("chain: no more answers after %v, merging %d answers\n", timeout, len(succeeded))
+			break wait
+		case result := <-results:
+			if result.err != nil {
+				err := fmt.Errorf("chain: %w", result.err)
+				log.Println(err)
+				lastError = moreTelling(lastError, err)
+				continue
+			}
+			succeeded = append(succeeded, result)
+		}
+	}
+	if len(succeeded) == 0 {
+		if lastError == nil {
+			lastError = fmt.Errorf("chain: %w", domain.ErrWeatherUnavailable)
+		}
+		return nil, lastError
+	}
+
+	sort.Slice(succeeded, func(i, j int) bool { return succeeded[i].index < succeeded[j].index })
+	values := make([]T, 0, len(succeeded))
+	for _, result := range succeeded {
+		values = append(values, result.value)
+	}
+	return values, nil
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// circularMedian returns the angle with the smallest total distance to all others.
+func circularMedian(angles []float64) float64 {
+	field := consensusField{circular: true}
+	best, bestTotal := angles[0], math.Inf(1)
+	for _, candidate := range angles {
+		total := 0.0
+		for _, angle := range angles {
+			total += field.distance(candidate, angle)
+		}
+		if total < bestTotal {
+			best, bestTotal = candidate, total
+		}
+	}
+	return best
+}
+
+// majority returns the most common value, ties go to the one seen first.
+func majority(values []string) string {
+	counts := make(map[string]int, len(values))
+	for _, value := range values {
+		counts[value]++
+	}
+	best := ""
+	for _, value := range values {
+		if counts[value] > counts[best] {
+			best = value
+		}
+	}
+	return best
+}
+
+func medianOf[T any](items []T, get func(T) float64) float64 {
+	return median(collect(items, get))
+}
+
+func collect[T, V any](items []T, get func(T) V) []V {
+	values := make([]V, 0, len(items))
+	for _, item := range items {
+		values = append(values, get(item))
+	}
+	return values
+}
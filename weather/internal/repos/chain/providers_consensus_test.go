@@ -0,0 +1,260 @@
+//go:build unit
+
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockConsensusMetrics struct {
+	disagreement map[string]float64
+	outliers     map[string][]string
+}
+
+func newMockConsensusMetrics() *mockConsensusMetrics {
+	return &mockConsensusMetrics{
+		disagreement: make(map[string]float64),
+		outliers:     make(map[string][]string),
+	}
+}
+
+func (m *mockConsensusMetrics) ProviderDisagreement(field string, spread float64) {
+	m.disagreement[field] = spread
+}
+
+func (m *mockConsensusMetrics) ProviderOutlier(provider, field string) {
+	m.outliers[field] = append(m.outliers[field], provider)
+}
+
+type slowProvider struct {
+	mockProvider
+	delay time.Duration
+}
+
+func (m *slowProvider) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	select {
+	case <-time.After(m.delay):
+		return m.mockProvider.GetCurrent(ctx, loc)
+	case <-ctx.Done():
+		return domain.Weather{}, ctx.Err()
+	}
+}
+
+func TestProvidersConsensusChain_MedianAndMajority(t *testing.T) {
+	// Arrange
+	first := &mockProvider{resp: domain.Weather{Temperature: 20, Humidity: 50, WindDirection: 350, Description: "Sunny", Source: "a"}}
+	second := &mockProvider{resp: domain.Weather{Temperature: 21, Humidity: 55, WindDirection: 10, Description: "Clear", Source: "b"}}
+	third := &mockProvider{resp: domain.Weather{Temperature: 35, Humidity: 52, WindDirection: 0, Description: "Sunny", Source: "c"}}
+	metrics := newMockConsensusMetrics()
+	c := chain.NewProvidersConsensusChain(0, metrics, first, second, third)
+
+	// Act
+	weather, err := c.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 21.0, weather.Temperature)
+	assert.Equal(t, 52.0, weather.Humidity)
+	assert.Equal(t, 0.0, weather.WindDirection)
+	assert.Equal(t, "Sunny", weather.Description)
+	assert.Equal(t, "a,b,c", weather.Source)
+	assert.Equal(t, []string{"c"}, metrics.outliers["temperature"])
+	assert.Empty(t, metrics.outliers["wind_direction"])
+	assert.Equal(t, 14.0, metrics.disagreement["temperature"])
+	assert.Equal(t, 10.0, metrics.disagreement["wind_direction"])
+}
+
+func TestProvidersConsensusChain_IgnoresMissingFields(t *testing.T) {
+	// Arrange
+	first := &mockProvider{resp: domain.Weather{Temperature: 20, UVIndex: 6, Source: "weatherapi.com"}}
+	second := &mockProvider{resp: domain.Weather{Temperature: 21, Source: "openweathermap.org", Missing: domain.FieldUVIndex}}
+	metrics := newMockConsensusMetrics()
+	c := chain.NewProvidersConsensusChain(0, metrics, first, second)
+
+	// Act
+	weather, err := c.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, weather.UVIndex, "a provider without the field must not pull the median down")
+	assert.False(t, weather.Missing.Has(domain.FieldUVIndex))
+	assert.Empty(t, metrics.outliers["uv_index"])
+	assert.NotContains(t, metrics.disagreement, "uv_index", "a single value has nothing to disagree with")
+	assert.Contains(t, metrics.disagreement, "temperature")
+}
+
+func TestProvidersConsensusChain_FieldNobodyReports(t *testing.T) {
+	// Arrange
+	first := &mockProvider{resp: domain.Weather{Temperature: 20, Source: "a", Missing: domain.FieldUVIndex}}
+	second := &mockProvider{resp: domain.Weather{Temperature: 21, Source: "b", Missing: domain.FieldUVIndex | domain.FieldVisibility}}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), first, second)
+
+	// Act
+	weather, err := c.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.FieldUVIndex, weather.Missing)
+}
+
+func TestProvidersConsensusChain_SkipsFailedProviders(t *testing.T) {
+	// Arrange
+	first := &mockProvider{err: domain.ErrProviderUnreliable}
+	second := &mockProvider{resp: domain.Weather{Temperature: 10, Description: "Rain", Source: "b"}}
+	metrics := newMockConsensusMetrics()
+	c := chain.NewProvidersConsensusChain(0, metrics, first, second)
+
+	// Act
+	weather, err := c.GetCurrent(context.Background(), domain.Location{Name: "Lviv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, weather.Temperature)
+	assert.Equal(t, "b", weather.Source)
+	assert.True(t, first.called)
+	assert.Empty(t, metrics.disagreement, "a single answer has nothing to disagree with")
+}
+
+func TestProvidersConsensusChain_AllFail(t *testing.T) {
+	// Arrange
+	first := &mockProvider{err: errors.New("first fail")}
+	second := &mockProvider{err: domain.ErrWeatherUnavailable}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), first, second)
+
+	// Act
+	_, err := c.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chain:")
+}
+
+func TestProvidersConsensusChain_MergesAnswersBeforeDeadline(t *testing.T) {
+	// Arrange
+	fast := &mockProvider{resp: domain.Weather{Temperature: 15, Source: "fast"}}
+	slow := &slowProvider{mockProvider: mockProvider{resp: domain.Weather{Temperature: 40, Source: "slow"}}, delay: time.Second}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), fast, slow)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	weather, err := c.GetCurrent(ctx, domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, weather.Temperature)
+	assert.Equal(t, "fast", weather.Source)
+}
+
+func TestProvidersConsensusChain_Forecast(t *testing.T) {
+	// Arrange
+	day1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	first := &mockProvider{forecastResp: domain.Forecast{Daily: []domain.DailyForecast{
+		{Date: day1, MaxTemperature: 25, Description: "Sunny"},
+		{Date: day2, MaxTemperature: 20, Description: "Rain"},
+	}}}
+	second := &mockProvider{forecastResp: domain.Forecast{Daily: []domain.DailyForecast{
+		{Date: day1, MaxTemperature: 27, Description: "Sunny"},
+	}}}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), first, second)
+
+	// Act
+	forecast, err := c.GetForecast(context.Background(), domain.Location{Name: "Kyiv"}, 2)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, forecast.Daily, 2)
+	assert.Equal(t, day1, forecast.Daily[0].Date)
+	assert.Equal(t, 26.0, forecast.Daily[0].MaxTemperature)
+	assert.Equal(t, "Sunny", forecast.Daily[0].Description)
+	assert.Equal(t, 20.0, forecast.Daily[1].MaxTemperature)
+}
+
+func TestProvidersConsensusChain_Alerts(t *testing.T) {
+	// Arrange
+	effective := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+	first := &mockProvider{alertsResp: domain.Alerts{Source: "weatherapi.com", Alerts: []domain.Alert{
+		{Event: "Flood Warning", Headline: "Flood Warning until June 2", Severity: domain.AlertSeveritySevere, Effective: effective},
+	}}}
+	second := &mockProvider{alertsResp: domain.Alerts{Source: "visualcrossing.com", Alerts: []domain.Alert{
+		{Event: "flood warning", Headline: "Flood warning", Severity: domain.AlertSeverityUnknown, Effective: effective},
+		{Event: "Wind Advisory", Effective: effective.Add(time.Hour)},
+	}}}
+	without := &mockProvider{err: domain.ErrAlertsUnsupported}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), first, second, without)
+
+	// Act
+	alerts, err := c.GetAlerts(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "weatherapi.com,visualcrossing.com", alerts.Source)
+	require.Len(t, alerts.Alerts, 2)
+	assert.Equal(t, domain.AlertSeveritySevere, alerts.Alerts[0].Severity, "the first provider's version should be kept")
+	assert.Equal(t, "Wind Advisory", alerts.Alerts[1].Event)
+}
+
+func TestProvidersConsensusChain_AirQuality(t *testing.T) {
+	// Arrange
+	first := &mockProvider{airResp: domain.AirQuality{
+		PM25: 10, PM10: 20, O3: 50, NO2: 10, Pollen: map[string]float64{"birch": 30}, Source: "weatherapi.com",
+	}}
+	second := &mockProvider{airResp: domain.AirQuality{PM25: 40, PM10: 60, O3: 70, NO2: 30, Source: "openweathermap.org"}}
+	third := &mockProvider{airResp: domain.AirQuality{PM25: 20, PM10: 30, O3: 60, NO2: 20, Source: "regional"}}
+	without := &mockProvider{err: domain.ErrAirQualityUnsupported}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), first, second, third, without)
+
+	// Act
+	airQuality, err := c.GetAirQuality(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, airQuality.PM25)
+	assert.Equal(t, 30.0, airQuality.PM10)
+	assert.Equal(t, 60.0, airQuality.O3)
+	assert.Equal(t, 20.0, airQuality.NO2)
+	assert.Equal(t, domain.USAQI(20, 30, 60, 20), airQuality.AQI, "the index should be computed from the merged values")
+	assert.Equal(t, map[string]float64{"birch": 30}, airQuality.Pollen)
+	assert.Equal(t, "weatherapi.com,openweathermap.org,regional", airQuality.Source)
+}
+
+func TestProvidersConsensusChain_AirQualitySkipsUnreported(t *testing.T) {
+	// Arrange
+	first := &mockProvider{airResp: domain.AirQuality{PM25: 10, PM10: 20, Missing: domain.FieldO3 | domain.FieldNO2, Source: "a"}}
+	second := &mockProvider{airResp: domain.AirQuality{PM25: 30, PM10: 40, O3: 60, Missing: domain.FieldNO2, Source: "b"}}
+	c := chain.NewProvidersConsensusChain(0, newMockConsensusMetrics(), first, second)
+
+	// Act
+	airQuality, err := c.GetAirQuality(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, airQuality.PM25)
+	assert.Equal(t, 60.0, airQuality.O3, "a provider without O3 should not pull the median towards zero")
+	assert.Equal(t, domain.FieldNO2, airQuality.Missing)
+}
+
+func TestProvidersConsensusChain_MergesAnswersBeforeTimeout(t *testing.T) {
+	// Arrange
+	fast := &mockProvider{resp: domain.Weather{Temperature: 15, Source: "fast"}}
+	slow := &slowProvider{mockProvider: mockProvider{resp: domain.Weather{Temperature: 40, Source: "slow"}}, delay: time.Second}
+	c := chain.NewProvidersConsensusChain(20*time.Millisecond, newMockConsensusMetrics(), fast, slow)
+
+	// Act
+	start := time.Now()
+	weather, err := c.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, "fast", weather.Source)
+}
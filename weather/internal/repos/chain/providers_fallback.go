@@ -2,35 +2,202 @@ package chain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 )
 
-type weatherProvider interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+// WeatherProvider is a single weather source the chains combine. Providers always answer in metric units,
+// the service converts to the units clients ask for.
+type WeatherProvider interface {
+	GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error)
+	GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error)
+	GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error)
+	GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error)
 }
 
+const (
+	attemptFirst    = "first"
+	attemptHedge    = "hedge"
+	attemptFallback = "fallback"
+)
+
+type fallbackMetrics interface {
+	ProviderHedge()
+	ProviderWinner(attempt string)
+	ProviderChainDepth(position int)
+}
+
+type providerRanker interface {
+	Order() []int
+	Record(ctx context.Context, i int, latency time.Duration, err error)
+}
+
+// ProvidersFallbackChain returns the answer of the first provider that succeeds.
+// When HedgeDelay is set and the running provider has not answered within it, the next one
+// is asked in parallel, so a slow provider does not cost the whole timeout. Zero disables hedging.
+// With a Ranker, providers are tried in the order it ranks them and every outcome is reported back,
+// including the latency of hedged calls cancelled because another provider answered first,
+// without one they are tried in the configured order.
 type ProvidersFallbackChain struct {
-	Repos []weatherProvider
+	Repos      []WeatherProvider
+	HedgeDelay time.Duration
+	Metrics    fallbackMetrics
+	Ranker     providerRanker
+}
+
+func NewProvidersFallbackChain(
+	hedgeDelay time.Duration, metrics fallbackMetrics, ranker providerRanker, repos ...WeatherProvider,
+) *ProvidersFallbackChain {
+	return &ProvidersFallbackChain{Repos: repos, HedgeDelay: hedgeDelay, Metrics: metrics, Ranker: ranker}
+}
+
+// order returns the indices of Repos in the order they should be tried.
+func (c *ProvidersFallbackChain) order() []int {
+	if c.Ranker != nil {
+		return c.Ranker.Order()
+	}
+	order := make([]int, len(c.Repos))
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+func (c *ProvidersFallbackChain) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return fallback(ctx, c, func(ctx context.Context, repo WeatherProvider) (domain.Weather, error) {
+		return repo.GetCurrent(ctx, loc)
+	})
+}
+
+func (c *ProvidersFallbackChain) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return fallback(ctx, c, func(ctx context.Context, repo WeatherProvider) (domain.Forecast, error) {
+		return repo.GetForecast(ctx, loc, days)
+	})
+}
+
+// GetAlerts returns the alerts of the first provider that has them, providers without alerts fail
+// right away and are skipped like failing ones.
+func (c *ProvidersFallbackChain) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return fallback(ctx, c, func(ctx context.Context, repo WeatherProvider) (domain.Alerts, error) {
+		return repo.GetAlerts(ctx, loc)
+	})
+}
+
+// GetAirQuality returns the air quality of the first provider that has it, providers without it are
+// skipped like those without alerts.
+func (c *ProvidersFallbackChain) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return fallback(ctx, c, func(ctx context.Context, repo WeatherProvider) (domain.AirQuality, error) {
+		return repo.GetAirQuality(ctx, loc)
+	})
 }
 
-func NewProvidersFallbackChain(repos ...weatherProvider) *ProvidersFallbackChain {
-	return &ProvidersFallbackChain{Repos: repos}
+type attemptResult[T any] struct {
+	index int
+	// position in the order the repo was tried in, 1 is the first
+	position int
+	attempt  string
+	latency  time.Duration
+	value    T
+	err      error
 }
 
-func (c *ProvidersFallbackChain) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+// fallback calls repos in order and returns the first successful result. The next repo is called
+// as soon as the running ones have failed, or in parallel once the hedge delay has passed.
+// Calls still running when a result is returned are cancelled through ctx and reported to the ranker
+// once they return.
+func fallback[T any](ctx context.Context, c *ProvidersFallbackChain, call func(ctx context.Context, repo WeatherProvider) (T, error)) (T, error) {
+	var zero T
 	var lastError error
-	for _, repo := range c.Repos {
-		weather, err := repo.GetCurrent(ctx, city)
-		if err != nil {
-			err = fmt.Errorf("chain: %w", err)
+	if len(c.Repos) == 0 {
+		return zero, lastError
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan attemptResult[T], len(c.Repos))
+	order := c.order()
+	next, running := 0, 0
+	var hedge *time.Timer
+	var hedgeC <-chan time.Time
+	start := func(attempt string) {
+		index := order[next]
+		next++
+		running++
+		position := next
+		go func() {
+			started := time.Now()
+			value, err := call(ctx, c.Repos[index])
+			results <- attemptResult[T]{
+				index: index, position: position, attempt: attempt, latency: time.Since(started), value: value, err: err,
+			}
+		}()
+
+		hedgeC = nil
+		if c.HedgeDelay <= 0 || next == len(c.Repos) {
+			return
+		}
+		if hedge == nil {
+			hedge = time.NewTimer(c.HedgeDelay)
+		} else {
+			hedge.Reset(c.HedgeDelay)
+		}
+		hedgeC = hedge.C
+	}
+	defer func() {
+		if hedge != nil {
+			hedge.Stop()
+		}
+	}()
+
+	start(attemptFirst)
+	for running > 0 {
+		select {
+		case <-hedgeC:
+			c.Metrics.ProviderHedge()
+			start(attemptHedge)
+		case result := <-results:
+			running--
+			if c.Ranker != nil {
+				c.Ranker.Record(ctx, result.index, result.latency, result.err)
+			}
+			if result.err == nil {
+				c.Metrics.ProviderWinner(result.attempt)
+				c.Metrics.ProviderChainDepth(result.position)
+				if c.Ranker != nil && running > 0 {
+					go recordAbandoned(ctx, c.Ranker, results, running)
+				}
+				return result.value, nil
+			}
+			err := fmt.Errorf("chain: %w", result.err)
 			log.Println(err)
-			lastError = err
-			continue
+			lastError = moreTelling(lastError, err)
+			if running == 0 && next < len(c.Repos) {
+				start(attemptFallback)
+			}
 		}
-		return weather, nil
 	}
-	return domain.Weather{}, lastError
+	return zero, lastError
+}
+
+// recordAbandoned reports the outcome of the calls still running when fallback returned, so a provider
+// that keeps losing hedges is ranked by its latency rather than not at all. ctx is cancelled by then,
+// which tells the ranker the failures are not the providers' fault.
+func recordAbandoned[T any](ctx context.Context, ranker providerRanker, results <-chan attemptResult[T], running int) {
+	for range running {
+		result := <-results
+		ranker.Record(ctx, result.index, result.latency, result.err)
+	}
+}
+
+// moreTelling returns the error of the next failed call unless it only says the provider lacks the data,
+// e.g. has no alerts, while an earlier one failed for real, which is what a client should hear about.
+func moreTelling(last, next error) error {
+	if last != nil && errors.Is(next, domain.ErrUnsupported) {
+		return last
+	}
+	return next
 }
@@ -5,7 +5,10 @@ package chain_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/chain"
@@ -14,16 +17,84 @@ import (
 )
 
 type mockProvider struct {
-	resp   domain.Weather
-	err    error
-	called bool
+	resp         domain.Weather
+	forecastResp domain.Forecast
+	alertsResp   domain.Alerts
+	airResp      domain.AirQuality
+	err          error
+	called       bool
 }
 
-func (m *mockProvider) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (m *mockProvider) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
 	m.called = true
 	return m.resp, m.err
 }
 
+func (m *mockProvider) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	m.called = true
+	return m.forecastResp, m.err
+}
+
+func (m *mockProvider) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	m.called = true
+	return m.alertsResp, m.err
+}
+
+func (m *mockProvider) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	m.called = true
+	return m.airResp, m.err
+}
+
+type mockHedgeMetrics struct {
+	hedges  int
+	winners []string
+	depths  []int
+}
+
+func newMockHedgeMetrics() *mockHedgeMetrics {
+	return &mockHedgeMetrics{}
+}
+
+func (m *mockHedgeMetrics) ProviderHedge() {
+	m.hedges++
+}
+
+func (m *mockHedgeMetrics) ProviderWinner(attempt string) {
+	m.winners = append(m.winners, attempt)
+}
+
+func (m *mockHedgeMetrics) ProviderChainDepth(position int) {
+	m.depths = append(m.depths, position)
+}
+
+type mockRanker struct {
+	order []int
+
+	mu       sync.Mutex
+	recorded map[int]error
+	canceled map[int]time.Duration
+}
+
+func (m *mockRanker) Order() []int {
+	return m.order
+}
+
+func (m *mockRanker) Record(ctx context.Context, i int, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil && ctx.Err() != nil {
+		m.canceled[i] = latency
+		return
+	}
+	m.recorded[i] = err
+}
+
+func (m *mockRanker) Canceled() map[int]time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.canceled
+}
+
 func TestWeatherRepoChain_FirstSuccess(t *testing.T) {
 	// Arrange
 	first := &mockProvider{
@@ -33,10 +104,10 @@ func TestWeatherRepoChain_FirstSuccess(t *testing.T) {
 	second := &mockProvider{
 		err: errors.New("should not be called"),
 	}
-	chain := chain.NewProvidersFallbackChain(first, second)
+	chain := chain.NewProvidersFallbackChain(0, newMockHedgeMetrics(), nil, first, second)
 
 	// Act
-	weather, err := chain.GetCurrent(context.Background(), "Kyiv")
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
 
 	// Assert
 	require.NoError(t, err)
@@ -54,10 +125,10 @@ func TestWeatherRepoChain_SecondSuccess(t *testing.T) {
 		resp: domain.Weather{Temperature: 10, Humidity: 80, Description: "Rain"},
 		err:  nil,
 	}
-	chain := chain.NewProvidersFallbackChain(first, second)
+	chain := chain.NewProvidersFallbackChain(0, newMockHedgeMetrics(), nil, first, second)
 
 	// Act
-	weather, err := chain.GetCurrent(context.Background(), "Lviv")
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Lviv"})
 
 	// Assert
 	require.NoError(t, err)
@@ -70,10 +141,10 @@ func TestWeatherRepoChain_AllFail(t *testing.T) {
 	// Arrange
 	first := &mockProvider{err: errors.New("first fail")}
 	second := &mockProvider{err: errors.New("second fail")}
-	chain := chain.NewProvidersFallbackChain(first, second)
+	chain := chain.NewProvidersFallbackChain(0, newMockHedgeMetrics(), nil, first, second)
 
 	// Act
-	weather, err := chain.GetCurrent(context.Background(), "Tsrcuny")
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Tsrcuny"})
 
 	// Assert
 	require.Error(t, err)
@@ -82,3 +153,145 @@ func TestWeatherRepoChain_AllFail(t *testing.T) {
 	assert.True(t, first.called)
 	assert.True(t, second.called)
 }
+
+func TestWeatherRepoChain_ForecastFallback(t *testing.T) {
+	// Arrange
+	first := &mockProvider{err: errors.New("first failed")}
+	second := &mockProvider{
+		forecastResp: domain.Forecast{Daily: []domain.DailyForecast{{MaxTemperature: 25}}},
+	}
+	chain := chain.NewProvidersFallbackChain(0, newMockHedgeMetrics(), nil, first, second)
+
+	// Act
+	forecast, err := chain.GetForecast(context.Background(), domain.Location{Name: "Kyiv"}, 1)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, forecast.Daily, 1)
+	assert.Equal(t, 25.0, forecast.Daily[0].MaxTemperature)
+	assert.True(t, first.called)
+	assert.True(t, second.called)
+}
+
+func TestWeatherRepoChain_AlertsSkipProvidersWithout(t *testing.T) {
+	// Arrange
+	without := &mockProvider{err: fmt.Errorf("open-meteo: %w", domain.ErrAlertsUnsupported)}
+	with := &mockProvider{alertsResp: domain.Alerts{Alerts: []domain.Alert{{Event: "Flood Warning"}}, Source: "weatherapi.com"}}
+	chain := chain.NewProvidersFallbackChain(0, newMockHedgeMetrics(), nil, without, with)
+
+	// Act
+	alerts, err := chain.GetAlerts(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "weatherapi.com", alerts.Source)
+	require.Len(t, alerts.Alerts, 1)
+}
+
+func TestWeatherRepoChain_AlertsReportRealFailure(t *testing.T) {
+	// Arrange
+	failing := &mockProvider{err: fmt.Errorf("weatherapi.com: %w", domain.ErrWeatherUnavailable)}
+	without := &mockProvider{err: fmt.Errorf("open-meteo: %w", domain.ErrAlertsUnsupported)}
+	chain := chain.NewProvidersFallbackChain(0, newMockHedgeMetrics(), nil, failing, without)
+
+	// Act
+	_, err := chain.GetAlerts(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.NotErrorIs(t, err, domain.ErrAlertsUnsupported)
+}
+
+func TestWeatherRepoChain_HedgesSlowProvider(t *testing.T) {
+	// Arrange
+	slow := &slowProvider{mockProvider: mockProvider{resp: domain.Weather{Temperature: 1}}, delay: time.Second}
+	fast := &mockProvider{resp: domain.Weather{Temperature: 20}}
+	metrics := newMockHedgeMetrics()
+	chain := chain.NewProvidersFallbackChain(10*time.Millisecond, metrics, nil, slow, fast)
+
+	// Act
+	start := time.Now()
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.Less(t, time.Since(start), time.Second, "slow provider must be cancelled, not waited for")
+	assert.Equal(t, 1, metrics.hedges)
+	assert.Equal(t, []string{"hedge"}, metrics.winners)
+}
+
+func TestWeatherRepoChain_RecordsLostHedge(t *testing.T) {
+	// Arrange
+	slow := &slowProvider{mockProvider: mockProvider{resp: domain.Weather{Temperature: 1}}, delay: time.Second}
+	fast := &mockProvider{resp: domain.Weather{Temperature: 20}}
+	ranker := &mockRanker{order: []int{0, 1}, recorded: make(map[int]error), canceled: make(map[int]time.Duration)}
+	chain := chain.NewProvidersFallbackChain(10*time.Millisecond, newMockHedgeMetrics(), ranker, slow, fast)
+
+	// Act
+	_, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(ranker.Canceled()) == 1
+	}, time.Second, time.Millisecond, "the cancelled slow provider must be reported")
+	assert.GreaterOrEqual(t, ranker.Canceled()[0], 10*time.Millisecond)
+}
+
+func TestWeatherRepoChain_NoHedgeWhenFastEnough(t *testing.T) {
+	// Arrange
+	first := &mockProvider{resp: domain.Weather{Temperature: 20}}
+	second := &mockProvider{resp: domain.Weather{Temperature: 10}}
+	metrics := newMockHedgeMetrics()
+	chain := chain.NewProvidersFallbackChain(time.Second, metrics, nil, first, second)
+
+	// Act
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.False(t, second.called)
+	assert.Zero(t, metrics.hedges)
+	assert.Equal(t, []string{"first"}, metrics.winners)
+}
+
+func TestWeatherRepoChain_FallbackAfterHedgeFails(t *testing.T) {
+	// Arrange
+	slow := &slowProvider{mockProvider: mockProvider{err: errors.New("slow failed")}, delay: 30 * time.Millisecond}
+	failing := &mockProvider{err: errors.New("hedge failed")}
+	last := &mockProvider{resp: domain.Weather{Temperature: 5}}
+	metrics := newMockHedgeMetrics()
+	chain := chain.NewProvidersFallbackChain(10*time.Millisecond, metrics, nil, slow, failing, last)
+
+	// Act
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, weather.Temperature)
+	assert.Equal(t, 2, metrics.hedges)
+	assert.Equal(t, []string{"hedge"}, metrics.winners)
+	assert.Equal(t, []int{3}, metrics.depths)
+}
+
+func TestWeatherRepoChain_RankedOrder(t *testing.T) {
+	// Arrange
+	first := &mockProvider{resp: domain.Weather{Temperature: 20}}
+	second := &mockProvider{err: domain.ErrWeatherUnavailable}
+	third := &mockProvider{resp: domain.Weather{Temperature: 10}}
+	ranker := &mockRanker{order: []int{1, 2, 0}, recorded: make(map[int]error), canceled: make(map[int]time.Duration)}
+	metrics := newMockHedgeMetrics()
+	chain := chain.NewProvidersFallbackChain(0, metrics, ranker, first, second, third)
+
+	// Act
+	weather, err := chain.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, weather.Temperature)
+	assert.False(t, first.called)
+	assert.Equal(t, map[int]error{1: domain.ErrWeatherUnavailable, 2: nil}, ranker.recorded)
+	assert.Equal(t, []int{2}, metrics.depths, "depth is the position in the ranked order")
+}
@@ -0,0 +1,35 @@
+package decorator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// NoAirQualityDecorator marks a provider whose api has no air quality data. Like NoAlertsDecorator it is
+// placed outermost, so air quality requests skip the provider without spending its quota.
+type NoAirQualityDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+}
+
+func NewNoAirQualityDecorator(inner weatherRepo, repoName string) *NoAirQualityDecorator {
+	return &NoAirQualityDecorator{Inner: inner, RepoName: repoName}
+}
+
+func (d *NoAirQualityDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return d.Inner.GetCurrent(ctx, loc)
+}
+
+func (d *NoAirQualityDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return d.Inner.GetForecast(ctx, loc, days)
+}
+
+func (d *NoAirQualityDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return d.Inner.GetAlerts(ctx, loc)
+}
+
+func (d *NoAirQualityDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return domain.AirQuality{}, fmt.Errorf("%s: %w", d.RepoName, domain.ErrAirQualityUnsupported)
+}
@@ -0,0 +1,31 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoAirQualityDecorator(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{AlertsResponse: domain.Alerts{Alerts: []domain.Alert{{Event: "Heat"}}}}
+	repo := decorator.NewNoAirQualityDecorator(mock, "tomorrow.io")
+
+	// Act
+	_, airErr := repo.GetAirQuality(context.Background(), domain.Location{Name: "Kyiv"})
+	calledForAir := mock.Called
+	alerts, alertsErr := repo.GetAlerts(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, airErr, domain.ErrAirQualityUnsupported)
+	require.ErrorIs(t, airErr, domain.ErrUnsupported)
+	assert.False(t, calledForAir, "air quality requests should not reach the provider")
+	require.NoError(t, alertsErr)
+	assert.Len(t, alerts.Alerts, 1)
+}
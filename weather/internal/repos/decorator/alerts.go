@@ -0,0 +1,36 @@
+package decorator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// NoAlertsDecorator marks a provider whose api has no weather alerts. Placed outermost, it fails alert
+// requests with domain.ErrAlertsUnsupported before they spend its quota or reach its breaker and metrics,
+// so the chain moves on to a provider that has alerts.
+type NoAlertsDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+}
+
+func NewNoAlertsDecorator(inner weatherRepo, repoName string) *NoAlertsDecorator {
+	return &NoAlertsDecorator{Inner: inner, RepoName: repoName}
+}
+
+func (d *NoAlertsDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return d.Inner.GetCurrent(ctx, loc)
+}
+
+func (d *NoAlertsDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return d.Inner.GetForecast(ctx, loc, days)
+}
+
+func (d *NoAlertsDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return domain.Alerts{}, fmt.Errorf("%s: %w", d.RepoName, domain.ErrAlertsUnsupported)
+}
+
+func (d *NoAlertsDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return d.Inner.GetAirQuality(ctx, loc)
+}
@@ -0,0 +1,30 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoAlertsDecorator(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{Response: domain.Weather{Temperature: 20}}
+	repo := decorator.NewNoAlertsDecorator(mock, "open-meteo")
+
+	// Act
+	_, alertsErr := repo.GetAlerts(context.Background(), domain.Location{Name: "Kyiv"})
+	calledForAlerts := mock.Called
+	weather, currentErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, alertsErr, domain.ErrAlertsUnsupported)
+	assert.False(t, calledForAlerts, "alerts requests should not reach the provider")
+	require.NoError(t, currentErr)
+	assert.Equal(t, 20.0, weather.Temperature)
+}
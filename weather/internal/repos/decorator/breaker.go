@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
@@ -18,19 +19,54 @@ func NewBreakerDecorator(inner weatherRepo, breaker *cb.CircuitBreaker) *Breaker
 	return &BreakerDecorator{Inner: inner, Breaker: breaker}
 }
 
-func (d *BreakerDecorator) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	if !d.Breaker.Allowed() {
-		return domain.Weather{}, fmt.Errorf("circuit breaker: %w", domain.ErrProviderUnreliable)
+func (d *BreakerDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return withBreaker(ctx, d.Breaker, func() (domain.Weather, error) {
+		return d.Inner.GetCurrent(ctx, loc)
+	})
+}
+
+func (d *BreakerDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return withBreaker(ctx, d.Breaker, func() (domain.Forecast, error) {
+		return d.Inner.GetForecast(ctx, loc, days)
+	})
+}
+
+func (d *BreakerDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return withBreaker(ctx, d.Breaker, func() (domain.Alerts, error) {
+		return d.Inner.GetAlerts(ctx, loc)
+	})
+}
+
+func (d *BreakerDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return withBreaker(ctx, d.Breaker, func() (domain.AirQuality, error) {
+		return d.Inner.GetAirQuality(ctx, loc)
+	})
+}
+
+// withBreaker runs call if the breaker allows it and reports unavailability failures back to the breaker.
+// Errors that say nothing about the provider's health, like an unknown city or a call cancelled because
+// a hedged provider answered first, are neither failures nor successes. Cancellation is read from ctx,
+// as providers report a cancelled request as unavailable.
+func withBreaker[T any](ctx context.Context, breaker *cb.CircuitBreaker, call func() (T, error)) (T, error) {
+	var zero T
+	if !breaker.Allowed() {
+		return zero, fmt.Errorf("circuit breaker: %w", domain.ErrProviderUnreliable)
 	}
 
-	weather, err := d.Inner.GetCurrent(ctx, city)
-	if errors.Is(err, domain.ErrWeatherUnavailable) {
-		d.Breaker.Fail()
+	start := time.Now()
+	result, err := call()
+	switch {
+	case err != nil && errors.Is(ctx.Err(), context.Canceled):
+		breaker.Ignore()
+	case errors.Is(err, domain.ErrWeatherUnavailable):
+		breaker.Fail()
+	case err != nil:
+		breaker.Ignore()
+	default:
+		breaker.SuccessAfter(time.Since(start))
 	}
 	if err != nil {
-		return domain.Weather{}, err
+		return zero, err
 	}
-
-	d.Breaker.Success()
-	return weather, nil
+	return result, nil
 }
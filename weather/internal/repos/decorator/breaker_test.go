@@ -33,7 +33,7 @@ func TestBreakerDecorator_Success(t *testing.T) {
 	repo := decorator.NewBreakerDecorator(mock, breaker)
 
 	// Act
-	result, err := repo.GetCurrent(context.Background(), "Lviv")
+	result, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Lviv"})
 
 	// Assert
 	require.NoError(t, err)
@@ -52,7 +52,7 @@ func TestBreakerDecorator_UnavailableErrorTriggersBreaker(t *testing.T) {
 	repo := decorator.NewBreakerDecorator(mock, breaker)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Odessa")
+	_, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Odessa"})
 
 	// Assert
 	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
@@ -69,13 +69,32 @@ func TestBreakerDecorator_OtherErrorDoesNotTriggerBreaker(t *testing.T) {
 	repo := decorator.NewBreakerDecorator(mock, breaker)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "!!!")
+	_, err := repo.GetCurrent(context.Background(), domain.Location{Name: "!!!"})
 
 	// Assert
 	require.ErrorIs(t, err, domain.ErrCityNotFound)
 	assert.True(t, breaker.Allowed())
 }
 
+func TestBreakerDecorator_CancelledCallDoesNotTriggerBreaker(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{
+		Response: domain.Weather{},
+		Err:      domain.ErrWeatherUnavailable,
+	}
+	breaker := newTestBreaker()
+	repo := decorator.NewBreakerDecorator(mock, breaker)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := repo.GetCurrent(ctx, domain.Location{Name: "Odessa"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.True(t, breaker.Allowed())
+}
+
 func TestBreakerDecorator_BreakerOpenSkipsCall(t *testing.T) {
 	// Arrange
 	mock := &mockWeatherRepo{
@@ -89,7 +108,7 @@ func TestBreakerDecorator_BreakerOpenSkipsCall(t *testing.T) {
 	repo := decorator.NewBreakerDecorator(mock, breaker)
 
 	// Act
-	result, err := repo.GetCurrent(context.Background(), "Dnipro")
+	result, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Dnipro"})
 
 	// Assert
 	require.ErrorIs(t, err, domain.ErrProviderUnreliable)
@@ -113,7 +132,7 @@ func TestBreakerDecorator_ClosedAfterTimeout(t *testing.T) {
 	repo := decorator.NewBreakerDecorator(mock, breaker)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kharkiv")
+	_, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Kharkiv"})
 	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
 	assert.False(t, breaker.Allowed())
 	currentTime = currentTime.Add(time.Minute + time.Second)
@@ -138,20 +157,36 @@ func TestBreakerDecorator_OpenAgain(t *testing.T) {
 	repo := decorator.NewBreakerDecorator(mock, breaker)
 
 	// Act
-	city := "Kharkiv"
-	_, err := repo.GetCurrent(context.Background(), city)
+	loc := domain.Location{Name: "Kharkiv"}
+	_, err := repo.GetCurrent(context.Background(), loc)
 	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
 	assert.True(t, breaker.Allowed())
-	_, err = repo.GetCurrent(context.Background(), city)
+	_, err = repo.GetCurrent(context.Background(), loc)
 	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
 	assert.False(t, breaker.Allowed())
 	require.Equal(t, cb.Open, breaker.State())
 	currentTime = currentTime.Add(time.Minute + time.Second)
 	require.Equal(t, cb.HalfOpen, breaker.State())
-	_, err = repo.GetCurrent(context.Background(), city)
+	_, err = repo.GetCurrent(context.Background(), loc)
 	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
 
 	// Assert
 	require.Equal(t, cb.Open, breaker.State())
 	assert.False(t, breaker.Allowed())
 }
+
+func TestBreakerDecorator_ForecastUnavailableTriggersBreaker(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{
+		Err: domain.ErrWeatherUnavailable,
+	}
+	breaker := newTestBreaker()
+	repo := decorator.NewBreakerDecorator(mock, breaker)
+
+	// Act
+	_, err := repo.GetForecast(context.Background(), domain.Location{Name: "Odessa"}, 3)
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.False(t, breaker.Allowed())
+}
@@ -2,57 +2,463 @@ package decorator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/singleflight"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 )
 
-type cacheClient interface {
-	Get(ctx context.Context, key string, value *domain.Weather) error
-	Set(ctx context.Context, key string, value domain.Weather) error
+type cacheClient[T any] interface {
+	Get(ctx context.Context, key string, value *T) error
+	Set(ctx context.Context, key string, value T) error
+	SetNegative(ctx context.Context, key string) error
+	Purge(ctx context.Context, pattern string) (int, error)
+}
+
+type batchCacheClient[T any] interface {
+	cacheClient[T]
+	MGet(ctx context.Context, keys []string) (map[string]T, error)
+}
+
+type keyLocker interface {
+	TryLock(ctx context.Context, key string) (unlock func(ctx context.Context) error, acquired bool, err error)
 }
 
 type weathMetrics interface {
 	CacheHit()
 	CacheMiss()
 	CacheAccessLatency(duration float64)
+	CacheLoad(role string)
+	CacheStale(reason string)
+}
+
+// aged is a cached value that can be marked with how old it is.
+type aged[T any] interface {
+	Aged(age time.Duration, stale bool) T
+}
+
+const (
+	lockKeyPrefix     = "lock:"
+	forecastKeyPrefix = "forecast:"
+	alertsKeyPrefix   = "alerts:"
+	airKeyPrefix      = "air:"
+	lockPollInterval  = 50 * time.Millisecond
+
+	// loaded the value from providers
+	loadLeader = "leader"
+	// shared the load of another request in this instance
+	loadCoalesced = "coalesced"
+	// waited for another instance to load the value
+	loadReplica = "replica"
+
+	// served while a newer value is loaded in the background
+	staleRevalidate = "revalidate"
+	// served because loading a newer value failed
+	staleError = "error"
+)
+
+// CacheTTL says how long a cached value is fresh and how long it may be served while it is refreshed.
+// Past Hard the value is only served when providers fail, for as long as the cache keeps it.
+type CacheTTL struct {
+	Soft time.Duration
+	Hard time.Duration
 }
+
+type CacheConfig struct {
+	Current  CacheTTL
+	Forecast CacheTTL
+	Alerts   CacheTTL
+	Air      CacheTTL
+	// how many cache misses of a batch request are loaded from providers at once
+	BatchParallelism int
+	// how long loading a single cache miss of a batch request may take, counted from when its turn comes,
+	// zero leaves it to the deadline of the request
+	BatchItemTimeout time.Duration
+	// how long a whole batch request may take, misses not loaded by then get their stale value or an error,
+	// zero leaves it to the deadline of the request
+	BatchTimeout time.Duration
+	// how long a background refresh of a stale value may take
+	RefreshTimeout time.Duration
+}
+
+// cacheKind holds what the decorator needs for one kind of cached value.
+type cacheKind[T any] struct {
+	client cacheClient[cache.Entry[T]]
+	ttl    CacheTTL
+	flight singleflight.Group[T]
+}
+
 type CacheDecorator struct {
 	inner        weatherRepo
-	cacheClient  cacheClient
 	weathMetrics weathMetrics
+	locker       keyLocker
+	cfg          CacheConfig
+
+	currentCache batchCacheClient[cache.Entry[domain.Weather]]
+	current      *cacheKind[domain.Weather]
+	forecast     *cacheKind[domain.Forecast]
+	alerts       *cacheKind[domain.Alerts]
+	air          *cacheKind[domain.AirQuality]
+}
+
+func NewCacheDecorator(
+	inner weatherRepo,
+	cacheBack batchCacheClient[cache.Entry[domain.Weather]],
+	forecastCacheBack cacheClient[cache.Entry[domain.Forecast]],
+	alertsCacheBack cacheClient[cache.Entry[domain.Alerts]],
+	airCacheBack cacheClient[cache.Entry[domain.AirQuality]],
+	weathMetrics weathMetrics,
+	locker keyLocker,
+	cfg CacheConfig,
+) *CacheDecorator {
+	cfg.BatchParallelism = max(cfg.BatchParallelism, 1)
+	return &CacheDecorator{
+		inner:        inner,
+		weathMetrics: weathMetrics,
+		locker:       locker,
+		cfg:          cfg,
+		currentCache: cacheBack,
+		current:      &cacheKind[domain.Weather]{client: cacheBack, ttl: cfg.Current},
+		forecast:     &cacheKind[domain.Forecast]{client: forecastCacheBack, ttl: cfg.Forecast},
+		alerts:       &cacheKind[domain.Alerts]{client: alertsCacheBack, ttl: cfg.Alerts},
+		air:          &cacheKind[domain.AirQuality]{client: airCacheBack, ttl: cfg.Air},
+	}
+}
+
+func (d *CacheDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return withCache(ctx, d, d.current, loc.Key(), func(ctx context.Context) (domain.Weather, error) {
+		return d.inner.GetCurrent(ctx, loc)
+	})
+}
+
+func (d *CacheDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	key := fmt.Sprintf("%s%s:%d", forecastKeyPrefix, loc.Key(), days)
+	return withCache(ctx, d, d.forecast, key, func(ctx context.Context) (domain.Forecast, error) {
+		return d.inner.GetForecast(ctx, loc, days)
+	})
+}
+
+func (d *CacheDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return withCache(ctx, d, d.alerts, alertsKeyPrefix+loc.Key(), func(ctx context.Context) (domain.Alerts, error) {
+		return d.inner.GetAlerts(ctx, loc)
+	})
+}
+
+func (d *CacheDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return withCache(ctx, d, d.air, airKeyPrefix+loc.Key(), func(ctx context.Context) (domain.AirQuality, error) {
+		return d.inner.GetAirQuality(ctx, loc)
+	})
+}
+
+// Warm loads the current weather of loc from providers unless the cached value is still fresh at until.
+// It reports whether providers were asked.
+func (d *CacheDecorator) Warm(ctx context.Context, loc domain.Location, until time.Time) (bool, error) {
+	key := loc.Key()
+	var entry cache.Entry[domain.Weather]
+	err := d.current.client.Get(ctx, key, &entry)
+	if (err == nil && entry.Fresh(until)) || errors.Is(err, cache.ErrNegative) {
+		return false, nil
+	}
+	_, err = coalescedLoad(ctx, d, d.current, key, func(ctx context.Context) (domain.Weather, error) {
+		return d.inner.GetCurrent(ctx, loc)
+	})
+	return true, err
+}
+
+// PurgeLocation drops the cached weather, forecasts, alerts and air quality of loc on every replica
+// and returns how many entries there were.
+func (d *CacheDecorator) PurgeLocation(ctx context.Context, loc domain.Location) (int, error) {
+	key := cache.EscapePattern(loc.Key())
+	current, err := d.current.client.Purge(ctx, key)
+	if err != nil {
+		return current, err
+	}
+	forecasts, err := d.forecast.client.Purge(ctx, forecastKeyPrefix+key+":*")
+	if err != nil {
+		return current + forecasts, err
+	}
+	alerts, err := d.alerts.client.Purge(ctx, alertsKeyPrefix+key)
+	if err != nil {
+		return current + forecasts + alerts, err
+	}
+	air, err := d.air.client.Purge(ctx, airKeyPrefix+key)
+	return current + forecasts + alerts + air, err
 }
 
-func NewCacheDecorator(inner weatherRepo, cacheBack cacheClient, weathMetrics weathMetrics) *CacheDecorator {
-	return &CacheDecorator{inner: inner, cacheClient: cacheBack, weathMetrics: weathMetrics}
+// PurgeAll empties the weather cache of every replica and returns how many entries there were.
+func (d *CacheDecorator) PurgeAll(ctx context.Context) (int, error) {
+	current, err := d.current.client.Purge(ctx, "*")
+	if err != nil {
+		return current, err
+	}
+	forecasts, err := d.forecast.client.Purge(ctx, "*")
+	if err != nil {
+		return current + forecasts, err
+	}
+	alerts, err := d.alerts.client.Purge(ctx, "*")
+	if err != nil {
+		return current + forecasts + alerts, err
+	}
+	air, err := d.air.client.Purge(ctx, "*")
+	return current + forecasts + alerts + air, err
 }
 
-func (d *CacheDecorator) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	var weather domain.Weather
+// GetCurrentBatch looks up all locations with a single MGET and loads the misses concurrently,
+// at most BatchParallelism at a time, each within BatchItemTimeout, so misses waiting for their turn
+// do not run out of time spent on earlier ones. Once BatchTimeout passes the rest of the misses are
+// answered with their stale value or an error, and the results loaded by then are returned.
+// Results are in the order of locs.
+func (d *CacheDecorator) GetCurrentBatch(ctx context.Context, locs []domain.Location) []domain.CurrentResult {
+	if d.cfg.BatchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.cfg.BatchTimeout)
+		defer cancel()
+	}
+	results := make([]domain.CurrentResult, len(locs))
+	keys := make([]string, len(locs))
+	for i, loc := range locs {
+		keys[i] = loc.Key()
+	}
 
 	now := time.Now()
-	if err := d.cacheClient.Get(ctx, city, &weather); err == nil {
+	cached, err := d.currentCache.MGet(ctx, keys)
+	d.weathMetrics.CacheAccessLatency(time.Since(now).Seconds())
+	if err != nil {
+		log.Println("cache error:", err)
+	}
+
+	sem := make(chan struct{}, d.cfg.BatchParallelism)
+	var wg sync.WaitGroup
+	hits := 0
+	for i, loc := range locs {
+		load := func(ctx context.Context) (domain.Weather, error) {
+			return d.inner.GetCurrent(ctx, loc)
+		}
+		var entry *cache.Entry[domain.Weather]
+		if e, ok := cached[keys[i]]; ok {
+			entry = &e
+		}
+		if weather, ok := fromEntry(ctx, d, d.current, keys[i], entry, now, load); ok {
+			hits++
+			results[i] = domain.CurrentResult{Weather: weather}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				weather, err := staleOr(d, keys[i], entry, fmt.Errorf("cache: batch: %w", ctx.Err()))
+				results[i] = domain.CurrentResult{Weather: weather, Err: err}
+				return
+			}
+
+			ctx := ctx
+			if d.cfg.BatchItemTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, d.cfg.BatchItemTimeout)
+				defer cancel()
+			}
+			weather, err := loadOrStale(ctx, d, d.current, keys[i], entry, load)
+			results[i] = domain.CurrentResult{Weather: weather, Err: err}
+		}()
+	}
+	wg.Wait()
+	log.Printf("cache batch: %d hits, %d misses\n", hits, len(locs)-hits)
+	return results
+}
+
+// withCache returns the value stored under key or loads it and stores the result on success.
+func withCache[T aged[T]](
+	ctx context.Context, d *CacheDecorator, kind *cacheKind[T], key string, load func(ctx context.Context) (T, error),
+) (T, error) {
+	entry := &cache.Entry[T]{}
+
+	now := time.Now()
+	err := kind.client.Get(ctx, key, entry)
+	d.weathMetrics.CacheAccessLatency(time.Since(now).Seconds())
+	if errors.Is(err, cache.ErrNegative) {
+		var zero T
 		d.weathMetrics.CacheHit()
-		d.weathMetrics.CacheAccessLatency(time.Since(now).Seconds())
-		log.Println("cache hit")
+		log.Println("cache hit, not found")
+		return zero, fmt.Errorf("cache: %w", domain.ErrCityNotFound)
+	}
+	if err != nil {
+		entry = nil
+	}
+	if value, ok := fromEntry(ctx, d, kind, key, entry, now, load); ok {
+		return value, nil
+	}
+	return loadOrStale(ctx, d, kind, key, entry, load)
+}
 
-		return weather, nil
+// fromEntry serves entry, nil when nothing is cached, while it is fresh or past its soft TTL but not
+// its hard TTL, in which case a refresh is started in the background. It reports false otherwise.
+func fromEntry[T aged[T]](
+	ctx context.Context, d *CacheDecorator, kind *cacheKind[T], key string,
+	entry *cache.Entry[T], now time.Time, load func(ctx context.Context) (T, error),
+) (T, bool) {
+	if entry != nil && entry.Fresh(now) {
+		d.weathMetrics.CacheHit()
+		log.Println("cache hit")
+		return entry.Value.Aged(entry.Age(now), false), true
+	}
+	if entry != nil && entry.Revalidatable(now) {
+		d.weathMetrics.CacheHit()
+		d.weathMetrics.CacheStale(staleRevalidate)
+		log.Println("cache hit, revalidating")
+		revalidate(ctx, d, kind, key, load)
+		return entry.Value.Aged(entry.Age(now), false), true
 	}
 
+	var zero T
 	d.weathMetrics.CacheMiss()
-	d.weathMetrics.CacheAccessLatency(time.Since(now).Seconds())
 	log.Println("cache miss")
+	return zero, false
+}
 
-	weather, err := d.inner.GetCurrent(ctx, city)
+// loadOrStale loads the value for key and falls back to the expired entry, if there is one, marked stale.
+// An unknown city is an answer rather than a failure, so it is not covered up with a stale entry.
+func loadOrStale[T aged[T]](
+	ctx context.Context, d *CacheDecorator, kind *cacheKind[T], key string,
+	entry *cache.Entry[T], load func(ctx context.Context) (T, error),
+) (T, error) {
+	value, err := coalescedLoad(ctx, d, kind, key, load)
 	if err != nil {
-		return weather, err
+		return staleOr(d, key, entry, err)
+	}
+	return value, nil
+}
+
+// staleOr serves the expired entry instead of failing with err, unless there is none or the city is unknown.
+func staleOr[T aged[T]](d *CacheDecorator, key string, entry *cache.Entry[T], err error) (T, error) {
+	if entry == nil || errors.Is(err, domain.ErrCityNotFound) {
+		var zero T
+		return zero, err
+	}
+	age := entry.Age(time.Now())
+	log.Printf("cache: serving %s from %v ago: %v\n", key, age.Round(time.Second), err)
+	d.weathMetrics.CacheStale(staleError)
+	return entry.Value.Aged(age, true), nil
+}
+
+// revalidate refreshes key in the background, detached from the request that found it stale.
+func revalidate[T aged[T]](
+	ctx context.Context, d *CacheDecorator, kind *cacheKind[T], key string, load func(ctx context.Context) (T, error),
+) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.cfg.RefreshTimeout)
+		defer cancel()
+		if _, err := coalescedLoad(ctx, d, kind, key, load); err != nil {
+			log.Printf("cache: failed to revalidate %s: %v\n", key, err)
+		}
+	}()
+}
+
+// coalescedLoad makes concurrent loads of the same key in this instance share a single one.
+func coalescedLoad[T any](
+	ctx context.Context, d *CacheDecorator, kind *cacheKind[T], key string, load func(ctx context.Context) (T, error),
+) (T, error) {
+	value, leader, err := kind.flight.Do(ctx, key, func() (T, error) {
+		return lockedLoad(ctx, d, kind, key, load)
+	})
+	if !leader {
+		d.weathMetrics.CacheLoad(loadCoalesced)
 	}
-	err = d.cacheClient.Set(ctx, city, weather)
+	return value, err
+}
+
+// lockedLoad loads the value for key and stores it, holding a lock shared by all instances.
+// While another instance holds the lock it waits for that instance to store the value.
+// An unknown city is stored as a negative entry, so the next lookups don't reach providers.
+func lockedLoad[T any](
+	ctx context.Context, d *CacheDecorator, kind *cacheKind[T], key string, load func(ctx context.Context) (T, error),
+) (T, error) {
+	var entry cache.Entry[T]
+	unlock, found, err := waitForLock(ctx, d.locker, kind.client, key, &entry)
 	if err != nil {
+		return entry.Value, err
+	}
+	if found {
+		d.weathMetrics.CacheLoad(loadReplica)
+		return entry.Value, nil
+	}
+	if unlock != nil {
+		defer func() {
+			if err := unlock(context.WithoutCancel(ctx)); err != nil {
+				log.Println("cache unlock error:", err)
+			}
+		}()
+	}
+
+	d.weathMetrics.CacheLoad(loadLeader)
+	value, err := load(ctx)
+	if errors.Is(err, domain.ErrCityNotFound) {
+		if err := kind.client.SetNegative(ctx, key); err != nil {
+			log.Println("cache error:", err)
+		}
+	}
+	if err != nil {
+		return value, err
+	}
+	if err := kind.client.Set(ctx, key, cache.NewEntry(value, time.Now(), kind.ttl.Soft, kind.ttl.Hard)); err != nil {
 		log.Println("cache error:", err)
 	} else {
 		log.Println("cache set")
 	}
-	return weather, nil
+	return value, nil
+}
+
+// waitForLock takes the lock for key, or polls the cache until the holder stores a fresh entry
+// and reports it found. If the holder gives up without storing one, the lock is taken over.
+// unlock is nil when the lock can't be checked at all and the value should be loaded without it.
+func waitForLock[T any](
+	ctx context.Context, locker keyLocker, client cacheClient[cache.Entry[T]], key string, entry *cache.Entry[T],
+) (unlock func(ctx context.Context) error, found bool, err error) {
+	freshInCache := func() (bool, error) {
+		err := client.Get(ctx, key, entry)
+		if errors.Is(err, cache.ErrNegative) {
+			return false, fmt.Errorf("cache: %w", domain.ErrCityNotFound)
+		}
+		return err == nil && entry.Fresh(time.Now()), nil
+	}
+	waited := false
+	for {
+		unlock, acquired, err := locker.TryLock(ctx, lockKeyPrefix+key)
+		if err != nil {
+			log.Println("cache lock error:", err)
+			return nil, false, nil
+		}
+		// the previous holder may have stored the entry right before releasing the lock
+		if acquired && waited {
+			found, err := freshInCache()
+			if found || err != nil {
+				if err := unlock(ctx); err != nil {
+					log.Println("cache unlock error:", err)
+				}
+				return nil, found, err
+			}
+		}
+		if acquired {
+			return unlock, false, nil
+		}
+
+		waited = true
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+		if found, err := freshInCache(); found || err != nil {
+			return nil, found, err
+		}
+	}
 }
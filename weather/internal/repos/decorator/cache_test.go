@@ -0,0 +1,394 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memLocker struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+func newMemLocker() *memLocker {
+	return &memLocker{locked: make(map[string]bool)}
+}
+
+func (l *memLocker) TryLock(ctx context.Context, key string) (func(ctx context.Context) error, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locked[key] {
+		return nil, false, nil
+	}
+	l.locked[key] = true
+	return func(ctx context.Context) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.locked, key)
+		return nil
+	}, true, nil
+}
+
+type mockCacheMetrics struct {
+	mu    sync.Mutex
+	loads map[string]int
+}
+
+func newMockCacheMetrics() *mockCacheMetrics {
+	return &mockCacheMetrics{loads: make(map[string]int)}
+}
+
+func (m *mockCacheMetrics) CacheHit()                           {}
+func (m *mockCacheMetrics) CacheMiss()                          {}
+func (m *mockCacheMetrics) CacheAccessLatency(duration float64) {}
+func (m *mockCacheMetrics) CacheStale(reason string)            {}
+func (m *mockCacheMetrics) CacheLoad(role string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads[role]++
+}
+
+type countingRepo struct {
+	calls   atomic.Int32
+	delay   time.Duration
+	weather domain.Weather
+	alerts  domain.Alerts
+	air     domain.AirQuality
+	err     error
+}
+
+func (r *countingRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	r.calls.Add(1)
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return domain.Weather{}, ctx.Err()
+	}
+	return r.weather, r.err
+}
+
+func (r *countingRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	r.calls.Add(1)
+	return domain.Forecast{}, r.err
+}
+
+func (r *countingRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	r.calls.Add(1)
+	return r.alerts, r.err
+}
+
+func (r *countingRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	r.calls.Add(1)
+	return r.air, r.err
+}
+
+const testCacheSize = 100
+
+var testCacheConfig = decorator.CacheConfig{
+	Current:          decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+	Forecast:         decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+	Alerts:           decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+	Air:              decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+	BatchParallelism: 1,
+	RefreshTimeout:   time.Second,
+}
+
+func newTestCacheDecorator(
+	repo *countingRepo, memory *cache.LRU[cache.Entry[domain.Weather]], metrics *mockCacheMetrics, locker *memLocker,
+) *decorator.CacheDecorator {
+	forecasts := cache.NewLRU[cache.Entry[domain.Forecast]](testCacheSize, 0)
+	alerts := cache.NewLRU[cache.Entry[domain.Alerts]](testCacheSize, 0)
+	air := cache.NewLRU[cache.Entry[domain.AirQuality]](testCacheSize, 0)
+	return decorator.NewCacheDecorator(repo, memory, forecasts, alerts, air, metrics, locker, testCacheConfig)
+}
+
+func TestCacheDecorator_CoalescesMisses(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{delay: 50 * time.Millisecond, weather: domain.Weather{Temperature: 20}}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	locker := newMemLocker()
+	metrics := newMockCacheMetrics()
+	replicas := []*decorator.CacheDecorator{
+		newTestCacheDecorator(repo, memory, metrics, locker),
+		newTestCacheDecorator(repo, memory, metrics, locker),
+	}
+	const requestsPerReplica = 4
+	var wg sync.WaitGroup
+
+	// Act
+	for _, replica := range replicas {
+		for range requestsPerReplica {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				weather, err := replica.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+				assert.NoError(t, err)
+				assert.Equal(t, 20.0, weather.Temperature)
+			}()
+		}
+	}
+	wg.Wait()
+
+	// Assert
+	require.Equal(t, int32(1), repo.calls.Load())
+	assert.Equal(t, 1, metrics.loads["leader"])
+	assert.Equal(t, 1, metrics.loads["replica"])
+	assert.Equal(t, 2*(requestsPerReplica-1), metrics.loads["coalesced"])
+}
+
+func TestCacheDecorator_LoadsWhenLockHolderGivesUp(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{weather: domain.Weather{Temperature: 20}}
+	locker := newMemLocker()
+	unlock, acquired, err := locker.TryLock(context.Background(), "lock:"+domain.Location{Name: "Kyiv"}.Key())
+	require.NoError(t, err)
+	require.True(t, acquired)
+	time.AfterFunc(80*time.Millisecond, func() { _ = unlock(context.Background()) })
+	repoWithCache := newTestCacheDecorator(repo, cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0), newMockCacheMetrics(), locker)
+
+	// Act
+	weather, err := repoWithCache.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.Equal(t, int32(1), repo.calls.Load())
+}
+
+func TestCacheDecorator_ServesStaleWhileRevalidating(t *testing.T) {
+	// Arrange
+	kyiv := domain.Location{Name: "Kyiv"}
+	repo := &countingRepo{weather: domain.Weather{Temperature: 25}}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	storedAt := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, memory.Set(context.Background(), kyiv.Key(),
+		cache.NewEntry(domain.Weather{Temperature: 20}, storedAt, time.Minute, 10*time.Minute)))
+	repoWithCache := newTestCacheDecorator(repo, memory, newMockCacheMetrics(), newMemLocker())
+
+	// Act
+	weather, err := repoWithCache.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.False(t, weather.Stale)
+	assert.InDelta(t, 2*time.Minute, weather.Age, float64(time.Second))
+	assert.Eventually(t, func() bool {
+		var entry cache.Entry[domain.Weather]
+		return memory.Get(context.Background(), kyiv.Key(), &entry) == nil && entry.Value.Temperature == 25
+	}, time.Second, 10*time.Millisecond, "stale entry should be refreshed in the background")
+	assert.Equal(t, int32(1), repo.calls.Load())
+}
+
+func TestCacheDecorator_ServesStaleOnError(t *testing.T) {
+	// Arrange
+	kyiv := domain.Location{Name: "Kyiv"}
+	repo := &countingRepo{err: domain.ErrWeatherUnavailable}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	storedAt := time.Now().Add(-time.Hour)
+	require.NoError(t, memory.Set(context.Background(), kyiv.Key(),
+		cache.NewEntry(domain.Weather{Temperature: 20}, storedAt, time.Minute, 10*time.Minute)))
+	repoWithCache := newTestCacheDecorator(repo, memory, newMockCacheMetrics(), newMemLocker())
+
+	// Act
+	weather, err := repoWithCache.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.True(t, weather.Stale)
+	assert.InDelta(t, time.Hour, weather.Age, float64(time.Second))
+	assert.Equal(t, int32(1), repo.calls.Load())
+}
+
+func TestCacheDecorator_ErrorWithoutStaleEntry(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{err: domain.ErrWeatherUnavailable}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	repoWithCache := newTestCacheDecorator(repo, memory, newMockCacheMetrics(), newMemLocker())
+
+	// Act
+	_, err := repoWithCache.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+}
+
+func TestCacheDecorator_CachesUnknownCities(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{err: fmt.Errorf("chain: %w", domain.ErrCityNotFound)}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	repoWithCache := newTestCacheDecorator(repo, memory, newMockCacheMetrics(), newMemLocker())
+	atlantis := domain.Location{Name: "Atlantis", Lat: 31, Lon: -24}
+
+	// Act
+	_, firstErr := repoWithCache.GetCurrent(context.Background(), atlantis)
+	_, secondErr := repoWithCache.GetCurrent(context.Background(), atlantis)
+
+	// Assert
+	require.ErrorIs(t, firstErr, domain.ErrCityNotFound)
+	require.ErrorIs(t, secondErr, domain.ErrCityNotFound)
+	assert.Equal(t, int32(1), repo.calls.Load(), "unknown city should be answered from the cache")
+}
+
+func TestCacheDecorator_PurgeLocation(t *testing.T) {
+	// Arrange
+	kyiv := domain.Location{ID: 703448, Name: "Kyiv"}
+	lviv := domain.Location{ID: 702550, Name: "Lviv"}
+	repo := &countingRepo{weather: domain.Weather{Temperature: 20}}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	forecasts := cache.NewLRU[cache.Entry[domain.Forecast]](testCacheSize, 0)
+	alerts := cache.NewLRU[cache.Entry[domain.Alerts]](testCacheSize, 0)
+	air := cache.NewLRU[cache.Entry[domain.AirQuality]](testCacheSize, 0)
+	repoWithCache := decorator.NewCacheDecorator(
+		repo, memory, forecasts, alerts, air, newMockCacheMetrics(), newMemLocker(), testCacheConfig,
+	)
+	for _, loc := range []domain.Location{kyiv, lviv} {
+		_, err := repoWithCache.GetCurrent(context.Background(), loc)
+		require.NoError(t, err)
+		_, err = repoWithCache.GetForecast(context.Background(), loc, 3)
+		require.NoError(t, err)
+		_, err = repoWithCache.GetAlerts(context.Background(), loc)
+		require.NoError(t, err)
+		_, err = repoWithCache.GetAirQuality(context.Background(), loc)
+		require.NoError(t, err)
+	}
+
+	// Act
+	purged, err := repoWithCache.PurgeLocation(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 4, purged)
+	assert.Equal(t, 1, memory.Len())
+	assert.Equal(t, 1, forecasts.Len())
+	assert.Equal(t, 1, alerts.Len())
+	assert.Equal(t, 1, air.Len())
+}
+
+func TestCacheDecorator_Warm(t *testing.T) {
+	// Arrange
+	kyiv := domain.Location{ID: 703448, Name: "Kyiv"}
+	repo := &countingRepo{weather: domain.Weather{Temperature: 20}}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	repoWithCache := newTestCacheDecorator(repo, memory, newMockCacheMetrics(), newMemLocker())
+
+	// Act
+	refreshed, err := repoWithCache.Warm(context.Background(), kyiv, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	freshUntilWindow, err := repoWithCache.Warm(context.Background(), kyiv, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	staleAtWindow, err := repoWithCache.Warm(context.Background(), kyiv, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, refreshed)
+	assert.False(t, freshUntilWindow)
+	assert.True(t, staleAtWindow)
+	assert.Equal(t, int32(2), repo.calls.Load())
+}
+
+func TestCacheDecorator_CachesAlertsApartFromWeather(t *testing.T) {
+	// Arrange
+	kyiv := domain.Location{ID: 703448, Name: "Kyiv"}
+	repo := &countingRepo{
+		weather: domain.Weather{Temperature: 20},
+		alerts:  domain.Alerts{Alerts: []domain.Alert{{Event: "Flood Warning"}}, Source: "weatherapi.com"},
+	}
+	memory := cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0)
+	repoWithCache := newTestCacheDecorator(repo, memory, newMockCacheMetrics(), newMemLocker())
+	_, err := repoWithCache.GetCurrent(context.Background(), kyiv)
+	require.NoError(t, err)
+
+	// Act
+	first, firstErr := repoWithCache.GetAlerts(context.Background(), kyiv)
+	second, secondErr := repoWithCache.GetAlerts(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, repo.alerts, first)
+	assert.Equal(t, "Flood Warning", second.Alerts[0].Event)
+	assert.Equal(t, int32(2), repo.calls.Load(), "alerts should be loaded once, apart from the current weather")
+}
+
+func newBatchTestCacheDecorator(repo *countingRepo, itemTimeout, batchTimeout time.Duration) *decorator.CacheDecorator {
+	cfg := testCacheConfig
+	cfg.BatchItemTimeout = itemTimeout
+	cfg.BatchTimeout = batchTimeout
+	return decorator.NewCacheDecorator(
+		repo,
+		cache.NewLRU[cache.Entry[domain.Weather]](testCacheSize, 0),
+		cache.NewLRU[cache.Entry[domain.Forecast]](testCacheSize, 0),
+		cache.NewLRU[cache.Entry[domain.Alerts]](testCacheSize, 0),
+		cache.NewLRU[cache.Entry[domain.AirQuality]](testCacheSize, 0),
+		newMockCacheMetrics(), newMemLocker(), cfg,
+	)
+}
+
+func TestCacheDecorator_GetCurrentBatch_TimeoutPerMiss(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{delay: 30 * time.Millisecond, weather: domain.Weather{Temperature: 20}}
+	repoWithCache := newBatchTestCacheDecorator(repo, 50*time.Millisecond, 0)
+	locs := []domain.Location{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	// Act
+	results := repoWithCache.GetCurrentBatch(context.Background(), locs)
+
+	// Assert
+	require.Len(t, results, len(locs))
+	for _, r := range results {
+		require.NoError(t, r.Err, "misses loaded one at a time must not share a deadline")
+		assert.Equal(t, 20.0, r.Weather.Temperature)
+	}
+}
+
+func TestCacheDecorator_GetCurrentBatch_SlowMissTimesOut(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{delay: time.Second}
+	repoWithCache := newBatchTestCacheDecorator(repo, 10*time.Millisecond, 0)
+
+	// Act
+	results := repoWithCache.GetCurrentBatch(context.Background(), []domain.Location{{ID: 1}})
+
+	// Assert
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, context.DeadlineExceeded)
+}
+
+func TestCacheDecorator_GetCurrentBatch_PartialResultsAfterBatchTimeout(t *testing.T) {
+	// Arrange
+	repo := &countingRepo{delay: 40 * time.Millisecond, weather: domain.Weather{Temperature: 20}}
+	repoWithCache := newBatchTestCacheDecorator(repo, time.Second, 60*time.Millisecond)
+	locs := []domain.Location{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	// Act
+	start := time.Now()
+	results := repoWithCache.GetCurrentBatch(context.Background(), locs)
+
+	// Assert
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	require.Len(t, results, len(locs))
+	loaded := 0
+	for _, r := range results {
+		if r.Err != nil {
+			assert.ErrorIs(t, r.Err, context.DeadlineExceeded)
+			continue
+		}
+		loaded++
+		assert.Equal(t, 20.0, r.Weather.Temperature)
+	}
+	assert.Equal(t, 1, loaded, "only the miss loaded before the batch deadline should have a result")
+}
@@ -0,0 +1,53 @@
+package decorator
+
+import (
+	"context"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type batchWeatherRepo interface {
+	weatherRepo
+	GetCurrentBatch(ctx context.Context, locs []domain.Location) []domain.CurrentResult
+}
+
+type demandRecorder interface {
+	Record(loc domain.Location, t time.Time)
+}
+
+// DemandDecorator records which locations current weather is asked for and when,
+// so the cache can be warmed for them ahead of time. Forecasts, alerts and air quality are not warmed.
+type DemandDecorator struct {
+	Inner  batchWeatherRepo
+	Demand demandRecorder
+}
+
+func NewDemandDecorator(inner batchWeatherRepo, demand demandRecorder) *DemandDecorator {
+	return &DemandDecorator{Inner: inner, Demand: demand}
+}
+
+func (d *DemandDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	d.Demand.Record(loc, time.Now())
+	return d.Inner.GetCurrent(ctx, loc)
+}
+
+func (d *DemandDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return d.Inner.GetForecast(ctx, loc, days)
+}
+
+func (d *DemandDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return d.Inner.GetAlerts(ctx, loc)
+}
+
+func (d *DemandDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return d.Inner.GetAirQuality(ctx, loc)
+}
+
+func (d *DemandDecorator) GetCurrentBatch(ctx context.Context, locs []domain.Location) []domain.CurrentResult {
+	now := time.Now()
+	for _, loc := range locs {
+		d.Demand.Record(loc, now)
+	}
+	return d.Inner.GetCurrentBatch(ctx, locs)
+}
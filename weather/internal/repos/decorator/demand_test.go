@@ -0,0 +1,45 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDemand struct {
+	recorded []domain.Location
+}
+
+func (m *mockDemand) Record(loc domain.Location, t time.Time) {
+	m.recorded = append(m.recorded, loc)
+}
+
+type mockBatchRepo struct {
+	mockWeatherRepo
+}
+
+func (m *mockBatchRepo) GetCurrentBatch(ctx context.Context, locs []domain.Location) []domain.CurrentResult {
+	return make([]domain.CurrentResult, len(locs))
+}
+
+func TestDemandDecorator_RecordsCurrentLookups(t *testing.T) {
+	// Arrange
+	kyiv := domain.Location{ID: 703448, Name: "Kyiv"}
+	lviv := domain.Location{ID: 702550, Name: "Lviv"}
+	demand := &mockDemand{}
+	repo := decorator.NewDemandDecorator(&mockBatchRepo{}, demand)
+
+	// Act
+	_, _ = repo.GetCurrent(context.Background(), kyiv)
+	_ = repo.GetCurrentBatch(context.Background(), []domain.Location{kyiv, lviv})
+	_, _ = repo.GetForecast(context.Background(), lviv, 3)
+
+	// Assert
+	assert.Equal(t, []domain.Location{kyiv, kyiv, lviv}, demand.recorded)
+}
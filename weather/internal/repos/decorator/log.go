@@ -8,7 +8,10 @@ import (
 )
 
 type weatherRepo interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+	GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error)
+	GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error)
+	GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error)
+	GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error)
 }
 
 type LogDecorator struct {
@@ -21,12 +24,43 @@ func NewLogDecorator(inner weatherRepo, repoName string, logger *log.Logger) *Lo
 	return &LogDecorator{Inner: inner, RepoName: repoName, Logger: logger}
 }
 
-func (d *LogDecorator) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	weather, err := d.Inner.GetCurrent(ctx, city)
+func (d *LogDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	weather, err := d.Inner.GetCurrent(ctx, loc)
 	if err != nil {
-		d.Logger.Printf("%s - error for %s: %v\n", d.RepoName, city, err)
+		d.Logger.Printf("%s - error for %s: %v\n", d.RepoName, loc, err)
 		return domain.Weather{}, err
 	}
-	d.Logger.Printf("%s - success for %s: %v\n", d.RepoName, city, weather)
+	d.Logger.Printf("%s - success for %s: %v\n", d.RepoName, loc, weather)
 	return weather, nil
 }
+
+func (d *LogDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	forecast, err := d.Inner.GetForecast(ctx, loc, days)
+	if err != nil {
+		d.Logger.Printf("%s - forecast error for %s (%d days): %v\n", d.RepoName, loc, days, err)
+		return domain.Forecast{}, err
+	}
+	d.Logger.Printf("%s - forecast success for %s (%d days): %d hourly, %d daily entries\n",
+		d.RepoName, loc, days, len(forecast.Hourly), len(forecast.Daily))
+	return forecast, nil
+}
+
+func (d *LogDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	alerts, err := d.Inner.GetAlerts(ctx, loc)
+	if err != nil {
+		d.Logger.Printf("%s - alerts error for %s: %v\n", d.RepoName, loc, err)
+		return domain.Alerts{}, err
+	}
+	d.Logger.Printf("%s - alerts success for %s: %d alerts\n", d.RepoName, loc, len(alerts.Alerts))
+	return alerts, nil
+}
+
+func (d *LogDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	airQuality, err := d.Inner.GetAirQuality(ctx, loc)
+	if err != nil {
+		d.Logger.Printf("%s - air quality error for %s: %v\n", d.RepoName, loc, err)
+		return domain.AirQuality{}, err
+	}
+	d.Logger.Printf("%s - air quality success for %s: aqi %d\n", d.RepoName, loc, airQuality.AQI)
+	return airQuality, nil
+}
@@ -15,16 +15,34 @@ import (
 )
 
 type mockWeatherRepo struct {
-	Response domain.Weather
-	Err      error
-	Called   bool
+	Response         domain.Weather
+	ForecastResponse domain.Forecast
+	AlertsResponse   domain.Alerts
+	AirResponse      domain.AirQuality
+	Err              error
+	Called           bool
 }
 
-func (m *mockWeatherRepo) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (m *mockWeatherRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
 	m.Called = true
 	return m.Response, m.Err
 }
 
+func (m *mockWeatherRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	m.Called = true
+	return m.ForecastResponse, m.Err
+}
+
+func (m *mockWeatherRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	m.Called = true
+	return m.AlertsResponse, m.Err
+}
+
+func (m *mockWeatherRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	m.Called = true
+	return m.AirResponse, m.Err
+}
+
 func TestLoggingWeatherRepo_Success(t *testing.T) {
 	// Arrange
 	var buf bytes.Buffer
@@ -36,7 +54,7 @@ func TestLoggingWeatherRepo_Success(t *testing.T) {
 	repo := decorator.NewLogDecorator(mock, "MockRepo", logger)
 
 	// Act
-	result, err := repo.GetCurrent(context.Background(), "Kyiv")
+	result, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
 
 	// Assert
 	require.NoError(t, err)
@@ -58,7 +76,7 @@ func TestLoggingWeatherRepo_Error(t *testing.T) {
 	repo := decorator.NewLogDecorator(mock, "MockRepo", logger)
 
 	// Act
-	result, err := repo.GetCurrent(context.Background(), "kmaTop")
+	result, err := repo.GetCurrent(context.Background(), domain.Location{Name: "kmaTop"})
 
 	// Assert
 	require.Error(t, err)
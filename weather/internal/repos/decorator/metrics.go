@@ -0,0 +1,93 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// outcomes of a provider call, by the class of its error
+const (
+	outcomeOK            = "ok"
+	outcomeNotFound      = "not_found"
+	outcomeUnavailable   = "unavailable"
+	outcomeInternal      = "internal"
+	outcomeBreakerOpen   = "breaker_open"
+	outcomeDisabled      = "disabled"
+	outcomeQuotaExceeded = "quota_exceeded"
+	// the chain no longer needed the answer, e.g. another provider won a hedged request
+	outcomeCanceled = "canceled"
+)
+
+type providerCallMetrics interface {
+	ProviderRequest(provider, outcome string, duration float64)
+}
+
+// MetricsDecorator records the duration and outcome of every call to a provider.
+// It goes outside BreakerDecorator, so calls the breaker rejects are counted too.
+type MetricsDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+	Metrics  providerCallMetrics
+}
+
+func NewMetricsDecorator(inner weatherRepo, repoName string, metrics providerCallMetrics) *MetricsDecorator {
+	return &MetricsDecorator{Inner: inner, RepoName: repoName, Metrics: metrics}
+}
+
+func (d *MetricsDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	start := time.Now()
+	weather, err := d.Inner.GetCurrent(ctx, loc)
+	d.record(ctx, start, err)
+	return weather, err
+}
+
+func (d *MetricsDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	start := time.Now()
+	forecast, err := d.Inner.GetForecast(ctx, loc, days)
+	d.record(ctx, start, err)
+	return forecast, err
+}
+
+func (d *MetricsDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	start := time.Now()
+	alerts, err := d.Inner.GetAlerts(ctx, loc)
+	d.record(ctx, start, err)
+	return alerts, err
+}
+
+func (d *MetricsDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	start := time.Now()
+	airQuality, err := d.Inner.GetAirQuality(ctx, loc)
+	d.record(ctx, start, err)
+	return airQuality, err
+}
+
+func (d *MetricsDecorator) record(ctx context.Context, start time.Time, err error) {
+	d.Metrics.ProviderRequest(d.RepoName, outcome(ctx, err), time.Since(start).Seconds())
+}
+
+// outcome classifies the result of a call. Providers report a cancelled request as unavailable,
+// so cancellation is read from ctx.
+func outcome(ctx context.Context, err error) string {
+	switch {
+	case err == nil:
+		return outcomeOK
+	case errors.Is(ctx.Err(), context.Canceled):
+		return outcomeCanceled
+	case errors.Is(err, domain.ErrCityNotFound):
+		return outcomeNotFound
+	case errors.Is(err, domain.ErrProviderDisabled):
+		return outcomeDisabled
+	case errors.Is(err, domain.ErrProviderUnreliable):
+		return outcomeBreakerOpen
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return outcomeQuotaExceeded
+	case errors.Is(err, domain.ErrWeatherUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return outcomeUnavailable
+	default:
+		return outcomeInternal
+	}
+}
@@ -0,0 +1,67 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProviderCallMetrics struct {
+	outcomes []string
+}
+
+func (m *mockProviderCallMetrics) ProviderRequest(provider, outcome string, duration float64) {
+	m.outcomes = append(m.outcomes, outcome)
+}
+
+func TestMetricsDecorator_Outcomes(t *testing.T) {
+	tests := []struct {
+		err     error
+		outcome string
+	}{
+		{err: nil, outcome: "ok"},
+		{err: fmt.Errorf("free weather repo: %w", domain.ErrCityNotFound), outcome: "not_found"},
+		{err: fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable), outcome: "unavailable"},
+		{err: context.DeadlineExceeded, outcome: "unavailable"},
+		{err: fmt.Errorf("circuit breaker: %w", domain.ErrProviderUnreliable), outcome: "breaker_open"},
+		{err: domain.ErrQuotaExceeded, outcome: "quota_exceeded"},
+		{err: fmt.Errorf("test: %w", domain.ErrProviderDisabled), outcome: "disabled"},
+		{err: domain.ErrInternal, outcome: "internal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.outcome, func(t *testing.T) {
+			// Arrange
+			metrics := &mockProviderCallMetrics{}
+			repo := decorator.NewMetricsDecorator(&mockWeatherRepo{Err: tt.err}, "test", metrics)
+
+			// Act
+			_, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+			// Assert
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, []string{tt.outcome}, metrics.outcomes)
+		})
+	}
+}
+
+func TestMetricsDecorator_Canceled(t *testing.T) {
+	// Arrange
+	metrics := &mockProviderCallMetrics{}
+	repo := decorator.NewMetricsDecorator(&mockWeatherRepo{Err: domain.ErrWeatherUnavailable}, "test", metrics)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := repo.GetForecast(ctx, domain.Location{Name: "Kyiv"}, 1)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, []string{"canceled"}, metrics.outcomes, "a call the chain gave up on is not the provider's failure")
+}
@@ -0,0 +1,43 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type weatherPublisher interface {
+	Publish(key string, weather domain.Weather)
+}
+
+// PublishDecorator announces every reading fetched from the providers. Placed right under the
+// cache decorator, it reports each refresh of a cached value to watchers of the location.
+type PublishDecorator struct {
+	Inner     weatherRepo
+	Publisher weatherPublisher
+}
+
+func NewPublishDecorator(inner weatherRepo, publisher weatherPublisher) *PublishDecorator {
+	return &PublishDecorator{Inner: inner, Publisher: publisher}
+}
+
+func (d *PublishDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	weather, err := d.Inner.GetCurrent(ctx, loc)
+	if err != nil {
+		return domain.Weather{}, err
+	}
+	d.Publisher.Publish(loc.Key(), weather)
+	return weather, nil
+}
+
+func (d *PublishDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return d.Inner.GetForecast(ctx, loc, days)
+}
+
+func (d *PublishDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return d.Inner.GetAlerts(ctx, loc)
+}
+
+func (d *PublishDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return d.Inner.GetAirQuality(ctx, loc)
+}
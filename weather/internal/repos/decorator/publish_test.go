@@ -0,0 +1,50 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPublisher struct {
+	published map[string]domain.Weather
+}
+
+func (m *mockPublisher) Publish(key string, weather domain.Weather) {
+	m.published[key] = weather
+}
+
+func TestPublishDecorator_Success(t *testing.T) {
+	// Arrange
+	weather := domain.Weather{Temperature: 25.0, Description: "Clear"}
+	publisher := &mockPublisher{published: make(map[string]domain.Weather)}
+	repo := decorator.NewPublishDecorator(&mockWeatherRepo{Response: weather}, publisher)
+	kyiv := domain.Location{ID: 703448, Name: "Kyiv"}
+
+	// Act
+	result, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, weather, result)
+	assert.Equal(t, map[string]domain.Weather{kyiv.Key(): weather}, publisher.published)
+}
+
+func TestPublishDecorator_Error(t *testing.T) {
+	// Arrange
+	publisher := &mockPublisher{published: make(map[string]domain.Weather)}
+	repo := decorator.NewPublishDecorator(&mockWeatherRepo{Err: domain.ErrWeatherUnavailable}, publisher)
+
+	// Act
+	_, err := repo.GetCurrent(context.Background(), domain.Location{ID: 703448, Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.Empty(t, publisher.published)
+}
@@ -0,0 +1,131 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const (
+	minuteQuota = "minute"
+	dayQuota    = "day"
+)
+
+type rateLimiter interface {
+	Allow() bool
+	Remaining() int
+	Drain()
+}
+
+type dailyBudget interface {
+	Spend(ctx context.Context) (remaining int, ok bool, err error)
+}
+
+type quotaMetrics interface {
+	ProviderQuotaRemaining(provider, window string, remaining int)
+}
+
+// RateLimitDecorator keeps calls to a provider within its per-minute and daily quotas.
+// Calls over a quota are not made at all and fail with domain.ErrQuotaExceeded, so the chain moves on
+// to the next provider without the breaker counting a failure. A nil Limiter or Budget means no such quota.
+type RateLimitDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+	Limiter  rateLimiter
+	Budget   dailyBudget
+	Metrics  quotaMetrics
+
+	mu sync.Mutex
+	// once the daily budget is spent the provider is skipped without asking Redis until the next UTC day
+	exhaustedUntil time.Time
+}
+
+func NewRateLimitDecorator(inner weatherRepo, repoName string, metrics quotaMetrics) *RateLimitDecorator {
+	return &RateLimitDecorator{Inner: inner, RepoName: repoName, Metrics: metrics}
+}
+
+func (d *RateLimitDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return withQuota(ctx, d, func() (domain.Weather, error) {
+		return d.Inner.GetCurrent(ctx, loc)
+	})
+}
+
+func (d *RateLimitDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return withQuota(ctx, d, func() (domain.Forecast, error) {
+		return d.Inner.GetForecast(ctx, loc, days)
+	})
+}
+
+func (d *RateLimitDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return withQuota(ctx, d, func() (domain.Alerts, error) {
+		return d.Inner.GetAlerts(ctx, loc)
+	})
+}
+
+func (d *RateLimitDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return withQuota(ctx, d, func() (domain.AirQuality, error) {
+		return d.Inner.GetAirQuality(ctx, loc)
+	})
+}
+
+// withQuota runs call if both quotas allow it. When the provider reports its quota exceeded
+// before we think it is, the per-minute bucket is drained so the next calls back off.
+func withQuota[T any](ctx context.Context, d *RateLimitDecorator, call func() (T, error)) (T, error) {
+	var zero T
+	if !d.allow(ctx) {
+		return zero, fmt.Errorf("rate limit: %s: %w", d.RepoName, domain.ErrQuotaExceeded)
+	}
+
+	result, err := call()
+	if errors.Is(err, domain.ErrQuotaExceeded) && d.Limiter != nil {
+		d.Limiter.Drain()
+		d.Metrics.ProviderQuotaRemaining(d.RepoName, minuteQuota, 0)
+	}
+	return result, err
+}
+
+func (d *RateLimitDecorator) allow(ctx context.Context) bool {
+	now := time.Now()
+	d.mu.Lock()
+	exhausted := now.Before(d.exhaustedUntil)
+	d.mu.Unlock()
+	if exhausted {
+		return false
+	}
+
+	if d.Limiter != nil {
+		allowed := d.Limiter.Allow()
+		d.Metrics.ProviderQuotaRemaining(d.RepoName, minuteQuota, d.Limiter.Remaining())
+		if !allowed {
+			return false
+		}
+	}
+
+	if d.Budget == nil {
+		return true
+	}
+	remaining, ok, err := d.Budget.Spend(ctx)
+	if err != nil {
+		// a Redis outage should not take the providers down with it
+		log.Printf("rate limit: %s: daily budget unavailable: %v\n", d.RepoName, err)
+		return true
+	}
+	d.Metrics.ProviderQuotaRemaining(d.RepoName, dayQuota, remaining)
+	if !ok {
+		log.Printf("rate limit: %s: daily budget exhausted\n", d.RepoName)
+		d.mu.Lock()
+		d.exhaustedUntil = nextUTCDay(now)
+		d.mu.Unlock()
+	}
+	return ok
+}
+
+func nextUTCDay(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
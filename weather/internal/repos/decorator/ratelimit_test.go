@@ -0,0 +1,91 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/ratelimit"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockQuotaMetrics struct {
+	remaining map[string]int
+}
+
+func newMockQuotaMetrics() *mockQuotaMetrics {
+	return &mockQuotaMetrics{remaining: make(map[string]int)}
+}
+
+func (m *mockQuotaMetrics) ProviderQuotaRemaining(provider, window string, remaining int) {
+	m.remaining[window] = remaining
+}
+
+type memBudget struct {
+	limit int
+	spent int
+}
+
+func (b *memBudget) Spend(ctx context.Context) (int, bool, error) {
+	b.spent++
+	return max(b.limit-b.spent, 0), b.spent <= b.limit, nil
+}
+
+func TestRateLimitDecorator_PerMinuteLimit(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{Response: domain.Weather{Temperature: 20}}
+	metrics := newMockQuotaMetrics()
+	repo := decorator.NewRateLimitDecorator(mock, "test", metrics)
+	repo.Limiter = ratelimit.NewTokenBucket(1)
+
+	// Act
+	_, firstErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+	mock.Called = false
+	_, secondErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.ErrorIs(t, secondErr, domain.ErrQuotaExceeded)
+	assert.False(t, mock.Called, "provider should not be called over the limit")
+	assert.Equal(t, 0, metrics.remaining["minute"])
+}
+
+func TestRateLimitDecorator_SkipsProviderWhenBudgetIsSpent(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{Response: domain.Weather{Temperature: 20}}
+	budget := &memBudget{limit: 1}
+	metrics := newMockQuotaMetrics()
+	repo := decorator.NewRateLimitDecorator(mock, "test", metrics)
+	repo.Budget = budget
+
+	// Act
+	_, firstErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+	_, secondErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+	_, thirdErr := repo.GetForecast(context.Background(), domain.Location{Name: "Kyiv"}, 1)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.ErrorIs(t, secondErr, domain.ErrQuotaExceeded)
+	require.ErrorIs(t, thirdErr, domain.ErrQuotaExceeded)
+	assert.Equal(t, 2, budget.spent, "exhausted budget should not be asked again today")
+	assert.Equal(t, 0, metrics.remaining["day"])
+}
+
+func TestRateLimitDecorator_DrainsBucketWhenProviderRejects(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{Err: domain.ErrQuotaExceeded}
+	repo := decorator.NewRateLimitDecorator(mock, "test", newMockQuotaMetrics())
+	bucket := ratelimit.NewTokenBucket(10)
+	repo.Limiter = bucket
+
+	// Act
+	_, err := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrQuotaExceeded)
+	assert.Equal(t, 0, bucket.Remaining())
+}
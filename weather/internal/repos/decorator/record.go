@@ -0,0 +1,45 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type weatherRecorder interface {
+	Record(loc domain.Location, provider string, weather domain.Weather)
+}
+
+// RecordDecorator hands every reading a provider returns to the weather history. Placed right over
+// the provider api, it stores each reading under the configured provider name, also the ones
+// a consensus merges or a hedged fallback throws away.
+type RecordDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+	Recorder weatherRecorder
+}
+
+func NewRecordDecorator(inner weatherRepo, repoName string, recorder weatherRecorder) *RecordDecorator {
+	return &RecordDecorator{Inner: inner, RepoName: repoName, Recorder: recorder}
+}
+
+func (d *RecordDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	weather, err := d.Inner.GetCurrent(ctx, loc)
+	if err != nil {
+		return domain.Weather{}, err
+	}
+	d.Recorder.Record(loc, d.RepoName, weather)
+	return weather, nil
+}
+
+func (d *RecordDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return d.Inner.GetForecast(ctx, loc, days)
+}
+
+func (d *RecordDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return d.Inner.GetAlerts(ctx, loc)
+}
+
+func (d *RecordDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return d.Inner.GetAirQuality(ctx, loc)
+}
@@ -0,0 +1,56 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorded struct {
+	loc      domain.Location
+	provider string
+	weather  domain.Weather
+}
+
+type mockRecorder struct {
+	recorded []recorded
+}
+
+func (m *mockRecorder) Record(loc domain.Location, provider string, weather domain.Weather) {
+	m.recorded = append(m.recorded, recorded{loc: loc, provider: provider, weather: weather})
+}
+
+func TestRecordDecorator_Success(t *testing.T) {
+	// Arrange
+	weather := domain.Weather{Temperature: 25.0, Description: "Clear", Source: "weatherapi.com"}
+	recorder := &mockRecorder{}
+	repo := decorator.NewRecordDecorator(&mockWeatherRepo{Response: weather}, "backup", recorder)
+	kyiv := domain.Location{ID: 703448, Name: "Kyiv"}
+
+	// Act
+	result, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, weather, result)
+	assert.Equal(t, []recorded{{loc: kyiv, provider: "backup", weather: weather}}, recorder.recorded)
+}
+
+func TestRecordDecorator_Error(t *testing.T) {
+	// Arrange
+	recorder := &mockRecorder{}
+	repo := decorator.NewRecordDecorator(&mockWeatherRepo{Err: domain.ErrWeatherUnavailable}, "backup", recorder)
+
+	// Act
+	_, err := repo.GetCurrent(context.Background(), domain.Location{ID: 703448, Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.Empty(t, recorder.recorded)
+}
@@ -0,0 +1,116 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type retryMetrics interface {
+	ProviderRetry(provider string)
+}
+
+type RetryPolicy struct {
+	// how many times a failed call is repeated, 0 disables retries
+	Retries int
+	// backoff before the first retry, doubled before every next one up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// RetryDecorator repeats provider calls that failed for a transient reason: the provider
+// was unreachable, answered with a 5xx or timed out. Unknown cities, exhausted quotas, rejected
+// api keys and internal errors are returned right away. It belongs inside BreakerDecorator, so the breaker
+// sees one outcome per call no matter how many attempts it took.
+type RetryDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+	Policy   RetryPolicy
+	Metrics  retryMetrics
+}
+
+func NewRetryDecorator(inner weatherRepo, repoName string, policy RetryPolicy, metrics retryMetrics) *RetryDecorator {
+	return &RetryDecorator{Inner: inner, RepoName: repoName, Policy: policy, Metrics: metrics}
+}
+
+func (d *RetryDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return withRetry(ctx, d, func() (domain.Weather, error) {
+		return d.Inner.GetCurrent(ctx, loc)
+	})
+}
+
+func (d *RetryDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return withRetry(ctx, d, func() (domain.Forecast, error) {
+		return d.Inner.GetForecast(ctx, loc, days)
+	})
+}
+
+func (d *RetryDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return withRetry(ctx, d, func() (domain.Alerts, error) {
+		return d.Inner.GetAlerts(ctx, loc)
+	})
+}
+
+func (d *RetryDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return withRetry(ctx, d, func() (domain.AirQuality, error) {
+		return d.Inner.GetAirQuality(ctx, loc)
+	})
+}
+
+// withRetry runs call until it succeeds, fails for a reason a retry won't fix, runs out of retries,
+// or the next attempt would start after the deadline of ctx.
+func withRetry[T any](ctx context.Context, d *RetryDecorator, call func() (T, error)) (T, error) {
+	result, err := call()
+	for attempt := range d.Policy.Retries {
+		if err == nil || !retryable(ctx, err) {
+			break
+		}
+		wait := d.Policy.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			break
+		}
+		log.Printf("retry: %s: attempt %d failed, retrying in %s: %v\n", d.RepoName, attempt+1, wait, err)
+		if !sleep(ctx, wait) {
+			break
+		}
+		d.Metrics.ProviderRetry(d.RepoName)
+		result, err = call()
+	}
+	return result, err
+}
+
+// retryable reports whether err is worth another attempt. A timeout only counts
+// when it was the provider call that timed out and not the whole request.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return errors.Is(err, domain.ErrWeatherUnavailable) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoff returns the exponential backoff before the retry after attempt with equal jitter:
+// at least half of it, so retries of many requests failed together spread out but still back off.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.Backoff << attempt
+	if wait > p.MaxBackoff || wait <= 0 {
+		wait = p.MaxBackoff
+	}
+	half := wait / 2
+	return half + rand.N(half+1) //nolint:gosec // jitter needs no secure source
+}
+
+// sleep waits for d and reports whether ctx is still alive afterwards.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRetryMetrics struct {
+	retries int
+}
+
+func (m *mockRetryMetrics) ProviderRetry(provider string) {
+	m.retries++
+}
+
+// flakyRepo fails with the given errors one by one and succeeds once they run out.
+type flakyRepo struct {
+	errs  []error
+	calls int
+}
+
+func (r *flakyRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	r.calls++
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+		return domain.Weather{}, err
+	}
+	return domain.Weather{Temperature: 20}, nil
+}
+
+func (r *flakyRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	r.calls++
+	return domain.Forecast{}, nil
+}
+
+func (r *flakyRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	r.calls++
+	return domain.Alerts{}, nil
+}
+
+func (r *flakyRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	r.calls++
+	return domain.AirQuality{}, nil
+}
+
+var testRetryPolicy = decorator.RetryPolicy{Retries: 2, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestRetryDecorator_RetriesTransientFailures(t *testing.T) {
+	// Arrange
+	unavailable := fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable)
+	repo := &flakyRepo{errs: []error{unavailable, context.DeadlineExceeded}}
+	metrics := &mockRetryMetrics{}
+	retried := decorator.NewRetryDecorator(repo, "test", testRetryPolicy, metrics)
+
+	// Act
+	weather, err := retried.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.Equal(t, 3, repo.calls)
+	assert.Equal(t, 2, metrics.retries)
+}
+
+func TestRetryDecorator_DoesNotRetryPermanentErrors(t *testing.T) {
+	for _, err := range []error{domain.ErrCityNotFound, domain.ErrQuotaExceeded, domain.ErrProviderAuth, domain.ErrInternal} {
+		t.Run(err.Error(), func(t *testing.T) {
+			// Arrange
+			repo := &flakyRepo{errs: []error{err}}
+			retried := decorator.NewRetryDecorator(repo, "test", testRetryPolicy, &mockRetryMetrics{})
+
+			// Act
+			_, gotErr := retried.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+			// Assert
+			require.ErrorIs(t, gotErr, err)
+			assert.Equal(t, 1, repo.calls)
+		})
+	}
+}
+
+func TestRetryDecorator_GivesUpBeforeDeadline(t *testing.T) {
+	// Arrange
+	repo := &flakyRepo{errs: []error{domain.ErrWeatherUnavailable}}
+	policy := decorator.RetryPolicy{Retries: 2, Backoff: time.Second, MaxBackoff: time.Second}
+	retried := decorator.NewRetryDecorator(repo, "test", policy, &mockRetryMetrics{})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	_, err := retried.GetCurrent(ctx, domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.Equal(t, 1, repo.calls)
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "should not wait for a retry that can't make it")
+}
+
+func TestRetryDecorator_BreakerCountsOneFailurePerCall(t *testing.T) {
+	// Arrange
+	unavailable := domain.ErrWeatherUnavailable
+	repo := &flakyRepo{errs: []error{unavailable, unavailable, unavailable}}
+	breaker := cb.NewCircuitBreaker(time.Minute, 2, 1)
+	retried := decorator.NewBreakerDecorator(decorator.NewRetryDecorator(repo, "test", testRetryPolicy, &mockRetryMetrics{}), breaker)
+
+	// Act
+	_, err := retried.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.Equal(t, 3, repo.calls)
+	assert.True(t, breaker.Allowed(), "three attempts of one call are a single failure")
+}
@@ -0,0 +1,51 @@
+package decorator
+
+import (
+	"context"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type statusRecorder interface {
+	Record(ctx context.Context, latency time.Duration, err error)
+}
+
+// StatusDecorator reports every call that reached the provider, with its latency and error,
+// for the admin API. It goes inside BreakerDecorator, so calls the breaker rejects are not counted.
+type StatusDecorator struct {
+	Inner  weatherRepo
+	Status statusRecorder
+}
+
+func NewStatusDecorator(inner weatherRepo, status statusRecorder) *StatusDecorator {
+	return &StatusDecorator{Inner: inner, Status: status}
+}
+
+func (d *StatusDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	start := time.Now()
+	weather, err := d.Inner.GetCurrent(ctx, loc)
+	d.Status.Record(ctx, time.Since(start), err)
+	return weather, err
+}
+
+func (d *StatusDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	start := time.Now()
+	forecast, err := d.Inner.GetForecast(ctx, loc, days)
+	d.Status.Record(ctx, time.Since(start), err)
+	return forecast, err
+}
+
+func (d *StatusDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	start := time.Now()
+	alerts, err := d.Inner.GetAlerts(ctx, loc)
+	d.Status.Record(ctx, time.Since(start), err)
+	return alerts, err
+}
+
+func (d *StatusDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	start := time.Now()
+	airQuality, err := d.Inner.GetAirQuality(ctx, loc)
+	d.Status.Record(ctx, time.Since(start), err)
+	return airQuality, err
+}
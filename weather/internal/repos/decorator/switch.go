@@ -0,0 +1,63 @@
+package decorator
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// SwitchDecorator lets a provider be taken out of rotation at runtime. While it is disabled
+// calls fail with domain.ErrProviderDisabled without reaching the provider, so the chain
+// skips it the same way it skips a provider behind an open breaker.
+type SwitchDecorator struct {
+	Inner    weatherRepo
+	RepoName string
+
+	disabled atomic.Bool
+}
+
+func NewSwitchDecorator(inner weatherRepo, repoName string) *SwitchDecorator {
+	return &SwitchDecorator{Inner: inner, RepoName: repoName}
+}
+
+func (d *SwitchDecorator) Enable() {
+	d.disabled.Store(false)
+}
+
+func (d *SwitchDecorator) Disable() {
+	d.disabled.Store(true)
+}
+
+func (d *SwitchDecorator) Enabled() bool {
+	return !d.disabled.Load()
+}
+
+func (d *SwitchDecorator) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	if !d.Enabled() {
+		return domain.Weather{}, fmt.Errorf("%s: %w", d.RepoName, domain.ErrProviderDisabled)
+	}
+	return d.Inner.GetCurrent(ctx, loc)
+}
+
+func (d *SwitchDecorator) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	if !d.Enabled() {
+		return domain.Forecast{}, fmt.Errorf("%s: %w", d.RepoName, domain.ErrProviderDisabled)
+	}
+	return d.Inner.GetForecast(ctx, loc, days)
+}
+
+func (d *SwitchDecorator) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	if !d.Enabled() {
+		return domain.Alerts{}, fmt.Errorf("%s: %w", d.RepoName, domain.ErrProviderDisabled)
+	}
+	return d.Inner.GetAlerts(ctx, loc)
+}
+
+func (d *SwitchDecorator) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	if !d.Enabled() {
+		return domain.AirQuality{}, fmt.Errorf("%s: %w", d.RepoName, domain.ErrProviderDisabled)
+	}
+	return d.Inner.GetAirQuality(ctx, loc)
+}
@@ -0,0 +1,33 @@
+//go:build unit
+
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/decorator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchDecorator(t *testing.T) {
+	// Arrange
+	mock := &mockWeatherRepo{Response: domain.Weather{Temperature: 20}}
+	repo := decorator.NewSwitchDecorator(mock, "test")
+
+	// Act
+	repo.Disable()
+	_, disabledErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+	calledWhileDisabled := mock.Called
+	repo.Enable()
+	weather, enabledErr := repo.GetCurrent(context.Background(), domain.Location{Name: "Kyiv"})
+
+	// Assert
+	require.ErrorIs(t, disabledErr, domain.ErrProviderDisabled)
+	require.ErrorIs(t, disabledErr, domain.ErrProviderUnreliable, "the chain skips it like an open breaker")
+	assert.False(t, calledWhileDisabled)
+	require.NoError(t, enabledErr)
+	assert.Equal(t, 20.0, weather.Temperature)
+}
@@ -0,0 +1,323 @@
+package gazetteer
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// columns of the GeoNames "cities" dump, see https://download.geonames.org/export/dump/readme.txt
+const (
+	colID = iota
+	colName
+	colASCIIName
+	colAlternateNames
+	colLat
+	colLon
+	_ // feature class
+	_ // feature code
+	colCountry
+	_ // cc2
+	_ // admin1 code
+	_ // admin2 code
+	_ // admin3 code
+	_ // admin4 code
+	colPopulation
+	_ // elevation
+	_ // dem
+	colTimezone
+
+	minColumns = colTimezone + 1
+)
+
+const (
+	maxSuggestions      = 3
+	maxSuggestDistance  = 3
+	suggestDistanceBase = 3
+	countryCodeLen      = 2
+	maxLineSize         = 1024 * 1024
+
+	earthDiameterKm = 2 * 6371.0
+	nearestRadiusKm = 30.0
+	degToRad        = math.Pi / 180
+	halfDegToRad    = degToRad / 2
+)
+
+//go:embed data/cities.tsv
+var bundledCities []byte
+
+type entry struct {
+	loc        domain.Location
+	population int64
+}
+
+// Gazetteer resolves free-form city names to canonical locations.
+// It is immutable after construction and safe for concurrent use.
+type Gazetteer struct {
+	entries []entry
+	names   map[string][]int
+}
+
+// NewBundled loads the city list shipped with the service.
+func NewBundled() (*Gazetteer, error) {
+	return New(bytes.NewReader(bundledCities))
+}
+
+// New loads cities from r in GeoNames "cities" tab-separated format.
+func New(r io.Reader) (*Gazetteer, error) {
+	g := &Gazetteer{names: make(map[string][]int)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		e, err := parseEntry(cols)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer: line %d: %w", lineNum, err)
+		}
+		g.add(e, cols[colAlternateNames])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gazetteer: %w", err)
+	}
+	if len(g.entries) == 0 {
+		return nil, errors.New("gazetteer: no cities loaded")
+	}
+	return g, nil
+}
+
+func parseEntry(cols []string) (entry, error) {
+	if len(cols) < minColumns {
+		return entry{}, fmt.Errorf("expected at least %d columns, got %d", minColumns, len(cols))
+	}
+	id, err := strconv.ParseInt(cols[colID], 10, 64)
+	if err != nil {
+		return entry{}, fmt.Errorf("invalid id: %w", err)
+	}
+	lat, err := strconv.ParseFloat(cols[colLat], 64)
+	if err != nil {
+		return entry{}, fmt.Errorf("invalid latitude: %w", err)
+	}
+	lon, err := strconv.ParseFloat(cols[colLon], 64)
+	if err != nil {
+		return entry{}, fmt.Errorf("invalid longitude: %w", err)
+	}
+	var population int64
+	if cols[colPopulation] != "" {
+		population, err = strconv.ParseInt(cols[colPopulation], 10, 64)
+		if err != nil {
+			return entry{}, fmt.Errorf("invalid population: %w", err)
+		}
+	}
+	return entry{
+		loc: domain.Location{
+			ID:       id,
+			Name:     cols[colName],
+			Country:  cols[colCountry],
+			Lat:      lat,
+			Lon:      lon,
+			Timezone: cols[colTimezone],
+		},
+		population: population,
+	}, nil
+}
+
+func (g *Gazetteer) add(e entry, alternateNames string) {
+	idx := len(g.entries)
+	g.entries = append(g.entries, e)
+
+	seen := make(map[string]struct{})
+	names := append([]string{e.loc.Name}, strings.Split(alternateNames, ",")...)
+	for _, name := range names {
+		key := normalize(name)
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		g.names[key] = append(g.names[key], idx)
+	}
+}
+
+// Resolve finds the location for query, which is a city name optionally followed by
+// a comma and an ISO 3166 country code, e.g. "Paris, US". Ambiguous names resolve to
+// the most populated city. Unknown names resolve to a location known only by its normalized name,
+// so cities missing from the list are left to providers, and only names without a single letter
+// or digit produce *domain.LocationNotFoundError.
+func (g *Gazetteer) Resolve(query string) (domain.Location, error) {
+	name, country := splitCountry(query)
+	key := normalize(name)
+
+	best := -1
+	for _, idx := range g.names[key] {
+		e := g.entries[idx]
+		if country != "" && e.loc.Country != country {
+			continue
+		}
+		if best == -1 || e.population > g.entries[best].population {
+			best = idx
+		}
+	}
+	if best == -1 {
+		if key == "" {
+			return domain.Location{}, &domain.LocationNotFoundError{Query: query}
+		}
+		return domain.Location{Name: key, Country: country, ByName: true}, nil
+	}
+	return g.entries[best].loc, nil
+}
+
+// Suggest returns display names of known cities close to name, for when providers don't know it either.
+func (g *Gazetteer) Suggest(name string) []string {
+	return g.suggest(normalize(name))
+}
+
+// Nearest returns the closest known city within nearestRadiusKm of the given point.
+func (g *Gazetteer) Nearest(lat, lon float64) (domain.Location, bool) {
+	best := -1
+	bestDistance := nearestRadiusKm
+	for i, e := range g.entries {
+		d := haversineKm(lat, lon, e.loc.Lat, e.loc.Lon)
+		if d <= bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+	if best == -1 {
+		return domain.Location{}, false
+	}
+	return g.entries[best].loc, true
+}
+
+// suggest returns display names of the cities closest to key by edit distance.
+func (g *Gazetteer) suggest(key string) []string {
+	if key == "" {
+		return nil
+	}
+	keyLen := len([]rune(key))
+	limit := min(max(keyLen/suggestDistanceBase, 1), maxSuggestDistance)
+
+	distances := make(map[int]int)
+	for name, idxs := range g.names {
+		if abs(len([]rune(name))-keyLen) > limit {
+			continue
+		}
+		d := levenshtein(key, name)
+		if d > limit {
+			continue
+		}
+		for _, idx := range idxs {
+			if prev, ok := distances[idx]; !ok || d < prev {
+				distances[idx] = d
+			}
+		}
+	}
+
+	candidates := make([]int, 0, len(distances))
+	for idx := range distances {
+		candidates = append(candidates, idx)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if distances[a] != distances[b] {
+			return distances[a] < distances[b]
+		}
+		return g.entries[a].population > g.entries[b].population
+	})
+
+	var suggestions []string
+	for _, idx := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, g.entries[idx].loc.String())
+	}
+	return suggestions
+}
+
+func splitCountry(query string) (name, country string) {
+	i := strings.LastIndex(query, ",")
+	if i == -1 {
+		return query, ""
+	}
+	code := strings.TrimSpace(query[i+1:])
+	if len(code) != countryCodeLen {
+		return query, ""
+	}
+	return query[:i], strings.ToUpper(code)
+}
+
+// normalize folds case and diacritics, drops apostrophes and treats punctuation as spaces,
+// so "Kam'ianets-Podilskyi ", "kamianets podilskyi" and "Київ"/"КИЇВ" compare equal.
+func normalize(s string) string {
+	folding := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)))
+	folded, _, err := transform.String(folding, s)
+	if err != nil {
+		folded = s
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(folded) {
+		switch {
+		case r == '\'' || r == '’' || r == 'ʼ' || r == '`':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	sinLat := math.Sin((lat2 - lat1) * halfDegToRad)
+	sinLon := math.Sin((lon2 - lon1) * halfDegToRad)
+	a := sinLat*sinLat + math.Cos(lat1*degToRad)*math.Cos(lat2*degToRad)*sinLon*sinLon
+	return earthDiameterKm * math.Asin(math.Sqrt(a))
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
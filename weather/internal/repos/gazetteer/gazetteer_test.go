@@ -0,0 +1,164 @@
+//go:build unit
+
+package gazetteer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/gazetteer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGazetteer_Resolve(t *testing.T) {
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		query      string
+		expectedID int64
+	}{
+		{name: "ExactName", query: "Kyiv", expectedID: 703448},
+		{name: "CaseAndSpaces", query: "  kYIv ", expectedID: 703448},
+		{name: "Cyrillic", query: "Київ", expectedID: 703448},
+		{name: "CyrillicUpper", query: "КИЇВ", expectedID: 703448},
+		{name: "AlternateName", query: "Kiev", expectedID: 703448},
+		{name: "Diacritics", query: "Krakow", expectedID: 3094802},
+		{name: "Punctuation", query: "ivano frankivsk", expectedID: 707471},
+		{name: "AmbiguousPicksMostPopulated", query: "Paris", expectedID: 2988507},
+		{name: "CountryFilter", query: "Paris, us", expectedID: 4717560},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			loc, err := g.Resolve(tt.query)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, loc.ID)
+		})
+	}
+}
+
+func TestGazetteer_Resolve_CanonicalLocation(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	loc, err := g.Resolve("Киев")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Kyiv", loc.Name)
+	assert.Equal(t, "UA", loc.Country)
+	assert.Equal(t, "Europe/Kyiv", loc.Timezone)
+	assert.InDelta(t, 50.45, loc.Lat, 0.01)
+	assert.InDelta(t, 30.52, loc.Lon, 0.01)
+}
+
+func TestGazetteer_Resolve_CloseToKnownByName(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	loc, err := g.Resolve("Kyivv")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.Location{Name: "kyivv", ByName: true}, loc)
+}
+
+func TestGazetteer_Suggest(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	suggestions := g.Suggest("Kyivv")
+
+	// Assert
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "Kyiv, UA", suggestions[0])
+}
+
+func TestGazetteer_Resolve_UnknownWithoutSuggestionsByName(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	loc, err := g.Resolve(" Qwertyuiop, us")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.Location{Name: "qwertyuiop", Country: "US", ByName: true}, loc)
+	assert.Equal(t, "qwertyuiop,US", loc.Query())
+}
+
+func TestGazetteer_Resolve_EmptyName(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	_, err = g.Resolve("?!")
+
+	// Assert
+	var notFound *domain.LocationNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Empty(t, notFound.Suggestions)
+}
+
+func TestGazetteer_New_CustomData(t *testing.T) {
+	// Arrange
+	data := "# comment\n" +
+		"1\tTestville\tTestville\tTestburg\t10.5\t20.25\tP\tPPL\tZZ\t\t\t\t\t\t100\t\t\tEtc/UTC\t2024-01-01\n"
+
+	// Act
+	g, err := gazetteer.New(strings.NewReader(data))
+	require.NoError(t, err)
+	loc, err := g.Resolve("testburg")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.Location{ID: 1, Name: "Testville", Country: "ZZ", Lat: 10.5, Lon: 20.25, Timezone: "Etc/UTC"}, loc)
+}
+
+func TestGazetteer_New_MalformedData(t *testing.T) {
+	// Act
+	_, err := gazetteer.New(strings.NewReader("1\tTestville\n"))
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestGazetteer_Nearest(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	loc, ok := g.Nearest(50.40, 30.60)
+
+	// Assert
+	require.True(t, ok)
+	assert.Equal(t, int64(703448), loc.ID)
+}
+
+func TestGazetteer_Nearest_FarFromCities(t *testing.T) {
+	// Arrange
+	g, err := gazetteer.NewBundled()
+	require.NoError(t, err)
+
+	// Act
+	_, ok := g.Nearest(0, -30)
+
+	// Assert
+	assert.False(t, ok)
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// Status counts the calls that reached a provider, how many of them failed and why the last one did,
+// and keeps an EWMA of their latency. Unknown cities are calls but not failures, cancelled calls are neither.
+type Status struct {
+	alpha float64
+
+	mu      sync.Mutex
+	stats   domain.ProviderStats
+	sampled bool
+}
+
+// NewStatus creates an empty status, alpha is the weight of the newest latency sample.
+func NewStatus(alpha float64) *Status {
+	return &Status{alpha: alpha}
+}
+
+// Record adds a call that took latency and failed with err. Cancellation is read from ctx,
+// as providers report a cancelled call as unavailable.
+func (s *Status) Record(ctx context.Context, latency time.Duration, err error) {
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Calls++
+	if err != nil && !errors.Is(err, domain.ErrCityNotFound) {
+		s.stats.Failures++
+		s.stats.LastError = err.Error()
+		s.stats.LastErrorAt = time.Now()
+	}
+	if !s.sampled {
+		s.stats.Latency = latency
+		s.sampled = true
+		return
+	}
+	s.stats.Latency = time.Duration(s.alpha*float64(latency) + (1-s.alpha)*float64(s.stats.Latency))
+}
+
+func (s *Status) Stats() domain.ProviderStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
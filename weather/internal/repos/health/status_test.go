@@ -0,0 +1,36 @@
+//go:build unit
+
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	// Arrange
+	status := health.NewStatus(0.5)
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// Act
+	status.Record(ctx, 100*time.Millisecond, nil)
+	status.Record(ctx, 300*time.Millisecond, fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable))
+	status.Record(canceled, time.Second, fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable))
+	status.Record(ctx, 100*time.Millisecond, domain.ErrCityNotFound)
+	stats := status.Stats()
+
+	// Assert
+	assert.Equal(t, 3, stats.Calls, "cancelled calls are not counted")
+	assert.Equal(t, 1, stats.Failures, "unknown cities are not failures")
+	assert.Equal(t, "free weather repo: weather api is unavailable", stats.LastError)
+	assert.False(t, stats.LastErrorAt.IsZero())
+	assert.Equal(t, 150*time.Millisecond, stats.Latency)
+}
@@ -0,0 +1,157 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const (
+	// latency at which a provider with a perfect success rate scores 0.5
+	latencyScale = time.Second
+	// score multiplier while the breaker is testing recovery
+	halfOpenPenalty = 0.5
+	// scores closer than this are a tie, decided by the configured order
+	scoreStep = 0.05
+)
+
+type breaker interface {
+	State() cb.State
+}
+
+type rankMetrics interface {
+	ProviderRank(provider string, rank int, score float64)
+}
+
+// Provider is a provider known to the tracker, in the configured order.
+type Provider struct {
+	Name    string
+	Breaker breaker
+}
+
+// Score is the current standing of a provider.
+type Score struct {
+	Name        string
+	Score       float64
+	SuccessRate float64
+	Latency     time.Duration
+	Breaker     string
+
+	index int
+}
+
+type stats struct {
+	successRate float64
+	latency     float64
+	sampled     bool
+}
+
+// Tracker keeps a rolling score per provider from the success rate, an EWMA of latency
+// and the breaker state, and ranks providers by it. Providers are addressed by their index
+// in the configured order, which also breaks ties.
+type Tracker struct {
+	alpha     float64
+	providers []Provider
+	metrics   rankMetrics
+
+	mu    sync.Mutex
+	stats []stats
+}
+
+// NewTracker creates a tracker, alpha is the weight of the newest sample in the moving averages.
+func NewTracker(alpha float64, metrics rankMetrics, providers ...Provider) *Tracker {
+	st := make([]stats, len(providers))
+	for i := range st {
+		st[i].successRate = 1
+	}
+	return &Tracker{alpha: alpha, providers: providers, metrics: metrics, stats: st}
+}
+
+// Record adds the outcome of a call to provider i. Unknown cities are not the provider's fault,
+// rejections by an open breaker are already covered by the breaker state and neither an exhausted
+// quota nor missing alerts or air quality data say anything about the provider's health, so none of
+// them counts.
+// A call failed because ctx was cancelled only contributes its latency, it lost a hedge but did not fail.
+// Cancellation is read from ctx, as providers report a cancelled call as unavailable.
+func (t *Tracker) Record(ctx context.Context, i int, latency time.Duration, err error) {
+	if errors.Is(err, domain.ErrCityNotFound) || errors.Is(err, domain.ErrProviderUnreliable) ||
+		errors.Is(err, domain.ErrQuotaExceeded) || errors.Is(err, domain.ErrUnsupported) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &t.stats[i]
+	if err == nil || !errors.Is(ctx.Err(), context.Canceled) {
+		outcome := 0.0
+		if err == nil {
+			outcome = 1
+		}
+		s.successRate = t.ewma(s.successRate, outcome)
+	}
+	if !s.sampled {
+		s.latency = latency.Seconds()
+		s.sampled = true
+		return
+	}
+	s.latency = t.ewma(s.latency, latency.Seconds())
+}
+
+// Order returns provider indices from the best to the worst score.
+func (t *Tracker) Order() []int {
+	scores := t.Ranking()
+	order := make([]int, len(scores))
+	for rank, score := range scores {
+		order[rank] = score.index
+		t.metrics.ProviderRank(score.Name, rank+1, score.Score)
+	}
+	return order
+}
+
+// Ranking returns the scores of all providers from the best to the worst.
+func (t *Tracker) Ranking() []Score {
+	t.mu.Lock()
+	scores := make([]Score, len(t.providers))
+	for i, provider := range t.providers {
+		s := t.stats[i]
+		scores[i] = Score{
+			Name:        provider.Name,
+			SuccessRate: s.successRate,
+			Latency:     time.Duration(s.latency * float64(time.Second)),
+			index:       i,
+		}
+	}
+	t.mu.Unlock()
+
+	for i, provider := range t.providers {
+		state := provider.Breaker.State()
+		score := scores[i].SuccessRate / (1 + scores[i].Latency.Seconds()/latencyScale.Seconds())
+		switch state {
+		case cb.Open:
+			score = 0
+		case cb.HalfOpen:
+			score *= halfOpenPenalty
+		case cb.Closed:
+		}
+		scores[i].Score = score
+		scores[i].Breaker = state.String()
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return quantize(scores[i].Score) > quantize(scores[j].Score)
+	})
+	return scores
+}
+
+func (t *Tracker) ewma(avg, sample float64) float64 {
+	return t.alpha*sample + (1-t.alpha)*avg
+}
+
+func quantize(score float64) float64 {
+	return math.Round(score / scoreStep)
+}
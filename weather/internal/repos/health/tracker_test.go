@@ -0,0 +1,120 @@
+//go:build unit
+
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/health"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubBreaker struct {
+	state cb.State
+}
+
+func (b *stubBreaker) State() cb.State {
+	return b.state
+}
+
+type mockRankMetrics struct {
+	ranks map[string]int
+}
+
+func (m *mockRankMetrics) ProviderRank(provider string, rank int, score float64) {
+	m.ranks[provider] = rank
+}
+
+func newTracker(breakers ...*stubBreaker) (*health.Tracker, *mockRankMetrics) {
+	metrics := &mockRankMetrics{ranks: make(map[string]int)}
+	providers := make([]health.Provider, 0, len(breakers))
+	for i, breaker := range breakers {
+		providers = append(providers, health.Provider{Name: fmt.Sprintf("p%d", i), Breaker: breaker})
+	}
+	return health.NewTracker(0.5, metrics, providers...), metrics
+}
+
+func TestTracker_ConfiguredOrderBreaksTies(t *testing.T) {
+	// Arrange
+	tracker, metrics := newTracker(&stubBreaker{}, &stubBreaker{}, &stubBreaker{})
+
+	// Act
+	order := tracker.Order()
+
+	// Assert
+	assert.Equal(t, []int{0, 1, 2}, order)
+	assert.Equal(t, map[string]int{"p0": 1, "p1": 2, "p2": 3}, metrics.ranks)
+}
+
+func TestTracker_FailingProviderSinks(t *testing.T) {
+	// Arrange
+	tracker, _ := newTracker(&stubBreaker{}, &stubBreaker{})
+	ctx := context.Background()
+	for range 3 {
+		tracker.Record(ctx, 0, 10*time.Millisecond, domain.ErrWeatherUnavailable)
+		tracker.Record(ctx, 1, 10*time.Millisecond, nil)
+	}
+
+	// Act
+	order := tracker.Order()
+
+	// Assert
+	assert.Equal(t, []int{1, 0}, order)
+}
+
+func TestTracker_SlowProviderSinks(t *testing.T) {
+	// Arrange
+	tracker, _ := newTracker(&stubBreaker{}, &stubBreaker{})
+	ctx := context.Background()
+	tracker.Record(ctx, 0, 3*time.Second, nil)
+	tracker.Record(ctx, 1, 50*time.Millisecond, nil)
+
+	// Act
+	ranking := tracker.Ranking()
+
+	// Assert
+	assert.Equal(t, "p1", ranking[0].Name)
+	assert.Equal(t, "p0", ranking[1].Name)
+	assert.Equal(t, 3*time.Second, ranking[1].Latency)
+}
+
+func TestTracker_OpenBreakerSinks(t *testing.T) {
+	// Arrange
+	tracker, _ := newTracker(&stubBreaker{state: cb.Open}, &stubBreaker{})
+
+	// Act
+	ranking := tracker.Ranking()
+
+	// Assert
+	assert.Equal(t, "p1", ranking[0].Name)
+	assert.Equal(t, "open", ranking[1].Breaker)
+	assert.Zero(t, ranking[1].Score)
+}
+
+func TestTracker_IgnoresErrorsThatAreNotTheProvidersFault(t *testing.T) {
+	// Arrange
+	tracker, _ := newTracker(&stubBreaker{}, &stubBreaker{})
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	tracker.Record(ctx, 0, time.Millisecond, fmt.Errorf("api: %w", domain.ErrCityNotFound))
+	tracker.Record(ctx, 0, 0, domain.ErrProviderUnreliable)
+	tracker.Record(ctx, 0, 0, fmt.Errorf("open-meteo: %w", domain.ErrAlertsUnsupported))
+	tracker.Record(ctx, 0, 0, fmt.Errorf("tomorrow.io: %w", domain.ErrAirQualityUnsupported))
+	tracker.Record(canceled, 0, time.Millisecond, fmt.Errorf("api: %w", domain.ErrWeatherUnavailable))
+	tracker.Record(ctx, 1, time.Millisecond, errors.New("boom"))
+
+	// Act
+	ranking := tracker.Ranking()
+
+	// Assert
+	assert.Equal(t, "p0", ranking[0].Name)
+	assert.Equal(t, 1.0, ranking[0].SuccessRate)
+	assert.Equal(t, 0.5, ranking[1].SuccessRate)
+}
@@ -0,0 +1,239 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// observationColumns are the columns both observation tables share, in the order values are bound.
+// The metric columns come last, in the order of weatherMetrics.
+const observationColumns = `location_key, provider, observed_at, description, temperature, humidity, wind_speed,
+	wind_direction, pressure, precipitation, cloud_cover, visibility, uv_index`
+
+const observationColumnsCount = 13
+
+// weatherMetrics are the numeric fields of a reading, stored as NULL when the provider did not report them.
+var weatherMetrics = []struct {
+	field domain.WeatherFields
+	get   func(w *domain.Weather) *float64
+}{
+	{domain.FieldTemperature, func(w *domain.Weather) *float64 { return &w.Temperature }},
+	{domain.FieldHumidity, func(w *domain.Weather) *float64 { return &w.Humidity }},
+	{domain.FieldWindSpeed, func(w *domain.Weather) *float64 { return &w.WindSpeed }},
+	{domain.FieldWindDirection, func(w *domain.Weather) *float64 { return &w.WindDirection }},
+	{domain.FieldPressure, func(w *domain.Weather) *float64 { return &w.Pressure }},
+	{domain.FieldPrecipitation, func(w *domain.Weather) *float64 { return &w.Precipitation }},
+	{domain.FieldCloudCover, func(w *domain.Weather) *float64 { return &w.CloudCover }},
+	{domain.FieldVisibility, func(w *domain.Weather) *float64 { return &w.Visibility }},
+	{domain.FieldUVIndex, func(w *domain.Weather) *float64 { return &w.UVIndex }},
+}
+
+// DBRepo stores observations in Postgres. Raw readings go to weather_observations, the ones
+// downsampled after the raw retention to weather_observations_downsampled.
+type DBRepo struct {
+	db *sql.DB
+}
+
+func NewDBRepo(db *sql.DB) *DBRepo {
+	return &DBRepo{
+		db: db,
+	}
+}
+
+// Save stores the observations in one statement. A reading stored before, e.g. by another replica
+// that fetched the same value, is skipped.
+func (r *DBRepo) Save(ctx context.Context, observations []domain.Observation) error {
+	if len(observations) == 0 {
+		return nil
+	}
+	rows := make([]string, 0, len(observations))
+	args := make([]any, 0, len(observations)*observationColumnsCount)
+	for i, o := range observations {
+		placeholders := make([]string, observationColumnsCount)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*observationColumnsCount+j+1)
+		}
+		rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+		w := o.Weather
+		args = append(args, o.LocationKey, w.Source, w.ObservedAt, w.Description)
+		for _, m := range weatherMetrics {
+			args = append(args, sql.NullFloat64{Float64: *m.get(&w), Valid: !w.Missing.Has(m.field)})
+		}
+	}
+	query := "INSERT INTO weather_observations (" + observationColumns + ") VALUES " +
+		strings.Join(rows, ", ") + " ON CONFLICT DO NOTHING"
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		log.Printf("history repo: save: %v\n", err)
+		return fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	return nil
+}
+
+// Range returns the raw and downsampled observations of the location in [from, to), oldest first.
+// Metrics stored as NULL come back as Missing.
+func (r *DBRepo) Range(ctx context.Context, locationKey string, from, to time.Time) ([]domain.Observation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+observationColumns+`, 1 FROM weather_observations
+		WHERE location_key = $1 AND observed_at >= $2 AND observed_at < $3
+		UNION ALL
+		SELECT `+observationColumns+`, samples FROM weather_observations_downsampled
+		WHERE location_key = $1 AND observed_at >= $2 AND observed_at < $3
+		ORDER BY observed_at, provider
+		`,
+		locationKey, from, to,
+	)
+	if err != nil {
+		log.Printf("history repo: range: %v\n", err)
+		return nil, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("history repo: close rows: %v\n", err)
+		}
+	}()
+
+	var observations []domain.Observation
+	for rows.Next() {
+		var o domain.Observation
+		w := &o.Weather
+		metrics := make([]sql.NullFloat64, len(weatherMetrics))
+		dest := []any{&o.LocationKey, &w.Source, &w.ObservedAt, &w.Description}
+		for i := range metrics {
+			dest = append(dest, &metrics[i])
+		}
+		if err := rows.Scan(append(dest, &o.Samples)...); err != nil {
+			log.Printf("history repo: range: %v\n", err)
+			return nil, fmt.Errorf("history repo: %w", domain.ErrInternal)
+		}
+		for i, m := range weatherMetrics {
+			if !metrics[i].Valid {
+				w.Missing |= m.field
+				continue
+			}
+			*m.get(w) = metrics[i].Float64
+		}
+		w.ObservedAt = w.ObservedAt.UTC()
+		observations = append(observations, o)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("history repo: range: %v\n", err)
+		return nil, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	return observations, nil
+}
+
+// Downsample replaces the raw observations older than before with one per location, provider and
+// interval, and returns how many raw ones it replaced. Intervals are aligned to the unix epoch and
+// before is rounded down to a whole interval, so an interval is never split between two runs.
+// Readings saved late, for an interval downsampled before, are folded into its averages.
+// Metrics are averaged over the readings that have them, AVG skips NULLs.
+func (r *DBRepo) Downsample(ctx context.Context, before time.Time, interval time.Duration) (int64, error) {
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return 0, fmt.Errorf("history repo: downsampling interval %v is shorter than a second", interval)
+	}
+	before = time.Unix(before.Unix()/seconds*seconds, 0).UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("history repo: downsample: %v\n", err)
+		return 0, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("history repo: rollback: %v\n", err)
+		}
+	}()
+
+	// wind direction is averaged on the circle, so 350° and 10° give 0° rather than 180°.
+	// An interval downsampled before keeps its description and weighs both averages by their samples.
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO weather_observations_downsampled AS d (`+observationColumns+`, samples)
+		SELECT location_key, provider, to_timestamp(floor(extract(epoch FROM observed_at) / $2) * $2) AS bucket,
+			MODE() WITHIN GROUP (ORDER BY description), AVG(temperature), AVG(humidity), AVG(wind_speed),
+			MOD((DEGREES(ATAN2(AVG(SIN(RADIANS(wind_direction))), AVG(COS(RADIANS(wind_direction))))) + 360)::numeric, 360)::float8,
+			AVG(pressure), AVG(precipitation), AVG(cloud_cover), AVG(visibility), AVG(uv_index), COUNT(*)
+		FROM weather_observations
+		WHERE observed_at < $1
+		GROUP BY location_key, provider, bucket
+		ON CONFLICT (location_key, provider, observed_at) DO UPDATE SET
+			`+mergedAverage("temperature")+`,
+			`+mergedAverage("humidity")+`,
+			`+mergedAverage("wind_speed")+`,
+			wind_direction = CASE
+				WHEN d.wind_direction IS NULL THEN EXCLUDED.wind_direction
+				WHEN EXCLUDED.wind_direction IS NULL THEN d.wind_direction
+				ELSE MOD((DEGREES(ATAN2(
+					d.samples * SIN(RADIANS(d.wind_direction)) + EXCLUDED.samples * SIN(RADIANS(EXCLUDED.wind_direction)),
+					d.samples * COS(RADIANS(d.wind_direction)) + EXCLUDED.samples * COS(RADIANS(EXCLUDED.wind_direction))
+				)) + 360)::numeric, 360)::float8
+			END,
+			`+mergedAverage("pressure")+`,
+			`+mergedAverage("precipitation")+`,
+			`+mergedAverage("cloud_cover")+`,
+			`+mergedAverage("visibility")+`,
+			`+mergedAverage("uv_index")+`,
+			samples = d.samples + EXCLUDED.samples
+		`,
+		before, seconds,
+	)
+	if err != nil {
+		log.Printf("history repo: downsample: %v\n", err)
+		return 0, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	res, err := tx.ExecContext(ctx, "DELETE FROM weather_observations WHERE observed_at < $1", before)
+	if err != nil {
+		log.Printf("history repo: downsample: %v\n", err)
+		return 0, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("history repo: downsample: %v\n", err)
+		return 0, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	return rowsAffected(res)
+}
+
+// mergedAverage is the SET clause that folds the average of new readings into the stored one,
+// weighing each by its samples. A side without the metric leaves the other as is.
+func mergedAverage(column string) string {
+	return fmt.Sprintf(`%[1]s = CASE
+				WHEN d.%[1]s IS NULL THEN EXCLUDED.%[1]s
+				WHEN EXCLUDED.%[1]s IS NULL THEN d.%[1]s
+				ELSE (d.%[1]s * d.samples + EXCLUDED.%[1]s * EXCLUDED.samples) / (d.samples + EXCLUDED.samples)
+			END`, column)
+}
+
+// DeleteRaw drops the raw observations older than before and returns how many there were.
+func (r *DBRepo) DeleteRaw(ctx context.Context, before time.Time) (int64, error) {
+	return r.deleteBefore(ctx, "DELETE FROM weather_observations WHERE observed_at < $1", before)
+}
+
+// DeleteDownsampled drops the downsampled observations older than before and returns how many there were.
+func (r *DBRepo) DeleteDownsampled(ctx context.Context, before time.Time) (int64, error) {
+	return r.deleteBefore(ctx, "DELETE FROM weather_observations_downsampled WHERE observed_at < $1", before)
+}
+
+func (r *DBRepo) deleteBefore(ctx context.Context, query string, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		log.Printf("history repo: delete: %v\n", err)
+		return 0, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	return rowsAffected(res)
+}
+
+func rowsAffected(res sql.Result) (int64, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("history repo: rows affected: %v\n", err)
+		return 0, fmt.Errorf("history repo: %w", domain.ErrInternal)
+	}
+	return n, nil
+}
@@ -0,0 +1,172 @@
+//go:build unit
+
+package history_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var observedAt = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func closeDB(mock sqlmock.Sqlmock, db *sql.DB, t *testing.T) {
+	mock.ExpectClose()
+	if err := db.Close(); err != nil {
+		t.Log(err)
+	}
+}
+
+func TestSave_Success(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer closeDB(mock, db, t)
+	repo := history.NewDBRepo(db)
+	observations := []domain.Observation{
+		{LocationKey: "geo:703448", Weather: domain.Weather{Temperature: 21, Source: "weatherapi.com", ObservedAt: observedAt}},
+		{LocationKey: "geo:703448", Weather: domain.Weather{
+			Temperature: 22, Source: "tomorrow.io", ObservedAt: observedAt, Missing: domain.FieldUVIndex | domain.FieldVisibility,
+		}},
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13), `+
+		`($14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26) ON CONFLICT DO NOTHING`)).
+		WithArgs(
+			"geo:703448", "weatherapi.com", observedAt, "", 21.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
+			"geo:703448", "tomorrow.io", observedAt, "", 22.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, nil, nil,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	// Act
+	err = repo.Save(context.Background(), observations)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSave_DBError(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer closeDB(mock, db, t)
+	repo := history.NewDBRepo(db)
+	mock.ExpectExec("INSERT INTO weather_observations").WillReturnError(errors.New("connection refused"))
+
+	// Act
+	err = repo.Save(context.Background(), []domain.Observation{{LocationKey: "geo:703448"}})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrInternal)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRange_Success(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer closeDB(mock, db, t)
+	repo := history.NewDBRepo(db)
+	from, to := observedAt.Add(-time.Hour), observedAt.Add(time.Hour)
+	columns := []string{
+		"location_key", "provider", "observed_at", "description", "temperature", "humidity", "wind_speed",
+		"wind_direction", "pressure", "precipitation", "cloud_cover", "visibility", "uv_index", "samples",
+	}
+	mock.ExpectQuery("FROM weather_observations .* UNION ALL .* FROM weather_observations_downsampled").
+		WithArgs("geo:703448", from, to).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("geo:703448", "weatherapi.com", observedAt.Add(-30*time.Minute), "Clear", 20.0, 55.0, 3.0,
+				180.0, 1012.0, 0.0, 10.0, 10.0, 5.0, 12).
+			AddRow("geo:703448", "weatherapi.com", observedAt, "Sunny", 21.0, 50.0, 3.5,
+				190.0, 1013.0, 0.0, 5.0, 10.0, nil, 1))
+
+	// Act
+	observations, err := repo.Range(context.Background(), "geo:703448", from, to)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, observations, 2)
+	assert.Equal(t, 12, observations[0].Samples)
+	assert.Equal(t, domain.Observation{
+		LocationKey: "geo:703448",
+		Weather: domain.Weather{
+			Temperature: 21, Humidity: 50, Description: "Sunny", WindSpeed: 3.5, WindDirection: 190,
+			Pressure: 1013, CloudCover: 5, Visibility: 10, ObservedAt: observedAt, Source: "weatherapi.com",
+			Missing: domain.FieldUVIndex,
+		},
+		Samples: 1,
+	}, observations[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDownsample_Success(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer closeDB(mock, db, t)
+	repo := history.NewDBRepo(db)
+	alignedBefore := observedAt
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO weather_observations_downsampled .* ON CONFLICT .* DO UPDATE SET").
+		WithArgs(alignedBefore, int64(3600)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM weather_observations WHERE observed_at < $1")).
+		WithArgs(alignedBefore).
+		WillReturnResult(sqlmock.NewResult(0, 40))
+	mock.ExpectCommit()
+
+	// Act
+	n, err := repo.Downsample(context.Background(), observedAt.Add(25*time.Minute), time.Hour)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDownsample_RollsBackOnError(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer closeDB(mock, db, t)
+	repo := history.NewDBRepo(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO weather_observations_downsampled").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM weather_observations").WillReturnError(errors.New("deadlock detected"))
+	mock.ExpectRollback()
+
+	// Act
+	_, err = repo.Downsample(context.Background(), observedAt, time.Hour)
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrInternal)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDownsampled_Success(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer closeDB(mock, db, t)
+	repo := history.NewDBRepo(db)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM weather_observations_downsampled WHERE observed_at < $1")).
+		WithArgs(observedAt).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	// Act
+	n, err := repo.DeleteDownsampled(context.Background(), observedAt)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,16 +7,25 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 )
 
-const noMatchingLocationFoundCode = 1006
+const (
+	FreeWeatherName = "weatherapi.com"
+
+	noMatchingLocationFoundCode = 1006
+	kphToMps                    = 1 / 3.6
+	mToKm                       = 1.0 / 1000
+)
 
 type APICfg struct {
 	APIKey string
 	APIURL string
 }
+
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -33,16 +42,73 @@ func NewFreeWeatherAPI(cfg APICfg, client HTTPClient) *FreeWeatherAPI {
 	}
 }
 
+type freeWeatherAPICondition struct {
+	Text string `json:"text"`
+}
+
 type freeWeatherAPIResponse struct {
 	Current struct {
-		TempC     float64 `json:"temp_c"`
-		Humidity  float64 `json:"humidity"`
-		Condition struct {
-			Text string `json:"text"`
-		} `json:"condition"`
+		LastUpdatedEpoch int64                   `json:"last_updated_epoch"`
+		TempC            float64                 `json:"temp_c"`
+		Humidity         float64                 `json:"humidity"`
+		Condition        freeWeatherAPICondition `json:"condition"`
+		WindKph          float64                 `json:"wind_kph"`
+		WindDegree       float64                 `json:"wind_degree"`
+		PressureMb       float64                 `json:"pressure_mb"`
+		PrecipMm         float64                 `json:"precip_mm"`
+		Cloud            float64                 `json:"cloud"`
+		VisKm            float64                 `json:"vis_km"`
+		UV               float64                 `json:"uv"`
 	} `json:"current"`
 }
 
+type freeWeatherAPIAirQualityResponse struct {
+	Current struct {
+		LastUpdatedEpoch int64 `json:"last_updated_epoch"`
+		AirQuality       struct {
+			PM25 *float64 `json:"pm2_5"`
+			PM10 *float64 `json:"pm10"`
+			O3   *float64 `json:"o3"`
+			NO2  *float64 `json:"no2"`
+		} `json:"air_quality"`
+		// grains/m³ by plant, only on plans with pollen data
+		Pollen map[string]float64 `json:"pollen"`
+	} `json:"current"`
+}
+
+type freeWeatherAPIForecastResponse struct {
+	Forecast struct {
+		ForecastDay []struct {
+			DateEpoch int64 `json:"date_epoch"`
+			Day       struct {
+				MaxTempC    float64                 `json:"maxtemp_c"`
+				MinTempC    float64                 `json:"mintemp_c"`
+				AvgHumidity float64                 `json:"avghumidity"`
+				Condition   freeWeatherAPICondition `json:"condition"`
+			} `json:"day"`
+			Hour []struct {
+				TimeEpoch int64                   `json:"time_epoch"`
+				TempC     float64                 `json:"temp_c"`
+				Humidity  float64                 `json:"humidity"`
+				Condition freeWeatherAPICondition `json:"condition"`
+			} `json:"hour"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+type freeWeatherAPIAlertsResponse struct {
+	Alerts struct {
+		Alert []struct {
+			Headline  string `json:"headline"`
+			Severity  string `json:"severity"`
+			Areas     string `json:"areas"`
+			Event     string `json:"event"`
+			Effective string `json:"effective"`
+			Expires   string `json:"expires"`
+		} `json:"alert"`
+	} `json:"alerts"`
+}
+
 type freeWeatherAPIErrorResponse struct {
 	Error struct {
 		Code    int    `json:"code"`
@@ -50,21 +116,143 @@ type freeWeatherAPIErrorResponse struct {
 	} `json:"error"`
 }
 
-func (r *FreeWeatherAPI) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (r *FreeWeatherAPI) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	var responseData freeWeatherAPIResponse
+	if err := r.fetch(ctx, "current.json", loc, url.Values{}, &responseData); err != nil {
+		return domain.Weather{}, err
+	}
+
+	current := responseData.Current
+	return domain.Weather{
+		Temperature:   current.TempC,
+		Humidity:      current.Humidity,
+		Description:   current.Condition.Text,
+		WindSpeed:     current.WindKph * kphToMps,
+		WindDirection: current.WindDegree,
+		Pressure:      current.PressureMb,
+		Precipitation: current.PrecipMm,
+		CloudCover:    current.Cloud,
+		Visibility:    current.VisKm,
+		UVIndex:       current.UV,
+		ObservedAt:    time.Unix(current.LastUpdatedEpoch, 0).UTC(),
+		Source:        FreeWeatherName,
+	}, nil
+}
+
+func (r *FreeWeatherAPI) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	var responseData freeWeatherAPIForecastResponse
+	params := url.Values{}
+	params.Set("days", fmt.Sprint(days))
+	if err := r.fetch(ctx, "forecast.json", loc, params, &responseData); err != nil {
+		return domain.Forecast{}, err
+	}
+
+	var forecast domain.Forecast
+	for _, day := range responseData.Forecast.ForecastDay {
+		forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+			Date:           time.Unix(day.DateEpoch, 0).UTC(),
+			MinTemperature: day.Day.MinTempC,
+			MaxTemperature: day.Day.MaxTempC,
+			Humidity:       day.Day.AvgHumidity,
+			Description:    day.Day.Condition.Text,
+		})
+		for _, hour := range day.Hour {
+			forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+				Time:        time.Unix(hour.TimeEpoch, 0).UTC(),
+				Temperature: hour.TempC,
+				Humidity:    hour.Humidity,
+				Description: hour.Condition.Text,
+			})
+		}
+	}
+	return forecast, nil
+}
+
+func (r *FreeWeatherAPI) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	var responseData freeWeatherAPIAlertsResponse
+	if err := r.fetch(ctx, "alerts.json", loc, url.Values{}, &responseData); err != nil {
+		return domain.Alerts{}, err
+	}
+
+	alerts := domain.Alerts{Source: FreeWeatherName}
+	for _, alert := range responseData.Alerts.Alert {
+		alerts.Alerts = append(alerts.Alerts, domain.Alert{
+			Event:     alert.Event,
+			Headline:  alert.Headline,
+			Severity:  domain.ParseAlertSeverity(alert.Severity),
+			Effective: parseAlertTime(alert.Effective),
+			Expires:   parseAlertTime(alert.Expires),
+			Areas:     splitAlertAreas(alert.Areas),
+		})
+	}
+	return alerts, nil
+}
+
+func (r *FreeWeatherAPI) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	var responseData freeWeatherAPIAirQualityResponse
+	params := url.Values{}
+	params.Set("aqi", "yes")
+	params.Set("pollen", "yes")
+	if err := r.fetch(ctx, "current.json", loc, params, &responseData); err != nil {
+		return domain.AirQuality{}, err
+	}
+
+	current := responseData.Current
+	air := current.AirQuality
+	airQuality := domain.NewAirQuality(air.PM25, air.PM10, air.O3, air.NO2)
+	airQuality.ObservedAt = time.Unix(current.LastUpdatedEpoch, 0).UTC()
+	airQuality.Source = FreeWeatherName
+	if len(current.Pollen) > 0 {
+		airQuality.Pollen = make(map[string]float64, len(current.Pollen))
+		for plant, count := range current.Pollen {
+			airQuality.Pollen[strings.ToLower(plant)] = count
+		}
+	}
+	return airQuality, nil
+}
+
+// parseAlertTime parses an RFC 3339 alert time, a missing or malformed one is left zero.
+func parseAlertTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Printf("free weather repo: malformed alert time %q: %v\n", value, err)
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
+// splitAlertAreas splits the "; " separated list of areas an alert covers.
+func splitAlertAreas(value string) []string {
+	var areas []string
+	for _, area := range strings.Split(value, ";") {
+		if area = strings.TrimSpace(area); area != "" {
+			areas = append(areas, area)
+		}
+	}
+	return areas
+}
+
+// fetch sends a GET request to the given endpoint and decodes a successful response into dest,
+// mapping api errors to domain errors.
+func (r *FreeWeatherAPI) fetch(ctx context.Context, endpoint string, loc domain.Location, params url.Values, dest any) error {
 	// step 1: format request
-	q := url.QueryEscape(city)
-	url := fmt.Sprintf("%s/current.json?key=%s&q=%s", r.cfg.APIURL, r.cfg.APIKey, q)
+	params.Set("key", r.cfg.APIKey)
+	params.Set("q", loc.Query())
+	url := fmt.Sprintf("%s/%s?%s", r.cfg.APIURL, endpoint, params.Encode())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		log.Printf("free weather repo: failed to format request for %s, err:%v\n", city, err)
-		return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrInternal)
+		log.Printf("free weather repo: failed to format request for %s, err:%v\n", loc, err)
+		return fmt.Errorf("free weather repo: %w", domain.ErrInternal)
 	}
 
 	// step 2: send request
 	resp, err := r.client.Do(req)
 	if err != nil {
-		log.Printf("free weather repo: failed to get weather for %s, err:%v\n", city, err)
-		return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable)
+		log.Printf("free weather repo: failed to get weather for %s, err:%v\n", loc, err)
+		return fmt.Errorf("free weather repo: %w: %w", domain.ErrWeatherUnavailable, err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -73,34 +261,36 @@ func (r *FreeWeatherAPI) GetCurrent(ctx context.Context, city string) (domain.We
 	}()
 
 	// step 3: handle response
-	if resp.StatusCode == http.StatusForbidden {
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
 		log.Println("free weather repo: api key is invalid")
-		return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable)
+		return fmt.Errorf("free weather repo: %w", domain.ErrProviderAuth)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Println("free weather repo: rate limit reached")
+		return fmt.Errorf("free weather repo: %w", domain.ErrQuotaExceeded)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		log.Printf("free weather repo: server error %d\n", resp.StatusCode)
+		return fmt.Errorf("free weather repo: %w", domain.ErrWeatherUnavailable)
 	}
 	if resp.StatusCode != http.StatusOK {
 		var errResp freeWeatherAPIErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
 			if errResp.Error.Code == noMatchingLocationFoundCode {
-				log.Printf("free weather repo: city %s not found\n", city)
-				return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrCityNotFound)
+				log.Printf("free weather repo: location %s not found\n", loc)
+				return fmt.Errorf("free weather repo: %w", domain.ErrCityNotFound)
 			}
 			log.Printf("free weather repo: api error: %s\n", errResp.Error.Message)
-			return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrInternal)
+			return fmt.Errorf("free weather repo: %w", domain.ErrInternal)
 		}
 		log.Printf("free weather repo: unexpected error %d\n", resp.StatusCode)
-		return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrInternal)
+		return fmt.Errorf("free weather repo: %w", domain.ErrInternal)
 	}
 
 	// step 4: parse response body
-	var responseData freeWeatherAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
 		log.Printf("free weather repo: failed to decode weather data: %v\n", err)
-		return domain.Weather{}, fmt.Errorf("free weather repo: %w", domain.ErrInternal)
+		return fmt.Errorf("free weather repo: %w", domain.ErrInternal)
 	}
-
-	return domain.Weather{
-		Temperature: responseData.Current.TempC,
-		Humidity:    responseData.Current.Humidity,
-		Description: responseData.Current.Condition.Text,
-	}, nil
+	return nil
 }
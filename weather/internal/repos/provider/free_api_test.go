@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
@@ -15,6 +16,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var (
+	kyiv            = domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+	unknownLocation = domain.Location{ID: 1, Name: "Nowhere", Country: "ZZ"}
+)
+
 type mockHTTPClient struct {
 	doFunc func(req *http.Request) (*http.Response, error)
 }
@@ -27,15 +33,24 @@ func TestFreeApiGetCurrentWeather_Success(t *testing.T) {
 	// Arrange
 	mockRespBody := `{
 		"current": {
+			"last_updated_epoch": 1748779200,
 			"temp_c": 10000.0,
 			"humidity": 100.0,
 			"condition": {
 				"text": "H_E_L_L"
-			}
+			},
+			"wind_kph": 36.0,
+			"wind_degree": 270,
+			"pressure_mb": 1012.5,
+			"precip_mm": 0.4,
+			"cloud": 75,
+			"vis_km": 10.0,
+			"uv": 3.0
 		}
 	}`
 	client := &mockHTTPClient{
 		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "50.4547,30.5238", req.URL.Query().Get("q"))
 			return &http.Response{
 				StatusCode: http.StatusOK,
 				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
@@ -46,13 +61,46 @@ func TestFreeApiGetCurrentWeather_Success(t *testing.T) {
 	repo := provider.NewFreeWeatherAPI(cfg, client)
 
 	// Act
-	weather, err := repo.GetCurrent(context.Background(), "Kyiv")
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, 10000.0, weather.Temperature)
 	assert.Equal(t, 100.0, weather.Humidity)
 	assert.Equal(t, "H_E_L_L", weather.Description)
+	assert.InDelta(t, 10.0, weather.WindSpeed, 1e-9)
+	assert.Equal(t, 270.0, weather.WindDirection)
+	assert.Equal(t, 1012.5, weather.Pressure)
+	assert.Equal(t, 0.4, weather.Precipitation)
+	assert.Equal(t, 75.0, weather.CloudCover)
+	assert.Equal(t, 10.0, weather.Visibility)
+	assert.Equal(t, 3.0, weather.UVIndex)
+	assert.Equal(t, time.Unix(1748779200, 0).UTC(), weather.ObservedAt)
+	assert.Equal(t, provider.FreeWeatherName, weather.Source)
+}
+
+func TestFreeApiGetCurrentWeather_ByName(t *testing.T) {
+	// Arrange
+	var query string
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query().Get("q")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"current": {"temp_c": 12.0}}`)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewFreeWeatherAPI(cfg, client)
+
+	// Act
+	weather, err := repo.GetCurrent(context.Background(), domain.Location{Name: "boston", Country: "US", ByName: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "boston,US", query)
+	assert.Equal(t, 12.0, weather.Temperature)
 }
 
 func TestFreeApiGetCurrentWeather_CityNotFound(t *testing.T) {
@@ -75,7 +123,7 @@ func TestFreeApiGetCurrentWeather_CityNotFound(t *testing.T) {
 	repo := provider.NewFreeWeatherAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "InvalidCity")
+	_, err := repo.GetCurrent(context.Background(), unknownLocation)
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrCityNotFound)
@@ -95,11 +143,11 @@ func TestFreeApiGetCurrentWeather_APIKeyInvalid(t *testing.T) {
 	repo := provider.NewFreeWeatherAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
-	assert.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.ErrorIs(t, err, domain.ErrProviderAuth)
 }
 
 func TestFreeApiGetCurrentWeather_HTTPError(t *testing.T) {
@@ -113,7 +161,48 @@ func TestFreeApiGetCurrentWeather_HTTPError(t *testing.T) {
 	repo := provider.NewFreeWeatherAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+}
+
+func TestFreeApiGetCurrentWeather_Cancelled(t *testing.T) {
+	// Arrange
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, req.Context().Err()
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewFreeWeatherAPI(cfg, client)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := repo.GetCurrent(ctx, kyiv)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFreeApiGetCurrentWeather_ServerError(t *testing.T) {
+	// Arrange
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(bytes.NewBufferString(`<html>Bad Gateway</html>`)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewFreeWeatherAPI(cfg, client)
+
+	// Act
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
@@ -134,9 +223,151 @@ func TestFreeApiGetCurrentWeather_BadJSON(t *testing.T) {
 	repo := provider.NewFreeWeatherAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
 	assert.ErrorIs(t, err, domain.ErrInternal)
 }
+
+func TestFreeApiGetForecast_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"forecast": {
+			"forecastday": [{
+				"date_epoch": 1748736000,
+				"day": {
+					"maxtemp_c": 25.0,
+					"mintemp_c": 12.0,
+					"avghumidity": 55.0,
+					"condition": {"text": "Sunny"}
+				},
+				"hour": [
+					{"time_epoch": 1748736000, "temp_c": 13.0, "humidity": 70.0, "condition": {"text": "Clear"}},
+					{"time_epoch": 1748739600, "temp_c": 12.5, "humidity": 72.0, "condition": {"text": "Clear"}}
+				]
+			}]
+		}
+	}`
+	var requestedURL string
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requestedURL = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewFreeWeatherAPI(cfg, client)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, requestedURL, "/forecast.json")
+	assert.Contains(t, requestedURL, "days=1")
+	require.Len(t, forecast.Daily, 1)
+	require.Len(t, forecast.Hourly, 2)
+	assert.Equal(t, 25.0, forecast.Daily[0].MaxTemperature)
+	assert.Equal(t, 12.0, forecast.Daily[0].MinTemperature)
+	assert.Equal(t, "Sunny", forecast.Daily[0].Description)
+	assert.Equal(t, int64(1748739600), forecast.Hourly[1].Time.Unix())
+	assert.Equal(t, 12.5, forecast.Hourly[1].Temperature)
+}
+
+func TestFreeApiGetAlerts_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"alerts": {
+			"alert": [{
+				"headline": "Flood Warning issued June 1 at 9:00AM EEST until June 2 at 9:00PM EEST",
+				"severity": "Severe",
+				"areas": "Kyiv; Kyiv Oblast",
+				"event": "Flood Warning",
+				"effective": "2025-06-01T09:00:00+03:00",
+				"expires": "2025-06-02T21:00:00+03:00"
+			}, {
+				"headline": "Heat advisory",
+				"severity": "",
+				"areas": "",
+				"event": "Heat Advisory",
+				"effective": "2025-06-01T12:00:00+03:00",
+				"expires": ""
+			}]
+		}
+	}`
+	var requestedPath string
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requestedPath = req.URL.Path
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewFreeWeatherAPI(cfg, client)
+
+	// Act
+	alerts, err := repo.GetAlerts(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "/alerts.json", requestedPath)
+	assert.Equal(t, provider.FreeWeatherName, alerts.Source)
+	require.Len(t, alerts.Alerts, 2)
+	assert.Equal(t, domain.Alert{
+		Event:     "Flood Warning",
+		Headline:  "Flood Warning issued June 1 at 9:00AM EEST until June 2 at 9:00PM EEST",
+		Severity:  domain.AlertSeveritySevere,
+		Effective: time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC),
+		Expires:   time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC),
+		Areas:     []string{"Kyiv", "Kyiv Oblast"},
+	}, alerts.Alerts[0])
+	assert.Equal(t, domain.AlertSeverityUnknown, alerts.Alerts[1].Severity)
+	assert.True(t, alerts.Alerts[1].Expires.IsZero())
+	assert.Empty(t, alerts.Alerts[1].Areas)
+}
+
+func TestFreeApiGetAirQuality_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"current": {
+			"last_updated_epoch": 1748779200,
+			"air_quality": {"co": 230.3, "no2": 15.0, "o3": 60.0, "so2": 1.2, "pm2_5": 12.3, "pm10": 20.1, "us-epa-index": 1},
+			"pollen": {"Birch": 25.5, "Grass": 3.0}
+		}
+	}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/current.json", req.URL.Path)
+			assert.Equal(t, "yes", req.URL.Query().Get("aqi"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewFreeWeatherAPI(cfg, client)
+
+	// Act
+	airQuality, err := repo.GetAirQuality(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.AirQuality{
+		AQI:        57,
+		PM25:       12.3,
+		PM10:       20.1,
+		O3:         60,
+		NO2:        15,
+		Pollen:     map[string]float64{"birch": 25.5, "grass": 3},
+		ObservedAt: time.Unix(1748779200, 0).UTC(),
+		Source:     provider.FreeWeatherName,
+	}, airQuality)
+}
@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const (
+	// JSONKind is the kind of providers read with JSONAPI instead of an adapter of their own.
+	JSONKind = "json"
+
+	jsonAuthQuery  = "query"
+	jsonAuthHeader = "header"
+	// answers bigger than this are not read
+	maxJSONBody = 10 << 20
+)
+
+// jsonErrorClasses are the errors a status code can be mapped to.
+var jsonErrorClasses = map[string]error{
+	"not_found":      domain.ErrCityNotFound,
+	"quota_exceeded": domain.ErrQuotaExceeded,
+	"unavailable":    domain.ErrWeatherUnavailable,
+	"internal":       domain.ErrInternal,
+}
+
+// jsonPlaceholder matches the {name} placeholders of a path template.
+var jsonPlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// JSONSpec declares how to call an api and read its answers, so an api can be used without writing an adapter.
+type JSONSpec struct {
+	// Name is reported as the source of the weather and used in logs.
+	Name     string
+	Current  JSONEndpoint
+	Forecast JSONForecastEndpoint
+	Auth     JSONAuth
+	NotFound JSONNotFound
+	// Errors maps status codes to an error class: not_found, quota_exceeded, unavailable or internal.
+	// Unmapped 401, 403 and 5xx are unavailable, 429 is quota_exceeded and anything else is internal.
+	Errors map[int]string
+}
+
+// JSONEndpoint is the current weather endpoint. Path is appended to the api url, {lat}, {lon} and {key} in it
+// are replaced with the coordinates and the api key.
+type JSONEndpoint struct {
+	Path   string
+	Fields JSONFields
+}
+
+// JSONForecastEndpoint is the forecast endpoint. Its Path may also use {days}.
+type JSONForecastEndpoint struct {
+	Path   string
+	Daily  JSONList
+	Hourly JSONList
+}
+
+// JSONList is an array in the forecast answer, each item of it is read with Fields. Hourly is optional.
+type JSONList struct {
+	Path   string
+	Fields JSONFields
+}
+
+// JSONFields maps field names to dotted JSON paths, e.g. "data.0.temp", and numbers to factors they are
+// multiplied by, e.g. 0.27778 for a wind speed in km/h. Numbers may be strings, times may be unix seconds,
+// RFC 3339 or dates.
+type JSONFields struct {
+	Paths map[string]string
+	Scale map[string]float64
+}
+
+// JSONAuth places the api key in a query parameter or header, In is "query", "header" or empty.
+type JSONAuth struct {
+	In     string
+	Name   string
+	Prefix string
+}
+
+// JSONNotFound recognises answers for unknown locations: their status is one of Status, or any
+// status when Status is empty, and the value at Path equals Equals, or exists when Equals is empty.
+type JSONNotFound struct {
+	Status []int
+	Path   string
+	Equals string
+}
+
+// JSONAPI asks an api described by a JSONSpec.
+type JSONAPI struct {
+	cfg      APICfg
+	client   HTTPClient
+	name     string
+	current  string
+	forecast string
+	auth     JSONAuth
+	notFound jsonNotFoundRule
+	errors   map[int]error
+
+	currentFields jsonMapping[domain.Weather]
+	dailyList     jsonPath
+	dailyFields   jsonMapping[domain.DailyForecast]
+	hourlyList    jsonPath
+	hourlyFields  jsonMapping[domain.HourlyForecast]
+}
+
+type jsonNotFoundRule struct {
+	enabled bool
+	status  []int
+	path    jsonPath
+	equals  string
+}
+
+// NewJSONAPI checks spec and returns an api reading answers with it. The error lists every problem of spec.
+func NewJSONAPI(cfg APICfg, spec JSONSpec, client HTTPClient) (*JSONAPI, error) {
+	api := &JSONAPI{
+		cfg:      cfg,
+		client:   client,
+		name:     spec.Name,
+		current:  spec.Current.Path,
+		forecast: spec.Forecast.Path,
+		auth:     spec.Auth,
+		errors:   make(map[int]error, len(spec.Errors)),
+	}
+	var errs []error
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	collect(checkTemplate("current.path", spec.Current.Path, "lat", "lon", "key"))
+	collect(checkTemplate("forecast.path", spec.Forecast.Path, "lat", "lon", "key", "days"))
+	var err error
+	api.currentFields, err = compileMapping("current", spec.Current.Fields, jsonCurrentFields, "temperature")
+	collect(err)
+	api.dailyList, err = parseJSONPath(spec.Forecast.Daily.Path)
+	collect(wrapSpecErr("forecast.daily.path", err))
+	api.dailyFields, err = compileMapping("forecast.daily", spec.Forecast.Daily.Fields, jsonDailyFields, "date")
+	collect(err)
+	if spec.Forecast.Hourly.Path != "" || len(spec.Forecast.Hourly.Fields.Paths) > 0 {
+		api.hourlyList, err = parseJSONPath(spec.Forecast.Hourly.Path)
+		collect(wrapSpecErr("forecast.hourly.path", err))
+		api.hourlyFields, err = compileMapping("forecast.hourly", spec.Forecast.Hourly.Fields, jsonHourlyFields, "time")
+		collect(err)
+	}
+
+	collect(api.compileAuth(spec))
+	api.notFound, err = compileNotFound(spec.NotFound)
+	collect(err)
+	for _, status := range slices.Sorted(maps.Keys(spec.Errors)) {
+		class, ok := jsonErrorClasses[spec.Errors[status]]
+		switch {
+		case status < http.StatusContinue || status > http.StatusNetworkAuthenticationRequired:
+			errs = append(errs, fmt.Errorf("errors.%d: not an HTTP status", status))
+		case !ok:
+			errs = append(errs, fmt.Errorf("errors.%d: unknown class %q, expected one of %s",
+				status, spec.Errors[status], strings.Join(slices.Sorted(maps.Keys(jsonErrorClasses)), ", ")))
+		default:
+			api.errors[status] = class
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid json spec:\n%w", err)
+	}
+	return api, nil
+}
+
+func (r *JSONAPI) compileAuth(spec JSONSpec) error {
+	usesKey := strings.Contains(spec.Current.Path, "{key}") || strings.Contains(spec.Forecast.Path, "{key}")
+	switch spec.Auth.In {
+	case "":
+		if r.cfg.APIKey != "" && !usesKey {
+			return errors.New("auth: a key is set, but neither auth nor a {key} placeholder uses it")
+		}
+	case jsonAuthQuery, jsonAuthHeader:
+		if spec.Auth.Name == "" {
+			return errors.New("auth.name: required")
+		}
+		if r.cfg.APIKey == "" {
+			return errors.New("auth: no key is set")
+		}
+	default:
+		return fmt.Errorf("auth.in: unknown placement %q, expected query or header", spec.Auth.In)
+	}
+	if usesKey && r.cfg.APIKey == "" {
+		return errors.New("path: {key} is used, but no key is set")
+	}
+	return nil
+}
+
+func compileNotFound(spec JSONNotFound) (jsonNotFoundRule, error) {
+	if len(spec.Status) == 0 && spec.Path == "" {
+		if spec.Equals != "" {
+			return jsonNotFoundRule{}, errors.New("not_found.equals: needs not_found.path")
+		}
+		return jsonNotFoundRule{}, nil
+	}
+	path, err := parseJSONPath(spec.Path)
+	if err != nil {
+		return jsonNotFoundRule{}, wrapSpecErr("not_found.path", err)
+	}
+	return jsonNotFoundRule{enabled: true, status: spec.Status, path: path, equals: spec.Equals}, nil
+}
+
+// checkTemplate checks that a path template only uses the allowed placeholders.
+func checkTemplate(where, template string, allowed ...string) error {
+	if template == "" {
+		return fmt.Errorf("%s: required", where)
+	}
+	var errs []error
+	for _, match := range jsonPlaceholder.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(allowed, match[1]) {
+			errs = append(errs, fmt.Errorf("%s: unknown placeholder {%s}, expected one of {%s}",
+				where, match[1], strings.Join(allowed, "}, {")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func wrapSpecErr(where string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", where, err)
+}
+
+func (r *JSONAPI) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	doc, err := r.fetch(ctx, r.current, loc, 0)
+	if err != nil {
+		return domain.Weather{}, err
+	}
+
+	// fields are taken off Missing as the answer turns out to have them
+	weather := domain.Weather{Missing: domain.AllWeatherFields}
+	if err := r.currentFields.apply(&weather, doc); err != nil {
+		log.Printf("%s repo: unexpected answer for %s: %v\n", r.name, loc, err)
+		return domain.Weather{}, fmt.Errorf("%s repo: %w", r.name, domain.ErrInternal)
+	}
+	weather.Source = r.name
+	return weather, nil
+}
+
+func (r *JSONAPI) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	doc, err := r.fetch(ctx, r.forecast, loc, days)
+	if err != nil {
+		return domain.Forecast{}, err
+	}
+
+	var forecast domain.Forecast
+	forecast.Daily, err = readJSONList(doc, r.dailyList, r.dailyFields)
+	if err == nil && r.hourlyFields != nil {
+		forecast.Hourly, err = readJSONList(doc, r.hourlyList, r.hourlyFields)
+	}
+	if err != nil {
+		log.Printf("%s repo: unexpected answer for %s: %v\n", r.name, loc, err)
+		return domain.Forecast{}, fmt.Errorf("%s repo: %w", r.name, domain.ErrInternal)
+	}
+	if len(forecast.Daily) > days {
+		forecast.Daily = forecast.Daily[:days]
+	}
+	return forecast, nil
+}
+
+// GetAlerts fails with domain.ErrAlertsUnsupported, a JSONSpec declares no alerts endpoint.
+func (r *JSONAPI) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return domain.Alerts{}, fmt.Errorf("%s repo: %w", r.name, domain.ErrAlertsUnsupported)
+}
+
+// GetAirQuality fails with domain.ErrAirQualityUnsupported, a JSONSpec declares no air quality endpoint.
+func (r *JSONAPI) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return domain.AirQuality{}, fmt.Errorf("%s repo: %w", r.name, domain.ErrAirQualityUnsupported)
+}
+
+func readJSONList[T any](doc any, path jsonPath, mapping jsonMapping[T]) ([]T, error) {
+	value, _ := path.lookup(doc)
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("no list at %q", strings.Join(path, "."))
+	}
+	list := make([]T, len(items))
+	for i, item := range items {
+		if err := mapping.apply(&list[i], item); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return list, nil
+}
+
+// fetch sends a GET request to the endpoint of the given template and returns the decoded answer,
+// mapping api errors to domain errors.
+func (r *JSONAPI) fetch(ctx context.Context, template string, loc domain.Location, days int) (any, error) {
+	// step 1: format request
+	if loc.ByName {
+		log.Printf("%s repo: %s has no coordinates\n", r.name, loc)
+		return nil, fmt.Errorf("%s repo: %w", r.name, domain.ErrCityNotFound)
+	}
+	replacer := strings.NewReplacer(
+		"{lat}", strconv.FormatFloat(loc.Lat, 'f', 4, 64),
+		"{lon}", strconv.FormatFloat(loc.Lon, 'f', 4, 64),
+		"{days}", strconv.Itoa(days),
+		"{key}", url.QueryEscape(r.cfg.APIKey),
+	)
+	reqURL, err := url.Parse(r.cfg.APIURL + replacer.Replace(template))
+	if err != nil {
+		log.Printf("%s repo: failed to format request for %s, err:%v\n", r.name, loc, err)
+		return nil, fmt.Errorf("%s repo: %w", r.name, domain.ErrInternal)
+	}
+	if r.auth.In == jsonAuthQuery {
+		query := reqURL.Query()
+		query.Set(r.auth.Name, r.auth.Prefix+r.cfg.APIKey)
+		reqURL.RawQuery = query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL.String(), nil)
+	if err != nil {
+		log.Printf("%s repo: failed to format request for %s, err:%v\n", r.name, loc, err)
+		return nil, fmt.Errorf("%s repo: %w", r.name, domain.ErrInternal)
+	}
+	if r.auth.In == jsonAuthHeader {
+		req.Header.Set(r.auth.Name, r.auth.Prefix+r.cfg.APIKey)
+	}
+
+	// step 2: send request
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Printf("%s repo: failed to get weather for %s, err:%v\n", r.name, loc, err)
+		return nil, fmt.Errorf("%s repo: %w: %w", r.name, domain.ErrWeatherUnavailable, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("%s repo: failed to close resp body: %v\n", r.name, err)
+		}
+	}()
+
+	// step 3: parse response body, error answers may not be JSON
+	var doc any
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, maxJSONBody)).Decode(&doc)
+
+	// step 4: handle response
+	if r.notFound.matches(resp.StatusCode, doc) {
+		log.Printf("%s repo: location %s not found\n", r.name, loc)
+		return nil, fmt.Errorf("%s repo: %w", r.name, domain.ErrCityNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("%s repo: api error %d for %s\n", r.name, resp.StatusCode, loc)
+		return nil, fmt.Errorf("%s repo: %w", r.name, r.statusError(resp.StatusCode))
+	}
+	if decodeErr != nil {
+		log.Printf("%s repo: failed to decode weather data: %v\n", r.name, decodeErr)
+		return nil, fmt.Errorf("%s repo: %w", r.name, domain.ErrInternal)
+	}
+	return doc, nil
+}
+
+func (r *JSONAPI) statusError(status int) error {
+	if err, ok := r.errors[status]; ok {
+		return err
+	}
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return domain.ErrProviderAuth
+	case status == http.StatusTooManyRequests:
+		return domain.ErrQuotaExceeded
+	case status >= http.StatusInternalServerError:
+		return domain.ErrWeatherUnavailable
+	default:
+		return domain.ErrInternal
+	}
+}
+
+func (rule jsonNotFoundRule) matches(status int, doc any) bool {
+	if !rule.enabled {
+		return false
+	}
+	if len(rule.status) > 0 && !slices.Contains(rule.status, status) {
+		return false
+	}
+	if len(rule.path) == 0 {
+		return true
+	}
+	value, ok := rule.path.lookup(doc)
+	return ok && (rule.equals == "" || fmt.Sprint(value) == rule.equals)
+}
@@ -0,0 +1,307 @@
+//go:build unit
+
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJSONSpec() provider.JSONSpec {
+	return provider.JSONSpec{
+		Name: "regional",
+		Current: provider.JSONEndpoint{
+			Path: "/v2/now?lat={lat}&lon={lon}",
+			Fields: provider.JSONFields{
+				Paths: map[string]string{
+					"temperature": "data.temp",
+					"humidity":    "data.rh",
+					"description": "data.conditions.0.text",
+					"wind_speed":  "data.wind.kph",
+					"observed_at": "data.ts",
+				},
+				Scale: map[string]float64{"wind_speed": 1 / 3.6},
+			},
+		},
+		Forecast: provider.JSONForecastEndpoint{
+			Path: "/v2/forecast?lat={lat}&lon={lon}&days={days}",
+			Daily: provider.JSONList{
+				Path: "data.days",
+				Fields: provider.JSONFields{Paths: map[string]string{
+					"date": "date", "min_temperature": "min", "max_temperature": "max",
+				}},
+			},
+			Hourly: provider.JSONList{
+				Path:   "data.hours",
+				Fields: provider.JSONFields{Paths: map[string]string{"time": "at", "temperature": "temp"}},
+			},
+		},
+		Auth:     provider.JSONAuth{In: "header", Name: "Authorization", Prefix: "Token "},
+		NotFound: provider.JSONNotFound{Path: "error.code", Equals: "1006"},
+		Errors:   map[int]string{http.StatusPaymentRequired: "quota_exceeded"},
+	}
+}
+
+func respondWith(status int, body string) *mockHTTPClient {
+	return &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+}
+
+func TestJSONAPIGetCurrentWeather_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{"data": {
+		"temp": "21.5",
+		"rh": 60,
+		"conditions": [{"text": "Overcast"}],
+		"wind": {"kph": 36},
+		"ts": "2025-06-01T12:00:00+02:00"
+	}}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/api/v2/now", req.URL.Path)
+			assert.Equal(t, "50.4547", req.URL.Query().Get("lat"))
+			assert.Equal(t, "30.5238", req.URL.Query().Get("lon"))
+			assert.Equal(t, "Token dummy-api-key", req.Header.Get("Authorization"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com/api"}
+	repo, err := provider.NewJSONAPI(cfg, testJSONSpec(), client)
+	require.NoError(t, err)
+
+	// Act
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, weather.Temperature)
+	assert.Equal(t, 60.0, weather.Humidity)
+	assert.Equal(t, "Overcast", weather.Description)
+	assert.InDelta(t, 10.0, weather.WindSpeed, 1e-9)
+	assert.Equal(t, time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC), weather.ObservedAt)
+	assert.Equal(t, "regional", weather.Source)
+	reported := domain.FieldTemperature | domain.FieldHumidity | domain.FieldWindSpeed
+	assert.Equal(t, domain.AllWeatherFields&^reported, weather.Missing, "fields the answer lacks are missing")
+}
+
+func TestJSONAPIGetCurrentWeather_KeyInQuery(t *testing.T) {
+	// Arrange
+	spec := testJSONSpec()
+	spec.Auth = provider.JSONAuth{In: "query", Name: "apikey"}
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "dummy-api-key", req.URL.Query().Get("apikey"))
+			assert.Equal(t, "50.4547", req.URL.Query().Get("lat"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"temp": 20}}`)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com/api"}
+	repo, err := provider.NewJSONAPI(cfg, spec, client)
+	require.NoError(t, err)
+
+	// Act
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, weather.Temperature)
+	assert.Empty(t, weather.Description, "unmapped answers leave optional fields zero")
+}
+
+func TestJSONAPIGetCurrentWeather_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"NotFoundByBody", http.StatusBadRequest, `{"error": {"code": 1006}}`, domain.ErrCityNotFound},
+		{"NotFoundIn200", http.StatusOK, `{"error": {"code": 1006}}`, domain.ErrCityNotFound},
+		{"OtherBadRequest", http.StatusBadRequest, `{"error": {"code": 2000}}`, domain.ErrInternal},
+		{"MappedStatus", http.StatusPaymentRequired, ``, domain.ErrQuotaExceeded},
+		{"KeyInvalid", http.StatusUnauthorized, ``, domain.ErrProviderAuth},
+		{"KeyForbidden", http.StatusForbidden, ``, domain.ErrProviderAuth},
+		{"RateLimit", http.StatusTooManyRequests, ``, domain.ErrQuotaExceeded},
+		{"ServerError", http.StatusBadGateway, `<html>Bad Gateway</html>`, domain.ErrWeatherUnavailable},
+		{"BadJSON", http.StatusOK, `{invalid json}`, domain.ErrInternal},
+		{"MissingTemperature", http.StatusOK, `{"data": {"rh": 60}}`, domain.ErrInternal},
+		{"NotANumber", http.StatusOK, `{"data": {"temp": "warm"}}`, domain.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com/api"}
+			repo, err := provider.NewJSONAPI(cfg, testJSONSpec(), respondWith(tt.status, tt.body))
+			require.NoError(t, err)
+
+			// Act
+			_, err = repo.GetCurrent(context.Background(), kyiv)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJSONAPIGetForecast_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{"data": {
+		"days": [
+			{"date": "2025-06-01", "min": 12, "max": 25},
+			{"date": "2025-06-02", "min": 10, "max": 20},
+			{"date": "2025-06-03", "min": 11, "max": 19}
+		],
+		"hours": [{"at": 1748736000, "temp": 13}]
+	}}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/api/v2/forecast", req.URL.Path)
+			assert.Equal(t, "2", req.URL.Query().Get("days"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com/api"}
+	repo, err := provider.NewJSONAPI(cfg, testJSONSpec(), client)
+	require.NoError(t, err)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 2)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, forecast.Daily, 2, "days past the requested ones are cut")
+	assert.Equal(t, domain.DailyForecast{
+		Date:           time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		MinTemperature: 12,
+		MaxTemperature: 25,
+	}, forecast.Daily[0])
+	require.Len(t, forecast.Hourly, 1)
+	assert.Equal(t, time.Unix(1748736000, 0).UTC(), forecast.Hourly[0].Time)
+	assert.Equal(t, 13.0, forecast.Hourly[0].Temperature)
+}
+
+func TestJSONAPIGetForecast_NoList(t *testing.T) {
+	// Arrange
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com/api"}
+	repo, err := provider.NewJSONAPI(cfg, testJSONSpec(), respondWith(http.StatusOK, `{"data": {"days": {}}}`))
+	require.NoError(t, err)
+
+	// Act
+	_, err = repo.GetForecast(context.Background(), kyiv, 2)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInternal)
+}
+
+func TestNewJSONAPI_InvalidSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		modify  func(spec *provider.JSONSpec)
+		wantErr []string
+	}{
+		{
+			name: "Fields",
+			key:  "dummy-api-key",
+			modify: func(spec *provider.JSONSpec) {
+				delete(spec.Current.Fields.Paths, "temperature")
+				spec.Current.Fields.Paths["temprature"] = "data.temp"
+				spec.Current.Fields.Paths["humidity"] = "data..rh"
+				spec.Current.Fields.Scale["description"] = 2
+				spec.Forecast.Daily.Fields.Scale = map[string]float64{"min_temperature": 0}
+			},
+			wantErr: []string{
+				"current.fields: temperature is required",
+				"current.fields.temprature: unknown field, expected one of cloud_cover, description,",
+				`current.fields.humidity: path "data..rh" has an empty segment`,
+				"current.scale.description: field is not a number",
+				"forecast.daily.scale.min_temperature: must not be 0",
+			},
+		},
+		{
+			name: "Paths",
+			key:  "dummy-api-key",
+			modify: func(spec *provider.JSONSpec) {
+				spec.Current.Path = "/now?lat={lat}&days={days}"
+				spec.Forecast.Path = ""
+			},
+			wantErr: []string{
+				"current.path: unknown placeholder {days}, expected one of {lat}, {lon}, {key}",
+				"forecast.path: required",
+			},
+		},
+		{
+			name: "Auth",
+			key:  "dummy-api-key",
+			modify: func(spec *provider.JSONSpec) {
+				spec.Auth = provider.JSONAuth{In: "cookie"}
+			},
+			wantErr: []string{`auth.in: unknown placement "cookie", expected query or header`},
+		},
+		{
+			name: "UnusedKey",
+			key:  "dummy-api-key",
+			modify: func(spec *provider.JSONSpec) {
+				spec.Auth = provider.JSONAuth{}
+			},
+			wantErr: []string{"auth: a key is set, but neither auth nor a {key} placeholder uses it"},
+		},
+		{
+			name:    "MissingKey",
+			modify:  func(spec *provider.JSONSpec) {},
+			wantErr: []string{"auth: no key is set"},
+		},
+		{
+			name: "Errors",
+			key:  "dummy-api-key",
+			modify: func(spec *provider.JSONSpec) {
+				spec.Errors = map[int]string{404: "missing", 1000: "internal"}
+			},
+			wantErr: []string{
+				`errors.404: unknown class "missing", expected one of internal, not_found, quota_exceeded, unavailable`,
+				"errors.1000: not an HTTP status",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			spec := testJSONSpec()
+			tt.modify(&spec)
+			cfg := provider.APICfg{APIKey: tt.key, APIURL: "http://dummy-url.com/api"}
+
+			// Act
+			_, err := provider.NewJSONAPI(cfg, spec, respondWith(http.StatusOK, ""))
+
+			// Assert
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
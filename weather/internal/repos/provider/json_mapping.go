@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// jsonTimeLayouts are tried in order for times given as strings, numbers are read as unix seconds.
+var jsonTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04", time.DateOnly}
+
+// jsonPath is a dotted path into a decoded JSON document, numeric segments index arrays.
+type jsonPath []string
+
+func parseJSONPath(path string) (jsonPath, error) {
+	if path == "" {
+		return jsonPath{}, nil
+	}
+	segments := strings.Split(path, ".")
+	if slices.Contains(segments, "") {
+		return nil, fmt.Errorf("path %q has an empty segment", path)
+	}
+	return segments, nil
+}
+
+// lookup returns the value at the path, or false when the document has none there.
+func (p jsonPath) lookup(doc any) (any, bool) {
+	value := doc
+	for _, segment := range p {
+		switch node := value.(type) {
+		case map[string]any:
+			next, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			value = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
+
+// jsonSetter stores a JSON value in a field of T, multiplying numbers by scale.
+type jsonSetter[T any] struct {
+	scalable bool
+	set      func(dst *T, value any, scale float64) error
+}
+
+func floatField[T any](set func(dst *T, value float64)) jsonSetter[T] {
+	return jsonSetter[T]{scalable: true, set: func(dst *T, value any, scale float64) error {
+		f, err := jsonFloat(value)
+		if err != nil {
+			return err
+		}
+		set(dst, f*scale)
+		return nil
+	}}
+}
+
+func stringField[T any](set func(dst *T, value string)) jsonSetter[T] {
+	return jsonSetter[T]{set: func(dst *T, value any, _ float64) error {
+		if s, ok := value.(string); ok {
+			set(dst, s)
+			return nil
+		}
+		set(dst, fmt.Sprint(value))
+		return nil
+	}}
+}
+
+func timeField[T any](set func(dst *T, value time.Time)) jsonSetter[T] {
+	return jsonSetter[T]{set: func(dst *T, value any, _ float64) error {
+		t, err := jsonTime(value)
+		if err != nil {
+			return err
+		}
+		set(dst, t)
+		return nil
+	}}
+}
+
+func jsonFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+}
+
+func jsonTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case float64:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case string:
+		for _, layout := range jsonTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("%q is not a time", v)
+	default:
+		return time.Time{}, fmt.Errorf("%v is not a time", value)
+	}
+}
+
+var jsonCurrentFields = map[string]jsonSetter[domain.Weather]{
+	"temperature":    weatherField(domain.FieldTemperature, func(w *domain.Weather, v float64) { w.Temperature = v }),
+	"humidity":       weatherField(domain.FieldHumidity, func(w *domain.Weather, v float64) { w.Humidity = v }),
+	"description":    stringField(func(w *domain.Weather, v string) { w.Description = v }),
+	"wind_speed":     weatherField(domain.FieldWindSpeed, func(w *domain.Weather, v float64) { w.WindSpeed = v }),
+	"wind_direction": weatherField(domain.FieldWindDirection, func(w *domain.Weather, v float64) { w.WindDirection = v }),
+	"pressure":       weatherField(domain.FieldPressure, func(w *domain.Weather, v float64) { w.Pressure = v }),
+	"precipitation":  weatherField(domain.FieldPrecipitation, func(w *domain.Weather, v float64) { w.Precipitation = v }),
+	"cloud_cover":    weatherField(domain.FieldCloudCover, func(w *domain.Weather, v float64) { w.CloudCover = v }),
+	"visibility":     weatherField(domain.FieldVisibility, func(w *domain.Weather, v float64) { w.Visibility = v }),
+	"uv_index":       weatherField(domain.FieldUVIndex, func(w *domain.Weather, v float64) { w.UVIndex = v }),
+	"observed_at":    timeField(func(w *domain.Weather, v time.Time) { w.ObservedAt = v }),
+}
+
+// weatherField is a floatField of Weather that also takes field off the ones the answer is missing.
+func weatherField(field domain.WeatherFields, set func(w *domain.Weather, v float64)) jsonSetter[domain.Weather] {
+	return floatField(func(w *domain.Weather, v float64) {
+		set(w, v)
+		w.Missing &^= field
+	})
+}
+
+var jsonDailyFields = map[string]jsonSetter[domain.DailyForecast]{
+	"date":            timeField(func(d *domain.DailyForecast, v time.Time) { d.Date = v }),
+	"min_temperature": floatField(func(d *domain.DailyForecast, v float64) { d.MinTemperature = v }),
+	"max_temperature": floatField(func(d *domain.DailyForecast, v float64) { d.MaxTemperature = v }),
+	"humidity":        floatField(func(d *domain.DailyForecast, v float64) { d.Humidity = v }),
+	"description":     stringField(func(d *domain.DailyForecast, v string) { d.Description = v }),
+}
+
+var jsonHourlyFields = map[string]jsonSetter[domain.HourlyForecast]{
+	"time":        timeField(func(h *domain.HourlyForecast, v time.Time) { h.Time = v }),
+	"temperature": floatField(func(h *domain.HourlyForecast, v float64) { h.Temperature = v }),
+	"humidity":    floatField(func(h *domain.HourlyForecast, v float64) { h.Humidity = v }),
+	"description": stringField(func(h *domain.HourlyForecast, v string) { h.Description = v }),
+}
+
+type jsonBinding[T any] struct {
+	name     string
+	path     jsonPath
+	scale    float64
+	required bool
+	setter   jsonSetter[T]
+}
+
+// jsonMapping fills a T from a JSON document.
+type jsonMapping[T any] []jsonBinding[T]
+
+// compileMapping checks the configured fields against the ones T supports. required must be mapped,
+// a response without it is an error, other fields are left zero when a response has none.
+func compileMapping[T any](
+	where string, fields JSONFields, setters map[string]jsonSetter[T], required string,
+) (jsonMapping[T], error) {
+	var errs []error
+	if _, ok := fields.Paths[required]; !ok {
+		errs = append(errs, fmt.Errorf("%s.fields: %s is required", where, required))
+	}
+	mapping := make(jsonMapping[T], 0, len(fields.Paths))
+	for _, name := range slices.Sorted(maps.Keys(fields.Paths)) {
+		setter, ok := setters[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s.fields.%s: unknown field, expected one of %s",
+				where, name, strings.Join(slices.Sorted(maps.Keys(setters)), ", ")))
+			continue
+		}
+		path, err := parseJSONPath(fields.Paths[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.fields.%s: %w", where, name, err))
+			continue
+		}
+		mapping = append(mapping, jsonBinding[T]{name: name, path: path, scale: 1, required: name == required, setter: setter})
+	}
+	for _, name := range slices.Sorted(maps.Keys(fields.Scale)) {
+		i := slices.IndexFunc(mapping, func(b jsonBinding[T]) bool { return b.name == name })
+		switch {
+		case i < 0:
+			errs = append(errs, fmt.Errorf("%s.scale.%s: field is not mapped", where, name))
+		case !mapping[i].setter.scalable:
+			errs = append(errs, fmt.Errorf("%s.scale.%s: field is not a number", where, name))
+		case fields.Scale[name] == 0:
+			errs = append(errs, fmt.Errorf("%s.scale.%s: must not be 0", where, name))
+		default:
+			mapping[i].scale = fields.Scale[name]
+		}
+	}
+	return mapping, errors.Join(errs...)
+}
+
+// apply fills dst from doc.
+func (m jsonMapping[T]) apply(dst *T, doc any) error {
+	for _, binding := range m {
+		value, ok := binding.path.lookup(doc)
+		if !ok {
+			if binding.required {
+				return fmt.Errorf("%s is missing", binding.name)
+			}
+			continue
+		}
+		if err := binding.setter.set(dst, value, binding.scale); err != nil {
+			return fmt.Errorf("%s: %w", binding.name, err)
+		}
+	}
+	return nil
+}
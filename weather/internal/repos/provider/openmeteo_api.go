@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const (
+	OpenMeteoName = "open-meteo.com"
+
+	openMeteoCurrentFields = "temperature_2m,relative_humidity_2m,weather_code,wind_speed_10m,wind_direction_10m," +
+		"pressure_msl,precipitation,cloud_cover,visibility,uv_index"
+	openMeteoHourlyFields = "temperature_2m,relative_humidity_2m,weather_code"
+	openMeteoDailyFields  = "temperature_2m_max,temperature_2m_min,relative_humidity_2m_mean,weather_code"
+)
+
+// wmoDescriptions names the WMO weather interpretation codes Open-Meteo reports conditions with.
+var wmoDescriptions = map[int]string{
+	0:  "Clear sky",
+	1:  "Mainly clear",
+	2:  "Partly cloudy",
+	3:  "Overcast",
+	45: "Fog",
+	48: "Depositing rime fog",
+	51: "Light drizzle",
+	53: "Moderate drizzle",
+	55: "Dense drizzle",
+	56: "Light freezing drizzle",
+	57: "Dense freezing drizzle",
+	61: "Slight rain",
+	63: "Moderate rain",
+	65: "Heavy rain",
+	66: "Light freezing rain",
+	67: "Heavy freezing rain",
+	71: "Slight snow fall",
+	73: "Moderate snow fall",
+	75: "Heavy snow fall",
+	77: "Snow grains",
+	80: "Slight rain showers",
+	81: "Moderate rain showers",
+	82: "Violent rain showers",
+	85: "Slight snow showers",
+	86: "Heavy snow showers",
+	95: "Thunderstorm",
+	96: "Thunderstorm with slight hail",
+	99: "Thunderstorm with heavy hail",
+}
+
+// OpenMeteoAPI asks Open-Meteo, which needs no api key.
+type OpenMeteoAPI struct {
+	cfg    APICfg
+	client HTTPClient
+}
+
+func NewOpenMeteoAPI(cfg APICfg, client HTTPClient) *OpenMeteoAPI {
+	return &OpenMeteoAPI{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+type openMeteoAPIResponse struct {
+	Current struct {
+		Time             int64   `json:"time"`
+		Temperature      float64 `json:"temperature_2m"`
+		Humidity         float64 `json:"relative_humidity_2m"`
+		WeatherCode      int     `json:"weather_code"`
+		WindSpeed        float64 `json:"wind_speed_10m"`
+		WindDirection    float64 `json:"wind_direction_10m"`
+		Pressure         float64 `json:"pressure_msl"`
+		Precipitation    float64 `json:"precipitation"`
+		CloudCover       float64 `json:"cloud_cover"`
+		VisibilityMeters float64 `json:"visibility"`
+		UVIndex          float64 `json:"uv_index"`
+	} `json:"current"`
+}
+
+// openMeteoAPIForecastResponse holds one array per field, all indexed like Time.
+type openMeteoAPIForecastResponse struct {
+	Hourly struct {
+		Time        []int64   `json:"time"`
+		Temperature []float64 `json:"temperature_2m"`
+		Humidity    []float64 `json:"relative_humidity_2m"`
+		WeatherCode []int     `json:"weather_code"`
+	} `json:"hourly"`
+	Daily struct {
+		Time           []int64   `json:"time"`
+		TemperatureMax []float64 `json:"temperature_2m_max"`
+		TemperatureMin []float64 `json:"temperature_2m_min"`
+		Humidity       []float64 `json:"relative_humidity_2m_mean"`
+		WeatherCode    []int     `json:"weather_code"`
+	} `json:"daily"`
+}
+
+type openMeteoAPIErrorResponse struct {
+	Reason string `json:"reason"`
+}
+
+func (r *OpenMeteoAPI) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	var responseData openMeteoAPIResponse
+	params := url.Values{}
+	params.Set("current", openMeteoCurrentFields)
+	if err := r.fetch(ctx, loc, params, &responseData); err != nil {
+		return domain.Weather{}, err
+	}
+
+	current := responseData.Current
+	return domain.Weather{
+		Temperature:   current.Temperature,
+		Humidity:      current.Humidity,
+		Description:   wmoDescription(current.WeatherCode),
+		WindSpeed:     current.WindSpeed,
+		WindDirection: current.WindDirection,
+		Pressure:      current.Pressure,
+		Precipitation: current.Precipitation,
+		CloudCover:    current.CloudCover,
+		Visibility:    current.VisibilityMeters * mToKm,
+		UVIndex:       current.UVIndex,
+		ObservedAt:    time.Unix(current.Time, 0).UTC(),
+		Source:        OpenMeteoName,
+	}, nil
+}
+
+func (r *OpenMeteoAPI) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	var responseData openMeteoAPIForecastResponse
+	params := url.Values{}
+	params.Set("hourly", openMeteoHourlyFields)
+	params.Set("daily", openMeteoDailyFields)
+	params.Set("forecast_days", strconv.Itoa(days))
+	if err := r.fetch(ctx, loc, params, &responseData); err != nil {
+		return domain.Forecast{}, err
+	}
+
+	var forecast domain.Forecast
+	daily := responseData.Daily
+	if len(daily.TemperatureMax) < len(daily.Time) || len(daily.TemperatureMin) < len(daily.Time) ||
+		len(daily.Humidity) < len(daily.Time) || len(daily.WeatherCode) < len(daily.Time) {
+		log.Println("open meteo repo: daily fields are shorter than daily time")
+		return domain.Forecast{}, fmt.Errorf("open meteo repo: %w", domain.ErrInternal)
+	}
+	for i, day := range daily.Time {
+		forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+			Date:           time.Unix(day, 0).UTC(),
+			MinTemperature: daily.TemperatureMin[i],
+			MaxTemperature: daily.TemperatureMax[i],
+			Humidity:       daily.Humidity[i],
+			Description:    wmoDescription(daily.WeatherCode[i]),
+		})
+	}
+	hourly := responseData.Hourly
+	if len(hourly.Temperature) < len(hourly.Time) || len(hourly.Humidity) < len(hourly.Time) ||
+		len(hourly.WeatherCode) < len(hourly.Time) {
+		log.Println("open meteo repo: hourly fields are shorter than hourly time")
+		return domain.Forecast{}, fmt.Errorf("open meteo repo: %w", domain.ErrInternal)
+	}
+	for i, hour := range hourly.Time {
+		forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+			Time:        time.Unix(hour, 0).UTC(),
+			Temperature: hourly.Temperature[i],
+			Humidity:    hourly.Humidity[i],
+			Description: wmoDescription(hourly.WeatherCode[i]),
+		})
+	}
+	return forecast, nil
+}
+
+func wmoDescription(code int) string {
+	if description, ok := wmoDescriptions[code]; ok {
+		return description
+	}
+	return "Unknown"
+}
+
+// GetAlerts fails with domain.ErrAlertsUnsupported, Open-Meteo has no weather alerts.
+func (r *OpenMeteoAPI) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return domain.Alerts{}, fmt.Errorf("open meteo repo: %w", domain.ErrAlertsUnsupported)
+}
+
+// GetAirQuality fails with domain.ErrAirQualityUnsupported, Open-Meteo serves it from a separate air quality api.
+func (r *OpenMeteoAPI) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return domain.AirQuality{}, fmt.Errorf("open meteo repo: %w", domain.ErrAirQualityUnsupported)
+}
+
+// fetch sends a GET request to the forecast endpoint and decodes a successful response into dest,
+// mapping api errors to domain errors.
+func (r *OpenMeteoAPI) fetch(ctx context.Context, loc domain.Location, params url.Values, dest any) error {
+	// step 1: format request
+	if loc.ByName {
+		log.Printf("open meteo repo: %s has no coordinates, the api knows no cities\n", loc)
+		return fmt.Errorf("open meteo repo: %w", domain.ErrCityNotFound)
+	}
+	params.Set("latitude", strconv.FormatFloat(loc.Lat, 'f', 4, 64))
+	params.Set("longitude", strconv.FormatFloat(loc.Lon, 'f', 4, 64))
+	params.Set("wind_speed_unit", "ms")
+	params.Set("timeformat", "unixtime")
+	params.Set("timezone", "GMT")
+	url := fmt.Sprintf("%s/forecast?%s", r.cfg.APIURL, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		log.Printf("open meteo repo: failed to format request for %s, err:%v\n", loc, err)
+		return fmt.Errorf("open meteo repo: %w", domain.ErrInternal)
+	}
+
+	// step 2: send request
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Printf("open meteo repo: failed to get weather for %s, err:%v\n", loc, err)
+		return fmt.Errorf("open meteo repo: %w: %w", domain.ErrWeatherUnavailable, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("open meteo repo: failed to close resp body: %v\n", err)
+		}
+	}()
+
+	// step 3: handle response
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Println("open meteo repo: rate limit reached")
+		return fmt.Errorf("open meteo repo: %w", domain.ErrQuotaExceeded)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		log.Printf("open meteo repo: server error %d\n", resp.StatusCode)
+		return fmt.Errorf("open meteo repo: %w", domain.ErrWeatherUnavailable)
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		// the api knows no cities, so a bad request means coordinates out of range
+		var errResp openMeteoAPIErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
+			log.Printf("open meteo repo: api error for %s: %s\n", loc, errResp.Reason)
+		}
+		return fmt.Errorf("open meteo repo: %w", domain.ErrCityNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("open meteo repo: unexpected error %d\n", resp.StatusCode)
+		return fmt.Errorf("open meteo repo: %w", domain.ErrInternal)
+	}
+
+	// step 4: parse response body
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		log.Printf("open meteo repo: failed to decode weather data: %v\n", err)
+		return fmt.Errorf("open meteo repo: %w", domain.ErrInternal)
+	}
+	return nil
+}
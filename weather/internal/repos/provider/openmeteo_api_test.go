@@ -0,0 +1,187 @@
+//go:build unit
+
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenMeteoGetCurrentWeather_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"current": {
+			"time": 1748779200,
+			"temperature_2m": 21.5,
+			"relative_humidity_2m": 60,
+			"weather_code": 3,
+			"wind_speed_10m": 4.2,
+			"wind_direction_10m": 270,
+			"pressure_msl": 1012.5,
+			"precipitation": 0.4,
+			"cloud_cover": 75,
+			"visibility": 24000,
+			"uv_index": 3.0
+		}
+	}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/v1/forecast", req.URL.Path)
+			assert.Equal(t, "50.4547", req.URL.Query().Get("latitude"))
+			assert.Equal(t, "30.5238", req.URL.Query().Get("longitude"))
+			assert.Equal(t, "ms", req.URL.Query().Get("wind_speed_unit"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIURL: "http://dummy-url.com/v1"}
+	repo := provider.NewOpenMeteoAPI(cfg, client)
+
+	// Act
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, weather.Temperature)
+	assert.Equal(t, 60.0, weather.Humidity)
+	assert.Equal(t, "Overcast", weather.Description)
+	assert.Equal(t, 4.2, weather.WindSpeed)
+	assert.Equal(t, 270.0, weather.WindDirection)
+	assert.Equal(t, 1012.5, weather.Pressure)
+	assert.Equal(t, 0.4, weather.Precipitation)
+	assert.Equal(t, 75.0, weather.CloudCover)
+	assert.InDelta(t, 24.0, weather.Visibility, 1e-9)
+	assert.Equal(t, 3.0, weather.UVIndex)
+	assert.Equal(t, time.Unix(1748779200, 0).UTC(), weather.ObservedAt)
+	assert.Equal(t, provider.OpenMeteoName, weather.Source)
+}
+
+func TestOpenMeteoGetCurrentWeather_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"CoordinatesOutOfRange", http.StatusBadRequest,
+			`{"error": true, "reason": "Latitude must be in range of -90 to 90°."}`, domain.ErrCityNotFound},
+		{"RateLimit", http.StatusTooManyRequests, `{"error": true, "reason": "Daily API request limit exceeded"}`, domain.ErrQuotaExceeded},
+		{"ServerError", http.StatusBadGateway, `<html>Bad Gateway</html>`, domain.ErrWeatherUnavailable},
+		{"BadJSON", http.StatusOK, `{invalid json}`, domain.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			client := &mockHTTPClient{
+				doFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.status,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+					}, nil
+				},
+			}
+			repo := provider.NewOpenMeteoAPI(provider.APICfg{APIURL: "http://dummy-url.com/v1"}, client)
+
+			// Act
+			_, err := repo.GetCurrent(context.Background(), kyiv)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOpenMeteoGetCurrentWeather_ByName(t *testing.T) {
+	// Arrange
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("the api knows no cities and must not be asked")
+			return nil, nil
+		},
+	}
+	repo := provider.NewOpenMeteoAPI(provider.APICfg{APIURL: "http://dummy-url.com"}, client)
+
+	// Act
+	_, err := repo.GetCurrent(context.Background(), domain.Location{Name: "boston", ByName: true})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrCityNotFound)
+}
+
+func TestOpenMeteoGetForecast_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"hourly": {
+			"time": [1748736000, 1748739600],
+			"temperature_2m": [13.0, 12.5],
+			"relative_humidity_2m": [70, 72],
+			"weather_code": [0, 61]
+		},
+		"daily": {
+			"time": [1748736000],
+			"temperature_2m_max": [25.0],
+			"temperature_2m_min": [12.0],
+			"relative_humidity_2m_mean": [55],
+			"weather_code": [2]
+		}
+	}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "1", req.URL.Query().Get("forecast_days"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	repo := provider.NewOpenMeteoAPI(provider.APICfg{APIURL: "http://dummy-url.com/v1"}, client)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 1)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, forecast.Daily, 1)
+	assert.Equal(t, domain.DailyForecast{
+		Date:           time.Unix(1748736000, 0).UTC(),
+		MinTemperature: 12.0,
+		MaxTemperature: 25.0,
+		Humidity:       55.0,
+		Description:    "Partly cloudy",
+	}, forecast.Daily[0])
+	require.Len(t, forecast.Hourly, 2)
+	assert.Equal(t, "Clear sky", forecast.Hourly[0].Description)
+	assert.Equal(t, "Slight rain", forecast.Hourly[1].Description)
+	assert.Equal(t, 72.0, forecast.Hourly[1].Humidity)
+}
+
+func TestOpenMeteoGetForecast_MismatchedFields(t *testing.T) {
+	// Arrange
+	mockRespBody := `{"daily": {"time": [1748736000, 1748822400], "temperature_2m_max": [25.0]}}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	repo := provider.NewOpenMeteoAPI(provider.APICfg{APIURL: "http://dummy-url.com/v1"}, client)
+
+	// Act
+	_, err := repo.GetForecast(context.Background(), kyiv, 2)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInternal)
+}
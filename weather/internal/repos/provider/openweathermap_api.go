@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const (
+	OpenWeatherMapName = "openweathermap.org"
+
+	// the forecast endpoint answers in 3 hour steps for at most 5 days
+	openWeatherMapStepsPerDay = 8
+	openWeatherMapMaxSteps    = 40
+	// hour of the step whose conditions describe the whole day
+	openWeatherMapMiddayHour = 12
+)
+
+type OpenWeatherMapAPI struct {
+	cfg    APICfg
+	client HTTPClient
+}
+
+func NewOpenWeatherMapAPI(cfg APICfg, client HTTPClient) *OpenWeatherMapAPI {
+	return &OpenWeatherMapAPI{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+type openWeatherMapCondition struct {
+	Description string `json:"description"`
+}
+
+type openWeatherMapPrecipitation struct {
+	OneHour float64 `json:"1h"`
+}
+
+type openWeatherMapAPIResponse struct {
+	Dt      int64                     `json:"dt"`
+	Weather []openWeatherMapCondition `json:"weather"`
+	Main    struct {
+		Temp     float64 `json:"temp"`
+		Pressure float64 `json:"pressure"`
+		Humidity float64 `json:"humidity"`
+	} `json:"main"`
+	VisibilityMeters float64 `json:"visibility"`
+	Wind             struct {
+		Speed float64 `json:"speed"`
+		Deg   float64 `json:"deg"`
+	} `json:"wind"`
+	Clouds struct {
+		All float64 `json:"all"`
+	} `json:"clouds"`
+	Rain openWeatherMapPrecipitation `json:"rain"`
+	Snow openWeatherMapPrecipitation `json:"snow"`
+}
+
+type openWeatherMapAPIForecastResponse struct {
+	List []struct {
+		Dt      int64                     `json:"dt"`
+		Weather []openWeatherMapCondition `json:"weather"`
+		Main    struct {
+			Temp     float64 `json:"temp"`
+			TempMin  float64 `json:"temp_min"`
+			TempMax  float64 `json:"temp_max"`
+			Humidity float64 `json:"humidity"`
+		} `json:"main"`
+	} `json:"list"`
+}
+
+type openWeatherMapAPIAirPollutionResponse struct {
+	List []struct {
+		Dt         int64 `json:"dt"`
+		Components struct {
+			PM25 *float64 `json:"pm2_5"`
+			PM10 *float64 `json:"pm10"`
+			O3   *float64 `json:"o3"`
+			NO2  *float64 `json:"no2"`
+		} `json:"components"`
+	} `json:"list"`
+}
+
+type openWeatherMapAPIErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func (r *OpenWeatherMapAPI) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	var responseData openWeatherMapAPIResponse
+	if err := r.fetch(ctx, "data/2.5/weather", loc, url.Values{}, &responseData); err != nil {
+		return domain.Weather{}, err
+	}
+
+	return domain.Weather{
+		Temperature:   responseData.Main.Temp,
+		Humidity:      responseData.Main.Humidity,
+		Description:   openWeatherMapDescription(responseData.Weather),
+		WindSpeed:     responseData.Wind.Speed,
+		WindDirection: responseData.Wind.Deg,
+		Pressure:      responseData.Main.Pressure,
+		Precipitation: responseData.Rain.OneHour + responseData.Snow.OneHour,
+		CloudCover:    responseData.Clouds.All,
+		Visibility:    responseData.VisibilityMeters * mToKm,
+		ObservedAt:    time.Unix(responseData.Dt, 0).UTC(),
+		Source:        OpenWeatherMapName,
+		// the current weather endpoint has no UV index
+		Missing: domain.FieldUVIndex,
+	}, nil
+}
+
+// GetForecast returns at most 5 days, the longest forecast the api offers. Days are aggregated
+// from the 3 hourly steps, which are also returned as the hourly forecast.
+func (r *OpenWeatherMapAPI) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	var responseData openWeatherMapAPIForecastResponse
+	params := url.Values{}
+	params.Set("cnt", strconv.Itoa(min(days*openWeatherMapStepsPerDay, openWeatherMapMaxSteps)))
+	if err := r.fetch(ctx, "data/2.5/forecast", loc, params, &responseData); err != nil {
+		return domain.Forecast{}, err
+	}
+
+	var forecast domain.Forecast
+	var humiditySum float64
+	var steps, middayDistance int
+	for _, step := range responseData.List {
+		stepTime := time.Unix(step.Dt, 0).UTC()
+		description := openWeatherMapDescription(step.Weather)
+		forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+			Time:        stepTime,
+			Temperature: step.Main.Temp,
+			Humidity:    step.Main.Humidity,
+			Description: description,
+		})
+
+		date := stepTime.Truncate(hoursPerDay * time.Hour)
+		distance := abs(stepTime.Hour() - openWeatherMapMiddayHour)
+		last := len(forecast.Daily) - 1
+		if last < 0 || !forecast.Daily[last].Date.Equal(date) {
+			forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+				Date:           date,
+				MinTemperature: step.Main.TempMin,
+				MaxTemperature: step.Main.TempMax,
+				Description:    description,
+			})
+			humiditySum, steps, middayDistance = 0, 0, distance
+			last++
+		}
+		day := &forecast.Daily[last]
+		day.MinTemperature = min(day.MinTemperature, step.Main.TempMin)
+		day.MaxTemperature = max(day.MaxTemperature, step.Main.TempMax)
+		humiditySum += step.Main.Humidity
+		steps++
+		day.Humidity = humiditySum / float64(steps)
+		if distance < middayDistance {
+			day.Description, middayDistance = description, distance
+		}
+	}
+	return forecast, nil
+}
+
+func openWeatherMapDescription(conditions []openWeatherMapCondition) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return conditions[0].Description
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// GetAlerts fails with domain.ErrAlertsUnsupported, the OpenWeatherMap endpoints it reads have no alerts.
+func (r *OpenWeatherMapAPI) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return domain.Alerts{}, fmt.Errorf("open weather map repo: %w", domain.ErrAlertsUnsupported)
+}
+
+// GetAirQuality reads the air pollution api, which OpenWeatherMap serves with the same key.
+func (r *OpenWeatherMapAPI) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	var responseData openWeatherMapAPIAirPollutionResponse
+	if err := r.fetch(ctx, "data/2.5/air_pollution", loc, url.Values{}, &responseData); err != nil {
+		return domain.AirQuality{}, err
+	}
+	if len(responseData.List) == 0 {
+		log.Printf("open weather map repo: no air pollution data for %s\n", loc)
+		return domain.AirQuality{}, fmt.Errorf("open weather map repo: %w", domain.ErrInternal)
+	}
+
+	components := responseData.List[0].Components
+	airQuality := domain.NewAirQuality(components.PM25, components.PM10, components.O3, components.NO2)
+	airQuality.ObservedAt = time.Unix(responseData.List[0].Dt, 0).UTC()
+	airQuality.Source = OpenWeatherMapName
+	return airQuality, nil
+}
+
+// fetch sends a GET request to the given endpoint and decodes a successful response into dest,
+// mapping api errors to domain errors.
+func (r *OpenWeatherMapAPI) fetch(ctx context.Context, endpoint string, loc domain.Location, params url.Values, dest any) error {
+	// step 1: format request
+	if loc.ByName {
+		log.Printf("open weather map repo: %s has no coordinates\n", loc)
+		return fmt.Errorf("open weather map repo: %w", domain.ErrCityNotFound)
+	}
+	params.Set("lat", strconv.FormatFloat(loc.Lat, 'f', 4, 64))
+	params.Set("lon", strconv.FormatFloat(loc.Lon, 'f', 4, 64))
+	params.Set("appid", r.cfg.APIKey)
+	params.Set("units", "metric")
+	url := fmt.Sprintf("%s/%s?%s", r.cfg.APIURL, endpoint, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		log.Printf("open weather map repo: failed to format request for %s, err:%v\n", loc, err)
+		return fmt.Errorf("open weather map repo: %w", domain.ErrInternal)
+	}
+
+	// step 2: send request
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Printf("open weather map repo: failed to get weather for %s, err:%v\n", loc, err)
+		return fmt.Errorf("open weather map repo: %w: %w", domain.ErrWeatherUnavailable, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("open weather map repo: failed to close resp body: %v\n", err)
+		}
+	}()
+
+	// step 3: handle response
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		log.Println("open weather map repo: api key is invalid")
+		return fmt.Errorf("open weather map repo: %w", domain.ErrProviderAuth)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Println("open weather map repo: rate limit reached")
+		return fmt.Errorf("open weather map repo: %w", domain.ErrQuotaExceeded)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		log.Printf("open weather map repo: server error %d\n", resp.StatusCode)
+		return fmt.Errorf("open weather map repo: %w", domain.ErrWeatherUnavailable)
+	}
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound {
+		var errResp openWeatherMapAPIErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
+			log.Printf("open weather map repo: api error for %s: %s\n", loc, errResp.Message)
+		}
+		return fmt.Errorf("open weather map repo: %w", domain.ErrCityNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("open weather map repo: unexpected error %d\n", resp.StatusCode)
+		return fmt.Errorf("open weather map repo: %w", domain.ErrInternal)
+	}
+
+	// step 4: parse response body
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		log.Printf("open weather map repo: failed to decode weather data: %v\n", err)
+		return fmt.Errorf("open weather map repo: %w", domain.ErrInternal)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+//go:build unit
+
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenWeatherMapGetCurrentWeather_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"dt": 1748779200,
+		"weather": [{"description": "light rain"}],
+		"main": {"temp": 18.5, "pressure": 1009, "humidity": 82},
+		"visibility": 8000,
+		"wind": {"speed": 5.1, "deg": 200},
+		"clouds": {"all": 90},
+		"rain": {"1h": 0.6}
+	}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/data/2.5/weather", req.URL.Path)
+			assert.Equal(t, "50.4547", req.URL.Query().Get("lat"))
+			assert.Equal(t, "30.5238", req.URL.Query().Get("lon"))
+			assert.Equal(t, "dummy-api-key", req.URL.Query().Get("appid"))
+			assert.Equal(t, "metric", req.URL.Query().Get("units"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+	// Act
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 18.5, weather.Temperature)
+	assert.Equal(t, 82.0, weather.Humidity)
+	assert.Equal(t, "light rain", weather.Description)
+	assert.Equal(t, 5.1, weather.WindSpeed)
+	assert.Equal(t, 200.0, weather.WindDirection)
+	assert.Equal(t, 1009.0, weather.Pressure)
+	assert.Equal(t, 0.6, weather.Precipitation)
+	assert.Equal(t, 90.0, weather.CloudCover)
+	assert.InDelta(t, 8.0, weather.Visibility, 1e-9)
+	assert.Equal(t, time.Unix(1748779200, 0).UTC(), weather.ObservedAt)
+	assert.Equal(t, provider.OpenWeatherMapName, weather.Source)
+	assert.Equal(t, domain.FieldUVIndex, weather.Missing)
+}
+
+func TestOpenWeatherMapGetCurrentWeather_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"APIKeyInvalid", http.StatusUnauthorized, `{"cod": 401, "message": "Invalid API key."}`, domain.ErrProviderAuth},
+		{"CityNotFound", http.StatusNotFound, `{"cod": "404", "message": "city not found"}`, domain.ErrCityNotFound},
+		{"WrongLatitude", http.StatusBadRequest, `{"cod": "400", "message": "wrong latitude"}`, domain.ErrCityNotFound},
+		{"RateLimit", http.StatusTooManyRequests, `{"cod": 429}`, domain.ErrQuotaExceeded},
+		{"ServerError", http.StatusServiceUnavailable, ``, domain.ErrWeatherUnavailable},
+		{"BadJSON", http.StatusOK, `{invalid json}`, domain.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			client := &mockHTTPClient{
+				doFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.status,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+					}, nil
+				},
+			}
+			cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+			repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+			// Act
+			_, err := repo.GetCurrent(context.Background(), kyiv)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOpenWeatherMapGetForecast_AggregatesDays(t *testing.T) {
+	// Arrange
+	// 2025-06-01 06:00, 12:00, 18:00 and 2025-06-02 00:00 UTC
+	mockRespBody := `{"list": [
+		{"dt": 1748757600, "main": {"temp": 14, "temp_min": 13, "temp_max": 14, "humidity": 80}, "weather": [{"description": "mist"}]},
+		{"dt": 1748779200, "main": {"temp": 24, "temp_min": 23, "temp_max": 25, "humidity": 50}, "weather": [{"description": "clear sky"}]},
+		{"dt": 1748800800, "main": {"temp": 19, "temp_min": 18, "temp_max": 19, "humidity": 65}, "weather": [{"description": "few clouds"}]},
+		{"dt": 1748822400, "main": {"temp": 11, "temp_min": 10, "temp_max": 11, "humidity": 90}, "weather": [{"description": "fog"}]}
+	]}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/data/2.5/forecast", req.URL.Path)
+			assert.Equal(t, "16", req.URL.Query().Get("cnt"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, forecast.Hourly, 4)
+	require.Len(t, forecast.Daily, 2)
+	assert.Equal(t, domain.DailyForecast{
+		Date:           time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		MinTemperature: 13,
+		MaxTemperature: 25,
+		Humidity:       65,
+		Description:    "clear sky",
+	}, forecast.Daily[0])
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), forecast.Daily[1].Date)
+	assert.Equal(t, "fog", forecast.Daily[1].Description)
+}
+
+func TestOpenWeatherMapGetForecast_CapsSteps(t *testing.T) {
+	// Arrange
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "40", req.URL.Query().Get("cnt"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"list": []}`)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 7)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, forecast.Daily)
+}
+
+func TestOpenWeatherMapGetAirQuality_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"coord": {"lon": 30.5238, "lat": 50.4547},
+		"list": [{
+			"main": {"aqi": 3},
+			"components": {"co": 300.4, "no": 0.1, "no2": 30.0, "o3": 80.0, "so2": 5.2, "pm2_5": 40.0, "pm10": 60.0, "nh3": 1.1},
+			"dt": 1748779200
+		}]
+	}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/data/2.5/air_pollution", req.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+	// Act
+	airQuality, err := repo.GetAirQuality(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 112, airQuality.AQI, "the index is computed on the EPA scale, not taken from the api")
+	assert.Equal(t, 40.0, airQuality.PM25)
+	assert.Equal(t, 60.0, airQuality.PM10)
+	assert.Equal(t, 80.0, airQuality.O3)
+	assert.Equal(t, 30.0, airQuality.NO2)
+	assert.Zero(t, airQuality.Missing)
+	assert.Nil(t, airQuality.Pollen)
+	assert.Equal(t, time.Unix(1748779200, 0).UTC(), airQuality.ObservedAt)
+	assert.Equal(t, provider.OpenWeatherMapName, airQuality.Source)
+}
+
+func TestOpenWeatherMapGetAirQuality_Unreported(t *testing.T) {
+	// Arrange
+	mockRespBody := `{"list": [{"components": {"pm2_5": 40.0, "pm10": 60.0}, "dt": 1748779200}]}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+	// Act
+	airQuality, err := repo.GetAirQuality(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.FieldO3|domain.FieldNO2, airQuality.Missing)
+	assert.Equal(t, domain.USAQI(40, 60, 0, 0), airQuality.AQI)
+}
+
+func TestOpenWeatherMapGetAirQuality_NoData(t *testing.T) {
+	// Arrange
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"list": []}`)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewOpenWeatherMapAPI(cfg, client)
+
+	// Act
+	_, err := repo.GetAirQuality(context.Background(), kyiv)
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrInternal)
+}
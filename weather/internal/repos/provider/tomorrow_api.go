@@ -7,11 +7,17 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 )
 
-const tomorrowCityNotFoundCode = 400001
+const (
+	TomorrowIOName = "tomorrow.io"
+
+	tomorrowCityNotFoundCode = 400001
+	hoursPerDay              = 24
+)
 
 type TomorrowAPI struct {
 	cfg    APICfg
@@ -27,36 +33,139 @@ func NewTomorrowAPI(cfg APICfg, client HTTPClient) *TomorrowAPI {
 
 type tomorrowAPIResponse struct {
 	Data struct {
+		Time   time.Time `json:"time"`
 		Values struct {
-			Temperature float64 `json:"temperature"`
-			Humidity    float64 `json:"humidity"`
-			Visibility  float64 `json:"visibility"`
-			CloudCover  float64 `json:"cloudCover"`
+			Temperature            float64 `json:"temperature"`
+			Humidity               float64 `json:"humidity"`
+			Visibility             float64 `json:"visibility"`
+			CloudCover             float64 `json:"cloudCover"`
+			WindSpeed              float64 `json:"windSpeed"`
+			WindDirection          float64 `json:"windDirection"`
+			PressureSurfaceLevel   float64 `json:"pressureSurfaceLevel"`
+			PrecipitationIntensity float64 `json:"precipitationIntensity"`
+			UVIndex                float64 `json:"uvIndex"`
 		} `json:"values"`
 	} `json:"data"`
 }
 
+type tomorrowAPIForecastResponse struct {
+	Timelines struct {
+		Hourly []struct {
+			Time   time.Time `json:"time"`
+			Values struct {
+				Temperature float64 `json:"temperature"`
+				Humidity    float64 `json:"humidity"`
+				CloudCover  float64 `json:"cloudCover"`
+			} `json:"values"`
+		} `json:"hourly"`
+		Daily []struct {
+			Time   time.Time `json:"time"`
+			Values struct {
+				TemperatureMin float64 `json:"temperatureMin"`
+				TemperatureMax float64 `json:"temperatureMax"`
+				HumidityAvg    float64 `json:"humidityAvg"`
+				CloudCoverAvg  float64 `json:"cloudCoverAvg"`
+			} `json:"values"`
+		} `json:"daily"`
+	} `json:"timelines"`
+}
+
 type tomorrowAPIErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Type    string `json:"type"`
 }
 
-func (r *TomorrowAPI) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (r *TomorrowAPI) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	var responseData tomorrowAPIResponse
+	if err := r.fetch(ctx, "weather/realtime", loc, url.Values{}, &responseData); err != nil {
+		return domain.Weather{}, err
+	}
+
+	values := responseData.Data.Values
+	return domain.Weather{
+		Temperature:   values.Temperature,
+		Humidity:      values.Humidity,
+		Description:   cloudCoverDescription(values.CloudCover),
+		WindSpeed:     values.WindSpeed,
+		WindDirection: values.WindDirection,
+		Pressure:      values.PressureSurfaceLevel,
+		Precipitation: values.PrecipitationIntensity,
+		CloudCover:    values.CloudCover,
+		Visibility:    values.Visibility,
+		UVIndex:       values.UVIndex,
+		ObservedAt:    responseData.Data.Time.UTC(),
+		Source:        TomorrowIOName,
+	}, nil
+}
+
+func (r *TomorrowAPI) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	var responseData tomorrowAPIForecastResponse
+	params := url.Values{}
+	params.Add("timesteps", "1h")
+	params.Add("timesteps", "1d")
+	if err := r.fetch(ctx, "weather/forecast", loc, params, &responseData); err != nil {
+		return domain.Forecast{}, err
+	}
+
+	var forecast domain.Forecast
+	for i, day := range responseData.Timelines.Daily {
+		if i == days {
+			break
+		}
+		forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+			Date:           day.Time.UTC(),
+			MinTemperature: day.Values.TemperatureMin,
+			MaxTemperature: day.Values.TemperatureMax,
+			Humidity:       day.Values.HumidityAvg,
+			Description:    cloudCoverDescription(day.Values.CloudCoverAvg),
+		})
+	}
+
+	// api returns a fixed hourly horizon, so cut it to the requested number of days
+	hourlyLimit := days * hoursPerDay
+	for i, hour := range responseData.Timelines.Hourly {
+		if i == hourlyLimit {
+			break
+		}
+		forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+			Time:        hour.Time.UTC(),
+			Temperature: hour.Values.Temperature,
+			Humidity:    hour.Values.Humidity,
+			Description: cloudCoverDescription(hour.Values.CloudCover),
+		})
+	}
+	return forecast, nil
+}
+
+// GetAlerts fails with domain.ErrAlertsUnsupported, the Tomorrow.io weather api has no alerts.
+func (r *TomorrowAPI) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return domain.Alerts{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrAlertsUnsupported)
+}
+
+// GetAirQuality fails with domain.ErrAirQualityUnsupported, Tomorrow.io has air quality only on paid plans.
+func (r *TomorrowAPI) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return domain.AirQuality{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrAirQualityUnsupported)
+}
+
+// fetch sends a GET request to the given endpoint and decodes a successful response into dest,
+// mapping api errors to domain errors.
+func (r *TomorrowAPI) fetch(ctx context.Context, endpoint string, loc domain.Location, params url.Values, dest any) error {
 	// step 1: format request
-	q := url.QueryEscape(city)
-	url := fmt.Sprintf("%s/weather/realtime?location=%s&apikey=%s", r.cfg.APIURL, q, r.cfg.APIKey)
+	params.Set("location", loc.Query())
+	params.Set("apikey", r.cfg.APIKey)
+	url := fmt.Sprintf("%s/%s?%s", r.cfg.APIURL, endpoint, params.Encode())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		log.Printf("tomorrow weather repo: failed to format request for %s, err:%v\n", city, err)
-		return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
+		log.Printf("tomorrow weather repo: failed to format request for %s, err:%v\n", loc, err)
+		return fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
 	}
 
 	// step 2: send request
 	resp, err := r.client.Do(req)
 	if err != nil {
-		log.Printf("tomorrow weather repo: failed to get weather for %s, err:%v\n", city, err)
-		return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrWeatherUnavailable)
+		log.Printf("tomorrow weather repo: failed to get weather for %s, err:%v\n", loc, err)
+		return fmt.Errorf("tomorrow weather repo: %w: %w", domain.ErrWeatherUnavailable, err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -65,38 +174,40 @@ func (r *TomorrowAPI) GetCurrent(ctx context.Context, city string) (domain.Weath
 	}()
 
 	// step 3: handle response
-	if resp.StatusCode == http.StatusUnauthorized {
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
 		log.Println("tomorrow weather repo: api key is invalid")
-		return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrWeatherUnavailable)
+		return fmt.Errorf("tomorrow weather repo: %w", domain.ErrProviderAuth)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Println("tomorrow weather repo: rate limit reached")
+		return fmt.Errorf("tomorrow weather repo: %w", domain.ErrQuotaExceeded)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		log.Printf("tomorrow weather repo: server error %d\n", resp.StatusCode)
+		return fmt.Errorf("tomorrow weather repo: %w", domain.ErrWeatherUnavailable)
 	}
 	if resp.StatusCode != http.StatusOK {
 		var errResp tomorrowAPIErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
 			if errResp.Code == tomorrowCityNotFoundCode {
-				log.Printf("tomorrow weather repo: city %s not found\n", city)
-				return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrCityNotFound)
+				log.Printf("tomorrow weather repo: location %s not found\n", loc)
+				return fmt.Errorf("tomorrow weather repo: %w", domain.ErrCityNotFound)
 			}
 			log.Printf("tomorrow weather repo: api error: %s\n", errResp.Message)
-			return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
+			return fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
 		}
 		log.Printf("tomorrow weather repo: unexpected error %d\n", resp.StatusCode)
-		return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
+		return fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
 	}
 
 	// step 4: parse response body
-	var responseData tomorrowAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
 		log.Printf("tomorrow weather repo: failed to decode weather data: %v\n", err)
-		return domain.Weather{}, fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
+		return fmt.Errorf("tomorrow weather repo: %w", domain.ErrInternal)
 	}
+	return nil
+}
 
-	description := fmt.Sprintf("Cloud cover: %.2f%%", responseData.Data.Values.CloudCover)
-	if responseData.Data.Values.Visibility > 0 {
-		description += fmt.Sprintf("\nVisibility: %.2f km", responseData.Data.Values.Visibility)
-	}
-	return domain.Weather{
-		Temperature: responseData.Data.Values.Temperature,
-		Humidity:    responseData.Data.Values.Humidity,
-		Description: description,
-	}, nil
+func cloudCoverDescription(cloudCover float64) string {
+	return fmt.Sprintf("Cloud cover: %.2f%%", cloudCover)
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
@@ -19,16 +20,23 @@ func TestTomorrowGetCurrentWeather_Success(t *testing.T) {
 	// Arrange
 	mockRespBody := `{
 		"data": {
+			"time": "2025-06-01T12:00:00Z",
 			"values": {
 				"temperature": 10000.0,
 				"humidity": 100.0,
 				"visibility": 12.7,
-				"cloudCover": 0.1
+				"cloudCover": 0.1,
+				"windSpeed": 4.2,
+				"windDirection": 180,
+				"pressureSurfaceLevel": 998.3,
+				"precipitationIntensity": 1.5,
+				"uvIndex": 5
 			}
 		}
 	}`
 	client := &mockHTTPClient{
 		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "50.4547,30.5238", req.URL.Query().Get("location"))
 			return &http.Response{
 				StatusCode: http.StatusOK,
 				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
@@ -39,12 +47,21 @@ func TestTomorrowGetCurrentWeather_Success(t *testing.T) {
 	repo := provider.NewTomorrowAPI(cfg, client)
 
 	// Act
-	weather, err := repo.GetCurrent(context.Background(), "Kyiv")
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, 10000.0, weather.Temperature)
 	assert.Equal(t, 100.0, weather.Humidity)
+	assert.Equal(t, 4.2, weather.WindSpeed)
+	assert.Equal(t, 180.0, weather.WindDirection)
+	assert.Equal(t, 998.3, weather.Pressure)
+	assert.Equal(t, 1.5, weather.Precipitation)
+	assert.Equal(t, 0.1, weather.CloudCover)
+	assert.Equal(t, 12.7, weather.Visibility)
+	assert.Equal(t, 5.0, weather.UVIndex)
+	assert.Equal(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), weather.ObservedAt)
+	assert.Equal(t, provider.TomorrowIOName, weather.Source)
 }
 
 func TestTomorrowGetCurrentWeather_CityNotFound(t *testing.T) {
@@ -66,7 +83,7 @@ func TestTomorrowGetCurrentWeather_CityNotFound(t *testing.T) {
 	repo := provider.NewTomorrowAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "InvalidCity")
+	_, err := repo.GetCurrent(context.Background(), unknownLocation)
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrCityNotFound)
@@ -86,11 +103,11 @@ func TestTomorrowGetCurrentWeather_APIKeyInvalid(t *testing.T) {
 	repo := provider.NewTomorrowAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
-	assert.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.ErrorIs(t, err, domain.ErrProviderAuth)
 }
 
 func TestTomorrowGetCurrentWeather_HTTPError(t *testing.T) {
@@ -104,7 +121,7 @@ func TestTomorrowGetCurrentWeather_HTTPError(t *testing.T) {
 	repo := provider.NewTomorrowAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
@@ -125,9 +142,63 @@ func TestTomorrowGetCurrentWeather_BadJSON(t *testing.T) {
 	repo := provider.NewTomorrowAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
 	assert.ErrorIs(t, err, domain.ErrInternal)
 }
+
+func TestTomorrowGetForecast_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"timelines": {
+			"hourly": [
+				{"time": "2025-06-01T00:00:00Z", "values": {"temperature": 13.0, "humidity": 70.0, "cloudCover": 10}},
+				{"time": "2025-06-01T01:00:00Z", "values": {"temperature": 12.5, "humidity": 72.0, "cloudCover": 20}}
+			],
+			"daily": [
+				{"time": "2025-06-01T00:00:00Z", "values": {"temperatureMin": 12.0, "temperatureMax": 25.0, "humidityAvg": 55.0, "cloudCoverAvg": 15}},
+				{"time": "2025-06-02T00:00:00Z", "values": {"temperatureMin": 14.0, "temperatureMax": 27.0, "humidityAvg": 50.0, "cloudCoverAvg": 5}}
+			]
+		}
+	}`
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewTomorrowAPI(cfg, client)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 1)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, forecast.Daily, 1)
+	require.Len(t, forecast.Hourly, 2)
+	assert.Equal(t, 25.0, forecast.Daily[0].MaxTemperature)
+	assert.Equal(t, 12.5, forecast.Hourly[1].Temperature)
+}
+
+func TestTomorrowGetAlerts_Unsupported(t *testing.T) {
+	// Arrange
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("alerts should not be requested")
+			return nil, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewTomorrowAPI(cfg, client)
+
+	// Act
+	_, err := repo.GetAlerts(context.Background(), kyiv)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrAlertsUnsupported)
+}
@@ -8,10 +8,13 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 )
 
+const VisualCrossingName = "visualcrossing.com"
+
 type VisualCrossingAPI struct {
 	cfg    APICfg
 	client HTTPClient
@@ -19,12 +22,45 @@ type VisualCrossingAPI struct {
 
 type visualCrossingAPIResponse struct {
 	Current struct {
-		TempC       float64 `json:"temp"`
-		Humidity    float64 `json:"humidity"`
-		Description string  `json:"conditions"`
+		DatetimeEpoch int64   `json:"datetimeEpoch"`
+		TempC         float64 `json:"temp"`
+		Humidity      float64 `json:"humidity"`
+		Description   string  `json:"conditions"`
+		WindSpeedKph  float64 `json:"windspeed"`
+		WindDir       float64 `json:"winddir"`
+		Pressure      float64 `json:"pressure"`
+		Precip        float64 `json:"precip"`
+		CloudCover    float64 `json:"cloudcover"`
+		Visibility    float64 `json:"visibility"`
+		UVIndex       float64 `json:"uvindex"`
 	} `json:"currentConditions"`
 }
 
+type visualCrossingAPIForecastResponse struct {
+	Days []struct {
+		DatetimeEpoch int64   `json:"datetimeEpoch"`
+		TempMax       float64 `json:"tempmax"`
+		TempMin       float64 `json:"tempmin"`
+		Humidity      float64 `json:"humidity"`
+		Description   string  `json:"conditions"`
+		Hours         []struct {
+			DatetimeEpoch int64   `json:"datetimeEpoch"`
+			TempC         float64 `json:"temp"`
+			Humidity      float64 `json:"humidity"`
+			Description   string  `json:"conditions"`
+		} `json:"hours"`
+	} `json:"days"`
+}
+
+type visualCrossingAPIAlertsResponse struct {
+	Alerts []struct {
+		Event      string `json:"event"`
+		Headline   string `json:"headline"`
+		OnsetEpoch int64  `json:"onsetEpoch"`
+		EndsEpoch  int64  `json:"endsEpoch"`
+	} `json:"alerts"`
+}
+
 func NewVisualCrossingAPI(cfg APICfg, client HTTPClient) *VisualCrossingAPI {
 	return &VisualCrossingAPI{
 		cfg:    cfg,
@@ -32,21 +68,113 @@ func NewVisualCrossingAPI(cfg APICfg, client HTTPClient) *VisualCrossingAPI {
 	}
 }
 
-func (r *VisualCrossingAPI) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (r *VisualCrossingAPI) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	var responseData visualCrossingAPIResponse
+	params := url.Values{}
+	params.Set("include", "current")
+	if err := r.fetch(ctx, "today", loc, params, &responseData); err != nil {
+		return domain.Weather{}, err
+	}
+
+	current := responseData.Current
+	return domain.Weather{
+		Temperature:   current.TempC,
+		Humidity:      current.Humidity,
+		Description:   current.Description,
+		WindSpeed:     current.WindSpeedKph * kphToMps,
+		WindDirection: current.WindDir,
+		Pressure:      current.Pressure,
+		Precipitation: current.Precip,
+		CloudCover:    current.CloudCover,
+		Visibility:    current.Visibility,
+		UVIndex:       current.UVIndex,
+		ObservedAt:    time.Unix(current.DatetimeEpoch, 0).UTC(),
+		Source:        VisualCrossingName,
+	}, nil
+}
+
+func (r *VisualCrossingAPI) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	var responseData visualCrossingAPIForecastResponse
+	params := url.Values{}
+	params.Set("include", "days,hours")
+	if err := r.fetch(ctx, fmt.Sprintf("next%ddays", days), loc, params, &responseData); err != nil {
+		return domain.Forecast{}, err
+	}
+
+	var forecast domain.Forecast
+	for i, day := range responseData.Days {
+		if i == days {
+			break
+		}
+		forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+			Date:           time.Unix(day.DatetimeEpoch, 0).UTC(),
+			MinTemperature: day.TempMin,
+			MaxTemperature: day.TempMax,
+			Humidity:       day.Humidity,
+			Description:    day.Description,
+		})
+		for _, hour := range day.Hours {
+			forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+				Time:        time.Unix(hour.DatetimeEpoch, 0).UTC(),
+				Temperature: hour.TempC,
+				Humidity:    hour.Humidity,
+				Description: hour.Description,
+			})
+		}
+	}
+	return forecast, nil
+}
+
+// GetAlerts returns the alerts in effect today. Visual Crossing rates neither their severity
+// nor names the areas they cover, so severity is unknown and areas are empty.
+func (r *VisualCrossingAPI) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	var responseData visualCrossingAPIAlertsResponse
+	params := url.Values{}
+	params.Set("include", "alerts")
+	if err := r.fetch(ctx, "today", loc, params, &responseData); err != nil {
+		return domain.Alerts{}, err
+	}
+
+	alerts := domain.Alerts{Source: VisualCrossingName}
+	for _, alert := range responseData.Alerts {
+		a := domain.Alert{
+			Event:     alert.Event,
+			Headline:  alert.Headline,
+			Severity:  domain.AlertSeverityUnknown,
+			Effective: time.Unix(alert.OnsetEpoch, 0).UTC(),
+		}
+		if alert.EndsEpoch != 0 {
+			a.Expires = time.Unix(alert.EndsEpoch, 0).UTC()
+		}
+		alerts.Alerts = append(alerts.Alerts, a)
+	}
+	return alerts, nil
+}
+
+// GetAirQuality fails with domain.ErrAirQualityUnsupported, the timeline elements it reads have no air quality.
+func (r *VisualCrossingAPI) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return domain.AirQuality{}, fmt.Errorf("visual crossing repo: %w", domain.ErrAirQualityUnsupported)
+}
+
+// fetch sends a GET request for the given timeline period and decodes a successful response into dest,
+// mapping api errors to domain errors.
+func (r *VisualCrossingAPI) fetch(ctx context.Context, period string, loc domain.Location, params url.Values, dest any) error {
 	// step 1: format request
-	q := url.QueryEscape(city)
-	url := fmt.Sprintf("%s/%s/today?key=%s&include=current&unitGroup=metric", r.cfg.APIURL, q, r.cfg.APIKey)
+	q := url.PathEscape(loc.Query())
+	params.Set("key", r.cfg.APIKey)
+	params.Set("unitGroup", "metric")
+	url := fmt.Sprintf("%s/%s/%s?%s", r.cfg.APIURL, q, period, params.Encode())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		log.Printf("visual crossing repo: failed to format request for %s, err:%v\n", city, err)
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
+		log.Printf("visual crossing repo: failed to format request for %s, err:%v\n", loc, err)
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
 	}
 
 	// step 2: send request
 	resp, err := r.client.Do(req)
 	if err != nil {
-		log.Printf("visual crossing repo: failed to get weather for %s, err:%v\n", city, err)
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrWeatherUnavailable)
+		log.Printf("visual crossing repo: failed to get weather for %s, err:%v\n", loc, err)
+		return fmt.Errorf("visual crossing repo: %w: %w", domain.ErrWeatherUnavailable, err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -55,44 +183,41 @@ func (r *VisualCrossingAPI) GetCurrent(ctx context.Context, city string) (domain
 	}()
 
 	// step 3: handle response
-	if resp.StatusCode == http.StatusUnauthorized {
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
 		log.Println("visual crossing repo: api key is invalid")
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrWeatherUnavailable)
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrProviderAuth)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Println("visual crossing repo: rate limit reached")
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrQuotaExceeded)
 	}
-	if resp.StatusCode == http.StatusInternalServerError {
+	if resp.StatusCode >= http.StatusInternalServerError {
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
 			log.Printf("visual crossing repo: failed to read response body: %v\n", err)
-			return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
+			return fmt.Errorf("visual crossing repo: %w", domain.ErrWeatherUnavailable)
 		}
-
-		log.Printf("visual crossing repo: api error: %s\n", string(bodyBytes))
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
+		log.Printf("visual crossing repo: server error %d: %s\n", resp.StatusCode, string(bodyBytes))
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrWeatherUnavailable)
 	}
 	if resp.StatusCode == http.StatusBadRequest {
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
 			log.Printf("visual crossing repo: failed to read response body: %v\n", err)
-			return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
+			return fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
 		}
-
 		log.Printf("visual crossing repo: api error: %s\n", string(bodyBytes))
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrCityNotFound)
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrCityNotFound)
 	}
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("visual crossing repo: unexpected error %d\n", resp.StatusCode)
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
 	}
 
 	// step 4: parse response body
-	var responseData visualCrossingAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
 		log.Printf("visual crossing repo: failed to decode weather data: %v\n", err)
-		return domain.Weather{}, fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
+		return fmt.Errorf("visual crossing repo: %w", domain.ErrInternal)
 	}
-	return domain.Weather{
-		Temperature: responseData.Current.TempC,
-		Humidity:    responseData.Current.Humidity,
-		Description: responseData.Current.Description,
-	}, nil
+	return nil
 }
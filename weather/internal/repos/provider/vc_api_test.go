@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/repos/provider"
@@ -19,13 +20,22 @@ func TestVisualCrossingGetCurrentWeather_Success(t *testing.T) {
 	// Arrange
 	mockRespBody := `{
 		"currentConditions": {
+			"datetimeEpoch": 1748779200,
 			"temp": 10000.0,
 			"humidity": 100.0,
-			"conditions": "H_E_L_L"
+			"conditions": "H_E_L_L",
+			"windspeed": 18.0,
+			"winddir": 90,
+			"pressure": 1020.0,
+			"precip": 0.0,
+			"cloudcover": 12.5,
+			"visibility": 24.1,
+			"uvindex": 7
 		}
 	}`
 	client := &mockHTTPClient{
 		doFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/50.4547,30.5238/today", req.URL.Path)
 			return &http.Response{
 				StatusCode: http.StatusOK,
 				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
@@ -36,13 +46,21 @@ func TestVisualCrossingGetCurrentWeather_Success(t *testing.T) {
 	repo := provider.NewVisualCrossingAPI(cfg, client)
 
 	// Act
-	weather, err := repo.GetCurrent(context.Background(), "Kyiv")
+	weather, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, 10000.0, weather.Temperature)
 	assert.Equal(t, 100.0, weather.Humidity)
 	assert.Equal(t, "H_E_L_L", weather.Description)
+	assert.InDelta(t, 5.0, weather.WindSpeed, 1e-9)
+	assert.Equal(t, 90.0, weather.WindDirection)
+	assert.Equal(t, 1020.0, weather.Pressure)
+	assert.Equal(t, 12.5, weather.CloudCover)
+	assert.Equal(t, 24.1, weather.Visibility)
+	assert.Equal(t, 7.0, weather.UVIndex)
+	assert.Equal(t, time.Unix(1748779200, 0).UTC(), weather.ObservedAt)
+	assert.Equal(t, provider.VisualCrossingName, weather.Source)
 }
 
 func TestVisualCrossingGetCurrentWeather_CityNotFound(t *testing.T) {
@@ -60,7 +78,7 @@ func TestVisualCrossingGetCurrentWeather_CityNotFound(t *testing.T) {
 	repo := provider.NewVisualCrossingAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "InvalidCity")
+	_, err := repo.GetCurrent(context.Background(), unknownLocation)
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrCityNotFound)
@@ -80,11 +98,11 @@ func TestVisualCrossingGetCurrentWeather_APIKeyInvalid(t *testing.T) {
 	repo := provider.NewVisualCrossingAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
-	assert.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+	assert.ErrorIs(t, err, domain.ErrProviderAuth)
 }
 
 func TestVisualCrossingGetCurrentWeather_HTTPError(t *testing.T) {
@@ -98,7 +116,7 @@ func TestVisualCrossingGetCurrentWeather_HTTPError(t *testing.T) {
 	repo := provider.NewVisualCrossingAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
@@ -119,9 +137,95 @@ func TestVisualCrossingGetCurrentWeather_BadJSON(t *testing.T) {
 	repo := provider.NewVisualCrossingAPI(cfg, client)
 
 	// Act
-	_, err := repo.GetCurrent(context.Background(), "Kyiv")
+	_, err := repo.GetCurrent(context.Background(), kyiv)
 
 	// Assert
 	require.Error(t, err)
 	assert.ErrorIs(t, err, domain.ErrInternal)
 }
+
+func TestVisualCrossingGetForecast_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"days": [{
+			"datetimeEpoch": 1748736000,
+			"tempmax": 25.0,
+			"tempmin": 12.0,
+			"humidity": 55.0,
+			"conditions": "Clear",
+			"hours": [
+				{"datetimeEpoch": 1748736000, "temp": 13.0, "humidity": 70.0, "conditions": "Clear"}
+			]
+		}, {
+			"datetimeEpoch": 1748822400,
+			"tempmax": 27.0,
+			"tempmin": 14.0,
+			"humidity": 50.0,
+			"conditions": "Rain",
+			"hours": []
+		}]
+	}`
+	var requestedPath string
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requestedPath = req.URL.Path
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewVisualCrossingAPI(cfg, client)
+
+	// Act
+	forecast, err := repo.GetForecast(context.Background(), kyiv, 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, requestedPath, "next1days")
+	require.Len(t, forecast.Daily, 1)
+	require.Len(t, forecast.Hourly, 1)
+	assert.Equal(t, 25.0, forecast.Daily[0].MaxTemperature)
+	assert.Equal(t, "Clear", forecast.Hourly[0].Description)
+}
+
+func TestVisualCrossingGetAlerts_Success(t *testing.T) {
+	// Arrange
+	mockRespBody := `{
+		"alerts": [{
+			"event": "Wind Advisory",
+			"headline": "Wind Advisory issued June 1",
+			"onsetEpoch": 1748768400,
+			"endsEpoch": 1748811600
+		}]
+	}`
+	var requested *http.Request
+	client := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requested = req
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(mockRespBody)),
+			}, nil
+		},
+	}
+	cfg := provider.APICfg{APIKey: "dummy-api-key", APIURL: "http://dummy-url.com"}
+	repo := provider.NewVisualCrossingAPI(cfg, client)
+
+	// Act
+	alerts, err := repo.GetAlerts(context.Background(), kyiv)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "alerts", requested.URL.Query().Get("include"))
+	assert.Equal(t, provider.VisualCrossingName, alerts.Source)
+	require.Len(t, alerts.Alerts, 1)
+	assert.Equal(t, domain.Alert{
+		Event:     "Wind Advisory",
+		Headline:  "Wind Advisory issued June 1",
+		Severity:  domain.AlertSeverityUnknown,
+		Effective: time.Unix(1748768400, 0).UTC(),
+		Expires:   time.Unix(1748811600, 0).UTC(),
+	}, alerts.Alerts[0])
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type providerBreaker interface {
+	State() cb.State
+	ForceOpen()
+	ForceClose()
+}
+
+type providerSwitch interface {
+	Enable()
+	Disable()
+	Enabled() bool
+}
+
+type providerStats interface {
+	Stats() domain.ProviderStats
+}
+
+type cachePurger interface {
+	PurgeLocation(ctx context.Context, loc domain.Location) (int, error)
+	PurgeAll(ctx context.Context) (int, error)
+}
+
+type replicaNotifier interface {
+	Publish(ctx context.Context, inv cache.Invalidation) error
+	OnInvalidate(handler func(inv cache.Invalidation))
+}
+
+// settings other replicas are told about, "provider:<name>:breaker" is open or closed,
+// "provider:<name>:enabled" is true or false
+const (
+	settingPrefix  = "provider:"
+	breakerSetting = "breaker"
+	enabledSetting = "enabled"
+	breakerOpen    = "open"
+	breakerClosed  = "closed"
+)
+
+// AdminProvider is what the admin service can see and change of one provider.
+type AdminProvider struct {
+	Name    string
+	Breaker providerBreaker
+	Switch  providerSwitch
+	Stats   providerStats
+}
+
+// AdminService lets operators inspect and override providers and the cache during incidents.
+// Every replica has its own breakers and switches, so overrides are passed on to the others through replicas.
+type AdminService struct {
+	providers []AdminProvider
+	cache     cachePurger
+	resolver  locationResolver
+	replicas  replicaNotifier
+}
+
+func NewAdminService(
+	cache cachePurger, resolver locationResolver, replicas replicaNotifier, providers ...AdminProvider,
+) *AdminService {
+	s := &AdminService{providers: providers, cache: cache, resolver: resolver, replicas: replicas}
+	replicas.OnInvalidate(s.applySettings)
+	return s
+}
+
+// Providers returns the status of every provider in the configured order.
+func (s *AdminService) Providers() []domain.ProviderStatus {
+	statuses := make([]domain.ProviderStatus, 0, len(s.providers))
+	for _, provider := range s.providers {
+		statuses = append(statuses, domain.ProviderStatus{
+			Name:          provider.Name,
+			Enabled:       provider.Switch.Enabled(),
+			Breaker:       provider.Breaker.State().String(),
+			ProviderStats: provider.Stats.Stats(),
+		})
+	}
+	return statuses
+}
+
+// SetBreaker forces the breaker of the named provider open or closed on every replica.
+func (s *AdminService) SetBreaker(ctx context.Context, name string, open bool) error {
+	provider, err := s.provider(name)
+	if err != nil {
+		return err
+	}
+	setBreaker(provider, open)
+	state := breakerClosed
+	if open {
+		state = breakerOpen
+	}
+	return s.notifyReplicas(ctx, name, breakerSetting, state)
+}
+
+// SetEnabled takes the named provider out of rotation or puts it back on every replica.
+func (s *AdminService) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	provider, err := s.provider(name)
+	if err != nil {
+		return err
+	}
+	setEnabled(provider, enabled)
+	return s.notifyReplicas(ctx, name, enabledSetting, strconv.FormatBool(enabled))
+}
+
+func setBreaker(provider AdminProvider, open bool) {
+	if open {
+		provider.Breaker.ForceOpen()
+	} else {
+		provider.Breaker.ForceClose()
+	}
+	log.Printf("admin service: breaker of %s forced %s\n", provider.Name, provider.Breaker.State())
+}
+
+func setEnabled(provider AdminProvider, enabled bool) {
+	if enabled {
+		provider.Switch.Enable()
+	} else {
+		provider.Switch.Disable()
+	}
+	log.Printf("admin service: %s enabled: %t\n", provider.Name, enabled)
+}
+
+// notifyReplicas tells the other replicas to change the setting of the named provider too.
+// The change already applies here when they can't be told, the override can be repeated.
+func (s *AdminService) notifyReplicas(ctx context.Context, name, setting, value string) error {
+	inv := cache.Invalidation{Settings: map[string]string{settingPrefix + name + ":" + setting: value}}
+	if err := s.replicas.Publish(ctx, inv); err != nil {
+		log.Printf("admin service: failed to tell other replicas %s %s is %s: %v\n", name, setting, value, err)
+		return fmt.Errorf("admin service: %w", domain.ErrInternal)
+	}
+	return nil
+}
+
+// applySettings applies the provider overrides another replica was given.
+func (s *AdminService) applySettings(inv cache.Invalidation) {
+	for key, value := range inv.Settings {
+		rest, ok := strings.CutPrefix(key, settingPrefix)
+		if !ok {
+			continue
+		}
+		i := strings.LastIndex(rest, ":")
+		if i == -1 {
+			continue
+		}
+		provider, err := s.provider(rest[:i])
+		if err != nil {
+			log.Printf("admin service: other replica changed %s: %v\n", key, err)
+			continue
+		}
+		switch rest[i+1:] {
+		case breakerSetting:
+			setBreaker(provider, value == breakerOpen)
+		case enabledSetting:
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				log.Printf("admin service: other replica set %s to %q: %v\n", key, value, err)
+				continue
+			}
+			setEnabled(provider, enabled)
+		}
+	}
+}
+
+// PurgeCache drops the cached weather of city on every replica, or the whole cache when city is empty,
+// and returns how many entries were dropped.
+func (s *AdminService) PurgeCache(ctx context.Context, city string) (int, error) {
+	if city == "" {
+		purged, err := s.cache.PurgeAll(ctx)
+		if err != nil {
+			return purged, fmt.Errorf("admin service: %w", err)
+		}
+		return purged, nil
+	}
+	loc, err := s.resolver.Resolve(city)
+	if err != nil {
+		return 0, fmt.Errorf("admin service: %w", err)
+	}
+	purged, err := s.cache.PurgeLocation(ctx, loc)
+	if err != nil {
+		return purged, fmt.Errorf("admin service: %w", err)
+	}
+	return purged, nil
+}
+
+func (s *AdminService) provider(name string) (AdminProvider, error) {
+	for _, provider := range s.providers {
+		if provider.Name == name {
+			return provider, nil
+		}
+	}
+	return AdminProvider{}, fmt.Errorf("admin service: %s: %w", name, domain.ErrProviderNotFound)
+}
@@ -0,0 +1,160 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cb"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSwitch struct {
+	disabled bool
+}
+
+func (m *mockSwitch) Enable()       { m.disabled = false }
+func (m *mockSwitch) Disable()      { m.disabled = true }
+func (m *mockSwitch) Enabled() bool { return !m.disabled }
+
+type mockStats struct {
+	stats domain.ProviderStats
+}
+
+func (m *mockStats) Stats() domain.ProviderStats {
+	return m.stats
+}
+
+type mockPurger struct {
+	purged []domain.Location
+	all    bool
+}
+
+func (m *mockPurger) PurgeLocation(ctx context.Context, loc domain.Location) (int, error) {
+	m.purged = append(m.purged, loc)
+	return 2, nil
+}
+
+func (m *mockPurger) PurgeAll(ctx context.Context) (int, error) {
+	m.all = true
+	return 10, nil
+}
+
+// mockReplicas passes what one replica publishes to the handlers of all the others, like the Redis channel does.
+type mockReplicas struct {
+	handlers map[*mockReplica]func(inv cache.Invalidation)
+}
+
+type mockReplica struct {
+	bus *mockReplicas
+}
+
+func (m *mockReplicas) replica() *mockReplica {
+	return &mockReplica{bus: m}
+}
+
+func (m *mockReplica) Publish(ctx context.Context, inv cache.Invalidation) error {
+	for replica, handler := range m.bus.handlers {
+		if replica != m {
+			handler(inv)
+		}
+	}
+	return nil
+}
+
+func (m *mockReplica) OnInvalidate(handler func(inv cache.Invalidation)) {
+	m.bus.handlers[m] = handler
+}
+
+func newTestAdminReplica(
+	purger *mockPurger, resolver *mockResolver, replicas *mockReplicas,
+) (*services.AdminService, *cb.CircuitBreaker, *mockSwitch) {
+	breaker := cb.NewCircuitBreaker(time.Minute, 1, 1)
+	switcher := &mockSwitch{}
+	stats := &mockStats{stats: domain.ProviderStats{Calls: 10, Failures: 1, LastError: "boom", Latency: time.Second}}
+	service := services.NewAdminService(purger, resolver, replicas.replica(),
+		services.AdminProvider{Name: "weatherapi.com", Breaker: breaker, Switch: switcher, Stats: stats})
+	return service, breaker, switcher
+}
+
+func newTestAdminService(purger *mockPurger, resolver *mockResolver) (*services.AdminService, *cb.CircuitBreaker, *mockSwitch) {
+	return newTestAdminReplica(purger, resolver, &mockReplicas{handlers: make(map[*mockReplica]func(cache.Invalidation))})
+}
+
+func TestAdminService_Providers(t *testing.T) {
+	// Arrange
+	service, breaker, switcher := newTestAdminService(&mockPurger{}, &mockResolver{})
+
+	// Act
+	require.NoError(t, service.SetBreaker(context.Background(), "weatherapi.com", true))
+	require.NoError(t, service.SetEnabled(context.Background(), "weatherapi.com", false))
+	statuses := service.Providers()
+
+	// Assert
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "weatherapi.com", statuses[0].Name)
+	assert.False(t, statuses[0].Enabled)
+	assert.Equal(t, "open", statuses[0].Breaker)
+	assert.Equal(t, 1, statuses[0].Failures)
+	assert.Equal(t, "boom", statuses[0].LastError)
+	assert.Equal(t, cb.Open, breaker.State())
+	assert.True(t, switcher.disabled)
+}
+
+func TestAdminService_OverridesReachOtherReplicas(t *testing.T) {
+	// Arrange
+	replicas := &mockReplicas{handlers: make(map[*mockReplica]func(cache.Invalidation))}
+	service, _, _ := newTestAdminReplica(&mockPurger{}, &mockResolver{}, replicas)
+	_, otherBreaker, otherSwitch := newTestAdminReplica(&mockPurger{}, &mockResolver{}, replicas)
+
+	// Act
+	require.NoError(t, service.SetBreaker(context.Background(), "weatherapi.com", true))
+	require.NoError(t, service.SetEnabled(context.Background(), "weatherapi.com", false))
+
+	// Assert
+	assert.Equal(t, cb.Open, otherBreaker.State())
+	assert.True(t, otherSwitch.disabled)
+}
+
+func TestAdminService_UnknownProvider(t *testing.T) {
+	// Arrange
+	service, _, _ := newTestAdminService(&mockPurger{}, &mockResolver{})
+
+	// Act
+	breakerErr := service.SetBreaker(context.Background(), "example.com", false)
+	switchErr := service.SetEnabled(context.Background(), "example.com", true)
+
+	// Assert
+	require.ErrorIs(t, breakerErr, domain.ErrProviderNotFound)
+	require.ErrorIs(t, switchErr, domain.ErrProviderNotFound)
+}
+
+func TestAdminService_PurgeCache(t *testing.T) {
+	// Arrange
+	purger := &mockPurger{}
+	resolver := &mockResolver{}
+	resolver.On("Resolve", "Kyiv").Return(kyiv, nil)
+	resolver.On("Resolve", "ZUUUBR").Return(domain.Location{}, &domain.LocationNotFoundError{Query: "ZUUUBR"})
+	service, _, _ := newTestAdminService(purger, resolver)
+
+	// Act
+	purgedCity, cityErr := service.PurgeCache(context.Background(), "Kyiv")
+	_, unknownErr := service.PurgeCache(context.Background(), "ZUUUBR")
+	purgedAll, allErr := service.PurgeCache(context.Background(), "")
+
+	// Assert
+	require.NoError(t, cityErr)
+	require.NoError(t, allErr)
+	assert.True(t, errors.Is(unknownErr, domain.ErrCityNotFound))
+	assert.Equal(t, 2, purgedCity)
+	assert.Equal(t, 10, purgedAll)
+	assert.Equal(t, []domain.Location{kyiv}, purger.purged)
+	assert.True(t, purger.all)
+}
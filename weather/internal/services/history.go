@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+// historyQueueBatches is how many batches of readings may wait for the database before new ones are dropped.
+const historyQueueBatches = 10
+
+type observationRepo interface {
+	Save(ctx context.Context, observations []domain.Observation) error
+	Range(ctx context.Context, locationKey string, from, to time.Time) ([]domain.Observation, error)
+	Downsample(ctx context.Context, before time.Time, interval time.Duration) (int64, error)
+	DeleteRaw(ctx context.Context, before time.Time) (int64, error)
+	DeleteDownsampled(ctx context.Context, before time.Time) (int64, error)
+}
+
+type HistoryConfig struct {
+	// how long readings are kept as they were fetched
+	Retention time.Duration
+	// older readings are averaged into one per provider and interval, 0 deletes them instead
+	DownsampleInterval time.Duration
+	// how long downsampled readings are kept
+	DownsampledRetention time.Duration
+	// readings are written in batches of up to BatchSize, at least every FlushInterval
+	BatchSize     int
+	FlushInterval time.Duration
+	// how often old readings are downsampled and deleted
+	MaintenanceInterval time.Duration
+	// longest a single database operation may take
+	Timeout time.Duration
+}
+
+// HistoryService stores the readings fetched from providers and answers history queries.
+// Readings are queued and written in batches in the background, so a slow database never
+// holds up a weather request, and the queue drops readings rather than grow without bound.
+type HistoryService struct {
+	repo  observationRepo
+	cfg   HistoryConfig
+	queue chan domain.Observation
+}
+
+func NewHistoryService(repo observationRepo, cfg HistoryConfig) *HistoryService {
+	return &HistoryService{
+		repo:  repo,
+		cfg:   cfg,
+		queue: make(chan domain.Observation, cfg.BatchSize*historyQueueBatches),
+	}
+}
+
+// Record queues a reading of loc by the named provider to be stored.
+// Readings without an observation time are stored as observed now.
+func (s *HistoryService) Record(loc domain.Location, provider string, weather domain.Weather) {
+	weather.Source = provider
+	if weather.ObservedAt.IsZero() {
+		weather.ObservedAt = time.Now()
+	}
+	weather.ObservedAt = weather.ObservedAt.UTC()
+	select {
+	case s.queue <- domain.Observation{LocationKey: loc.Key(), Weather: weather, Samples: 1}:
+	default:
+		log.Printf("history service: queue is full, dropped a reading for %s\n", loc)
+	}
+}
+
+// Run writes queued readings and maintains the stored ones until ctx is done, then writes what is left in the queue.
+func (s *HistoryService) Run(ctx context.Context) {
+	flush := time.NewTicker(s.cfg.FlushInterval)
+	defer flush.Stop()
+	maintenance := time.NewTicker(s.cfg.MaintenanceInterval)
+	defer maintenance.Stop()
+	s.Maintain(ctx, time.Now())
+
+	var batch []domain.Observation
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case o := <-s.queue:
+					batch = append(batch, o)
+				default:
+					s.save(context.Background(), batch)
+					return
+				}
+			}
+		case o := <-s.queue:
+			batch = append(batch, o)
+			if len(batch) >= s.cfg.BatchSize {
+				s.save(ctx, batch)
+				batch = nil
+			}
+		case <-flush.C:
+			s.save(ctx, batch)
+			batch = nil
+		case now := <-maintenance.C:
+			s.Maintain(ctx, now)
+		}
+	}
+}
+
+func (s *HistoryService) save(ctx context.Context, batch []domain.Observation) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	if err := s.repo.Save(ctx, batch); err != nil {
+		log.Printf("history service: failed to store %d readings: %v\n", len(batch), err)
+	}
+}
+
+// Maintain downsamples or deletes the readings past the retention and deletes the downsampled ones past theirs.
+func (s *HistoryService) Maintain(ctx context.Context, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	rawBefore := now.Add(-s.cfg.Retention)
+	if s.cfg.DownsampleInterval > 0 {
+		n, err := s.repo.Downsample(ctx, rawBefore, s.cfg.DownsampleInterval)
+		if err != nil {
+			log.Printf("history service: failed to downsample readings: %v\n", err)
+		} else if n > 0 {
+			log.Printf("history service: downsampled %d readings\n", n)
+		}
+	} else {
+		n, err := s.repo.DeleteRaw(ctx, rawBefore)
+		if err != nil {
+			log.Printf("history service: failed to delete old readings: %v\n", err)
+		} else if n > 0 {
+			log.Printf("history service: deleted %d old readings\n", n)
+		}
+	}
+	n, err := s.repo.DeleteDownsampled(ctx, now.Add(-s.cfg.DownsampledRetention))
+	if err != nil {
+		log.Printf("history service: failed to delete old downsampled readings: %v\n", err)
+	} else if n > 0 {
+		log.Printf("history service: deleted %d old downsampled readings\n", n)
+	}
+}
+
+// GetHistory returns the readings of every provider for loc observed in [from, to), oldest first, in the given units.
+func (s *HistoryService) GetHistory(
+	ctx context.Context, loc domain.Location, from, to time.Time, units domain.Units,
+) ([]domain.Observation, error) {
+	observations, err := s.repo.Range(ctx, loc.Key(), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("history service: %w", err)
+	}
+	for i := range observations {
+		observations[i].Weather = convertWeather(observations[i].Weather, units)
+	}
+	return observations, nil
+}
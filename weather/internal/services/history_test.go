@@ -0,0 +1,173 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockObservationRepo struct {
+	mu               sync.Mutex
+	saved            chan []domain.Observation
+	stored           []domain.Observation
+	downsampleBefore time.Time
+	downsampleEvery  time.Duration
+	rawBefore        time.Time
+	downsampledFrom  time.Time
+}
+
+func (m *mockObservationRepo) Save(ctx context.Context, observations []domain.Observation) error {
+	m.saved <- observations
+	return nil
+}
+
+func (m *mockObservationRepo) Range(ctx context.Context, locationKey string, from, to time.Time) ([]domain.Observation, error) {
+	return m.stored, nil
+}
+
+func (m *mockObservationRepo) Downsample(ctx context.Context, before time.Time, interval time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downsampleBefore, m.downsampleEvery = before, interval
+	return 0, nil
+}
+
+func (m *mockObservationRepo) DeleteRaw(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rawBefore = before
+	return 0, nil
+}
+
+func (m *mockObservationRepo) DeleteDownsampled(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downsampledFrom = before
+	return 0, nil
+}
+
+func historyConfig() services.HistoryConfig {
+	return services.HistoryConfig{
+		Retention:            7 * 24 * time.Hour,
+		DownsampleInterval:   time.Hour,
+		DownsampledRetention: 365 * 24 * time.Hour,
+		BatchSize:            2,
+		FlushInterval:        time.Hour,
+		MaintenanceInterval:  time.Hour,
+		Timeout:              time.Second,
+	}
+}
+
+func TestHistoryService_Run(t *testing.T) {
+	t.Run("SavesFullBatches", func(t *testing.T) {
+		// Arrange
+		repo := &mockObservationRepo{saved: make(chan []domain.Observation, 1)}
+		svc := services.NewHistoryService(repo, historyConfig())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go svc.Run(ctx)
+		observed := time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("EEST", 3*60*60))
+
+		// Act
+		svc.Record(kyiv, "weatherapi.com", domain.Weather{Temperature: 21, Source: "weatherapi.com", ObservedAt: observed})
+		svc.Record(kyiv, "backup", domain.Weather{Temperature: 22, Source: "tomorrow.io"})
+
+		// Assert
+		select {
+		case batch := <-repo.saved:
+			require.Len(t, batch, 2)
+			assert.Equal(t, kyiv.Key(), batch[0].LocationKey)
+			assert.Equal(t, 1, batch[0].Samples)
+			assert.Equal(t, observed.UTC(), batch[0].Weather.ObservedAt)
+			assert.Equal(t, "backup", batch[1].Weather.Source, "readings are stored under the configured provider name")
+			assert.WithinDuration(t, time.Now(), batch[1].Weather.ObservedAt, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("batch was not saved")
+		}
+	})
+
+	t.Run("SavesQueueOnStop", func(t *testing.T) {
+		// Arrange
+		repo := &mockObservationRepo{saved: make(chan []domain.Observation, 1)}
+		svc := services.NewHistoryService(repo, historyConfig())
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		svc.Record(kyiv, "weatherapi.com", domain.Weather{Temperature: 21})
+
+		// Act
+		go func() {
+			svc.Run(ctx)
+			close(done)
+		}()
+		cancel()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("history service did not stop")
+		}
+		require.Len(t, repo.saved, 1)
+		assert.Len(t, <-repo.saved, 1)
+	})
+}
+
+func TestHistoryService_Maintain(t *testing.T) {
+	now := time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Downsample", func(t *testing.T) {
+		// Arrange
+		repo := &mockObservationRepo{}
+		svc := services.NewHistoryService(repo, historyConfig())
+
+		// Act
+		svc.Maintain(context.Background(), now)
+
+		// Assert
+		assert.Equal(t, now.Add(-7*24*time.Hour), repo.downsampleBefore)
+		assert.Equal(t, time.Hour, repo.downsampleEvery)
+		assert.True(t, repo.rawBefore.IsZero(), "downsampled readings must not be deleted")
+		assert.Equal(t, now.Add(-365*24*time.Hour), repo.downsampledFrom)
+	})
+
+	t.Run("DeleteWithoutDownsampling", func(t *testing.T) {
+		// Arrange
+		repo := &mockObservationRepo{}
+		cfg := historyConfig()
+		cfg.DownsampleInterval = 0
+		svc := services.NewHistoryService(repo, cfg)
+
+		// Act
+		svc.Maintain(context.Background(), now)
+
+		// Assert
+		assert.True(t, repo.downsampleBefore.IsZero())
+		assert.Equal(t, now.Add(-7*24*time.Hour), repo.rawBefore)
+	})
+}
+
+func TestHistoryService_GetHistory(t *testing.T) {
+	// Arrange
+	repo := &mockObservationRepo{stored: []domain.Observation{
+		{LocationKey: kyiv.Key(), Weather: domain.Weather{Temperature: 20, WindSpeed: 10}, Samples: 12},
+	}}
+	svc := services.NewHistoryService(repo, historyConfig())
+
+	// Act
+	observations, err := svc.GetHistory(context.Background(), kyiv, time.Now().Add(-time.Hour), time.Now(), domain.UnitsImperial)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, observations, 1)
+	assert.InDelta(t, 68.0, observations[0].Weather.Temperature, 1e-9)
+	assert.InDelta(t, 22.369, observations[0].Weather.WindSpeed, 1e-3)
+	assert.Equal(t, 12, observations[0].Samples)
+}
@@ -2,27 +2,135 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 )
 
 type weatherRepo interface {
-	GetCurrent(ctx context.Context, city string) (domain.Weather, error)
+	GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error)
+	GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error)
+	GetCurrentBatch(ctx context.Context, locs []domain.Location) []domain.CurrentResult
+	GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error)
+	GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error)
+}
+
+type locationResolver interface {
+	Resolve(query string) (domain.Location, error)
+	Nearest(lat, lon float64) (domain.Location, bool)
+	Suggest(name string) []string
 }
 
 type WeatherService struct {
-	repo weatherRepo
+	repo     weatherRepo
+	resolver locationResolver
+}
+
+func NewWeatherService(repo weatherRepo, resolver locationResolver) *WeatherService {
+	return &WeatherService{repo: repo, resolver: resolver}
+}
+
+// Resolve maps a user supplied city name to the canonical location used for lookups.
+func (s *WeatherService) Resolve(city string) (domain.Location, error) {
+	loc, err := s.resolver.Resolve(city)
+	if err != nil {
+		return loc, fmt.Errorf("weather service: %w", err)
+	}
+	return loc, nil
+}
+
+// notFound turns the providers not knowing a city the gazetteer doesn't know either into
+// *domain.LocationNotFoundError with the known cities the user may have meant.
+func (s *WeatherService) notFound(loc domain.Location, err error) error {
+	if !loc.ByName || !errors.Is(err, domain.ErrCityNotFound) {
+		return err
+	}
+	return &domain.LocationNotFoundError{Query: loc.Query(), Suggestions: s.resolver.Suggest(loc.Name)}
+}
+
+// ResolveCoordinates builds the location for an arbitrary point, named after the nearest known city if there is one.
+// Weather is still looked up for the point itself, not for the city.
+func (s *WeatherService) ResolveCoordinates(lat, lon float64) domain.Location {
+	loc := domain.Location{Lat: lat, Lon: lon}
+	if nearest, ok := s.resolver.Nearest(lat, lon); ok {
+		loc.Name = nearest.Name
+		loc.Country = nearest.Country
+		loc.Timezone = nearest.Timezone
+	}
+	return loc
+}
+
+// GetCurrent returns current weather in the given units. The repo always works in metric,
+// so the cache holds a single entry per location regardless of what clients ask for.
+func (s *WeatherService) GetCurrent(ctx context.Context, loc domain.Location, units domain.Units) (domain.Weather, error) {
+	w, err := s.repo.GetCurrent(ctx, loc)
+	if err != nil {
+		return w, fmt.Errorf("weather service: %w", s.notFound(loc, err))
+	}
+	return convertWeather(w, units), nil
+}
+
+// GetCurrentBatch returns current weather for every query, in the same order. Queries that share a
+// location are fetched once, each result is converted to the units of its own query.
+func (s *WeatherService) GetCurrentBatch(ctx context.Context, queries []domain.CurrentQuery) []domain.CurrentResult {
+	index := make(map[string]int, len(queries))
+	unique := make([]domain.Location, 0, len(queries))
+	for _, q := range queries {
+		key := q.Location.Key()
+		if _, ok := index[key]; !ok {
+			index[key] = len(unique)
+			unique = append(unique, q.Location)
+		}
+	}
+
+	fetched := s.repo.GetCurrentBatch(ctx, unique)
+	results := make([]domain.CurrentResult, len(queries))
+	for i, q := range queries {
+		r := fetched[index[q.Location.Key()]]
+		if r.Err != nil {
+			results[i].Err = fmt.Errorf("weather service: %w", s.notFound(q.Location, r.Err))
+			continue
+		}
+		results[i].Weather = convertWeather(r.Weather, q.Units)
+	}
+	return results
 }
 
-func NewWeatherService(repo weatherRepo) *WeatherService {
-	return &WeatherService{repo: repo}
+func (s *WeatherService) GetForecast(ctx context.Context, loc domain.Location, days int, units domain.Units) (domain.Forecast, error) {
+	f, err := s.repo.GetForecast(ctx, loc, days)
+	if err != nil {
+		return f, fmt.Errorf("weather service: %w", s.notFound(loc, err))
+	}
+	return convertForecast(f, units), nil
+}
+
+// GetAlerts returns the weather alerts in effect for loc by when they took effect. Alerts that expired
+// since they were fetched are left out, as cached alerts may be served for a while after that.
+func (s *WeatherService) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	alerts, err := s.repo.GetAlerts(ctx, loc)
+	if err != nil {
+		return alerts, fmt.Errorf("weather service: %w", s.notFound(loc, err))
+	}
+	now := time.Now()
+	inEffect := make([]domain.Alert, 0, len(alerts.Alerts))
+	for _, alert := range alerts.Alerts {
+		if !alert.Expired(now) {
+			inEffect = append(inEffect, alert)
+		}
+	}
+	slices.SortStableFunc(inEffect, func(a, b domain.Alert) int { return a.Effective.Compare(b.Effective) })
+	alerts.Alerts = inEffect
+	return alerts, nil
 }
 
-func (s *WeatherService) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	w, err := s.repo.GetCurrent(ctx, city)
+// GetAirQuality returns the air quality near loc. Concentrations are in µg/m³ whatever the units.
+func (s *WeatherService) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	airQuality, err := s.repo.GetAirQuality(ctx, loc)
 	if err != nil {
-		return w, fmt.Errorf("weather service: %w", err)
+		return airQuality, fmt.Errorf("weather service: %w", s.notFound(loc, err))
 	}
-	return w, nil
+	return airQuality, nil
 }
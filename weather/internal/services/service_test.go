@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
@@ -14,12 +15,45 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var (
+	kyiv    = domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+	unknown = domain.Location{ID: 1, Name: "ZUUUBR", Country: "ZZ"}
+)
+
+type mockResolver struct {
+	mock.Mock
+}
+
+func (m *mockResolver) Resolve(query string) (domain.Location, error) {
+	args := m.Called(query)
+	loc, ok := args.Get(0).(domain.Location)
+	if !ok {
+		return domain.Location{}, fmt.Errorf("mock: expected domain.Location, got %T", loc)
+	}
+	return loc, args.Error(1)
+}
+
+func (m *mockResolver) Suggest(name string) []string {
+	args := m.Called(name)
+	suggestions, _ := args.Get(0).([]string)
+	return suggestions
+}
+
+func (m *mockResolver) Nearest(lat, lon float64) (domain.Location, bool) {
+	args := m.Called(lat, lon)
+	loc, ok := args.Get(0).(domain.Location)
+	if !ok {
+		return domain.Location{}, false
+	}
+	return loc, args.Bool(1)
+}
+
 type mockWeatherRepo struct {
 	mock.Mock
 }
 
-func (m *mockWeatherRepo) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
-	args := m.Called(ctx, city)
+func (m *mockWeatherRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	args := m.Called(ctx, loc)
 	weather, ok := args.Get(0).(domain.Weather)
 	if !ok {
 		return domain.Weather{}, fmt.Errorf("mock: expected models.Weather, got %T", weather)
@@ -27,22 +61,58 @@ func (m *mockWeatherRepo) GetCurrent(ctx context.Context, city string) (domain.W
 	return weather, args.Error(1)
 }
 
+func (m *mockWeatherRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	args := m.Called(ctx, loc, days)
+	forecast, ok := args.Get(0).(domain.Forecast)
+	if !ok {
+		return domain.Forecast{}, fmt.Errorf("mock: expected domain.Forecast, got %T", forecast)
+	}
+	return forecast, args.Error(1)
+}
+
+func (m *mockWeatherRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	args := m.Called(ctx, loc)
+	alerts, ok := args.Get(0).(domain.Alerts)
+	if !ok {
+		return domain.Alerts{}, fmt.Errorf("mock: expected domain.Alerts, got %T", alerts)
+	}
+	return alerts, args.Error(1)
+}
+
+func (m *mockWeatherRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	args := m.Called(ctx, loc)
+	airQuality, ok := args.Get(0).(domain.AirQuality)
+	if !ok {
+		return domain.AirQuality{}, fmt.Errorf("mock: expected domain.AirQuality, got %T", airQuality)
+	}
+	return airQuality, args.Error(1)
+}
+
+func (m *mockWeatherRepo) GetCurrentBatch(ctx context.Context, locs []domain.Location) []domain.CurrentResult {
+	args := m.Called(ctx, locs)
+	results, ok := args.Get(0).([]domain.CurrentResult)
+	if !ok {
+		return nil
+	}
+	return results
+}
+
 func TestWeatherService_GetCurrent_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockWeatherRepo)
-	service := services.NewWeatherService(mockRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
 	expected := domain.Weather{
 		Temperature: 20.0,
 		Humidity:    80.0,
 		Description: "Sunny",
 	}
 	mockRepo.
-		On("GetCurrent", mock.Anything, "Kyiv").
+		On("GetCurrent", mock.Anything, kyiv).
 		Return(expected, nil)
 
 	// Act
 	ctx := context.Background()
-	actual, err := service.GetCurrent(ctx, "Kyiv")
+	actual, err := service.GetCurrent(ctx, kyiv, domain.UnitsMetric)
 
 	// Assert
 	mockRepo.AssertExpectations(t)
@@ -54,18 +124,311 @@ func TestWeatherService_GetCurrent_Success(t *testing.T) {
 func TestWeatherService_GetCurrent_Error(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockWeatherRepo)
-	service := services.NewWeatherService(mockRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
 	mockRepo.
-		On("GetCurrent", mock.Anything, "ZUUUBR").
+		On("GetCurrent", mock.Anything, unknown).
 		Return(domain.Weather{}, domain.ErrCityNotFound)
 
 	// Act
 	ctx := context.Background()
-	_, err := service.GetCurrent(ctx, "ZUUUBR")
+	_, err := service.GetCurrent(ctx, unknown, domain.UnitsMetric)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrCityNotFound)
+
+}
+
+func TestWeatherService_GetCurrent_NotFoundByName(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	resolver := new(mockResolver)
+	service := services.NewWeatherService(mockRepo, resolver)
+	kyivv := domain.Location{Name: "kyivv", ByName: true}
+	mockRepo.On("GetCurrent", mock.Anything, kyivv).Return(domain.Weather{}, domain.ErrCityNotFound)
+	resolver.On("Suggest", "kyivv").Return([]string{"Kyiv, UA"})
+
+	// Act
+	_, err := service.GetCurrent(context.Background(), kyivv, domain.UnitsMetric)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	resolver.AssertExpectations(t)
+	require.ErrorIs(t, err, domain.ErrCityNotFound)
+	var notFound *domain.LocationNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, []string{"Kyiv, UA"}, notFound.Suggestions)
+}
+
+func TestWeatherService_GetCurrent_UnavailableByNameWithoutSuggestions(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	resolver := new(mockResolver)
+	service := services.NewWeatherService(mockRepo, resolver)
+	kyivv := domain.Location{Name: "kyivv", ByName: true}
+	mockRepo.On("GetCurrent", mock.Anything, kyivv).Return(domain.Weather{}, domain.ErrWeatherUnavailable)
+
+	// Act
+	_, err := service.GetCurrent(context.Background(), kyivv, domain.UnitsMetric)
+
+	// Assert
+	resolver.AssertNotCalled(t, "Suggest", mock.Anything)
+	require.ErrorIs(t, err, domain.ErrWeatherUnavailable)
+}
+
+func TestWeatherService_GetForecast_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	expected := domain.Forecast{
+		Daily: []domain.DailyForecast{{MinTemperature: 10, MaxTemperature: 20, Description: "Sunny"}},
+	}
+	mockRepo.
+		On("GetForecast", mock.Anything, kyiv, 3).
+		Return(expected, nil)
+
+	// Act
+	actual, err := service.GetForecast(context.Background(), kyiv, 3, domain.UnitsMetric)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestWeatherService_GetForecast_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	mockRepo.
+		On("GetForecast", mock.Anything, unknown, 3).
+		Return(domain.Forecast{}, domain.ErrCityNotFound)
+
+	// Act
+	_, err := service.GetForecast(context.Background(), unknown, 3, domain.UnitsMetric)
 
 	// Assert
 	mockRepo.AssertExpectations(t)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, domain.ErrCityNotFound)
+}
+
+func TestWeatherService_GetAlerts(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	now := time.Now()
+	mockRepo.
+		On("GetAlerts", mock.Anything, kyiv).
+		Return(domain.Alerts{Source: "weatherapi.com", Alerts: []domain.Alert{
+			{Event: "Heat", Effective: now.Add(-time.Hour), Expires: now.Add(time.Hour)},
+			{Event: "Frost", Effective: now.Add(-2 * time.Hour), Expires: now.Add(-time.Minute)},
+			{Event: "Wind", Effective: now.Add(-3 * time.Hour)},
+		}}, nil)
+
+	// Act
+	actual, err := service.GetAlerts(context.Background(), kyiv)
 
+	// Assert
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Len(t, actual.Alerts, 2, "expired alerts are left out")
+	assert.Equal(t, "Wind", actual.Alerts[0].Event)
+	assert.Equal(t, "Heat", actual.Alerts[1].Event)
+	assert.Equal(t, "weatherapi.com", actual.Source)
+}
+
+func TestWeatherService_GetAlerts_Unsupported(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	mockRepo.
+		On("GetAlerts", mock.Anything, kyiv).
+		Return(domain.Alerts{}, domain.ErrAlertsUnsupported)
+
+	// Act
+	_, err := service.GetAlerts(context.Background(), kyiv)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrAlertsUnsupported)
+}
+
+func TestWeatherService_GetAirQuality(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	expected := domain.AirQuality{AQI: 57, PM25: 12.3, Source: "weatherapi.com"}
+	mockRepo.
+		On("GetAirQuality", mock.Anything, kyiv).
+		Return(expected, nil)
+
+	// Act
+	actual, err := service.GetAirQuality(context.Background(), kyiv)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestWeatherService_GetAirQuality_Unsupported(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	mockRepo.
+		On("GetAirQuality", mock.Anything, kyiv).
+		Return(domain.AirQuality{}, domain.ErrAirQualityUnsupported)
+
+	// Act
+	_, err := service.GetAirQuality(context.Background(), kyiv)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrAirQualityUnsupported)
+}
+
+func TestWeatherService_GetCurrent_Imperial(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	metric := domain.Weather{
+		Temperature:   20,
+		Humidity:      50,
+		WindSpeed:     10,
+		WindDirection: 180,
+		Pressure:      1013.25,
+		Precipitation: 25.4,
+		Visibility:    16.09344,
+	}
+	mockRepo.
+		On("GetCurrent", mock.Anything, kyiv).
+		Return(metric, nil)
+
+	// Act
+	actual, err := service.GetCurrent(context.Background(), kyiv, domain.UnitsImperial)
+
+	// Assert
+	require.NoError(t, err)
+	assert.InDelta(t, 68.0, actual.Temperature, 0.001)
+	assert.InDelta(t, 22.369, actual.WindSpeed, 0.001)
+	assert.InDelta(t, 29.921, actual.Pressure, 0.001)
+	assert.InDelta(t, 1.0, actual.Precipitation, 0.001)
+	assert.InDelta(t, 10.0, actual.Visibility, 0.001)
+	assert.Equal(t, metric.Humidity, actual.Humidity)
+	assert.Equal(t, metric.WindDirection, actual.WindDirection)
+}
+
+func TestWeatherService_GetForecast_Imperial(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	metric := domain.Forecast{
+		Hourly: []domain.HourlyForecast{{Temperature: 0, Humidity: 80}},
+		Daily:  []domain.DailyForecast{{MinTemperature: -40, MaxTemperature: 100}},
+	}
+	mockRepo.
+		On("GetForecast", mock.Anything, kyiv, 3).
+		Return(metric, nil)
+
+	// Act
+	actual, err := service.GetForecast(context.Background(), kyiv, 3, domain.UnitsImperial)
+
+	// Assert
+	require.NoError(t, err)
+	assert.InDelta(t, 32.0, actual.Hourly[0].Temperature, 0.001)
+	assert.Equal(t, 80.0, actual.Hourly[0].Humidity)
+	assert.InDelta(t, -40.0, actual.Daily[0].MinTemperature, 0.001)
+	assert.InDelta(t, 212.0, actual.Daily[0].MaxTemperature, 0.001)
+	assert.Equal(t, 0.0, metric.Hourly[0].Temperature, "cached metric values must stay untouched")
+}
+
+func TestWeatherService_GetCurrentBatch(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockWeatherRepo)
+	service := services.NewWeatherService(mockRepo, new(mockResolver))
+	kyivWeather := domain.Weather{Temperature: 20, Description: "Sunny"}
+	mockRepo.
+		On("GetCurrentBatch", mock.Anything, []domain.Location{kyiv, unknown}).
+		Return([]domain.CurrentResult{{Weather: kyivWeather}, {Err: domain.ErrCityNotFound}})
+
+	// Act
+	actual := service.GetCurrentBatch(context.Background(), []domain.CurrentQuery{
+		{Location: kyiv, Units: domain.UnitsMetric},
+		{Location: unknown, Units: domain.UnitsMetric},
+		{Location: kyiv, Units: domain.UnitsImperial},
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	require.Len(t, actual, 3)
+	require.NoError(t, actual[0].Err)
+	assert.Equal(t, kyivWeather, actual[0].Weather)
+	assert.ErrorIs(t, actual[1].Err, domain.ErrCityNotFound)
+	require.NoError(t, actual[2].Err)
+	assert.InDelta(t, 68.0, actual[2].Weather.Temperature, 0.001)
+}
+
+func TestWeatherService_Resolve_Success(t *testing.T) {
+	// Arrange
+	resolver := new(mockResolver)
+	service := services.NewWeatherService(new(mockWeatherRepo), resolver)
+	resolver.On("Resolve", "Київ").Return(kyiv, nil)
+
+	// Act
+	actual, err := service.Resolve("Київ")
+
+	// Assert
+	resolver.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, kyiv, actual)
+}
+
+func TestWeatherService_Resolve_NotFound(t *testing.T) {
+	// Arrange
+	resolver := new(mockResolver)
+	service := services.NewWeatherService(new(mockWeatherRepo), resolver)
+	notFound := &domain.LocationNotFoundError{Query: "Kyivv", Suggestions: []string{"Kyiv, UA"}}
+	resolver.On("Resolve", "Kyivv").Return(domain.Location{}, notFound)
+
+	// Act
+	_, err := service.Resolve("Kyivv")
+
+	// Assert
+	resolver.AssertExpectations(t)
+	require.ErrorIs(t, err, domain.ErrCityNotFound)
+	var actual *domain.LocationNotFoundError
+	require.ErrorAs(t, err, &actual)
+	assert.Equal(t, []string{"Kyiv, UA"}, actual.Suggestions)
+}
+
+func TestWeatherService_ResolveCoordinates_NearCity(t *testing.T) {
+	// Arrange
+	resolver := new(mockResolver)
+	service := services.NewWeatherService(new(mockWeatherRepo), resolver)
+	resolver.On("Nearest", 50.4, 30.6).Return(kyiv, true)
+
+	// Act
+	actual := service.ResolveCoordinates(50.4, 30.6)
+
+	// Assert
+	resolver.AssertExpectations(t)
+	assert.Equal(t, domain.Location{Name: "Kyiv", Country: "UA", Lat: 50.4, Lon: 30.6}, actual)
+	assert.Equal(t, "geo:50.40,30.60", actual.Key())
+}
+
+func TestWeatherService_ResolveCoordinates_Nowhere(t *testing.T) {
+	// Arrange
+	resolver := new(mockResolver)
+	service := services.NewWeatherService(new(mockWeatherRepo), resolver)
+	resolver.On("Nearest", -0.001, -30.0).Return(domain.Location{}, false)
+
+	// Act
+	actual := service.ResolveCoordinates(-0.001, -30.0)
+
+	// Assert
+	resolver.AssertExpectations(t)
+	assert.Equal(t, domain.Location{Lat: -0.001, Lon: -30.0}, actual)
+	assert.Equal(t, "geo:0.00,-30.00", actual.Key())
 }
@@ -0,0 +1,53 @@
+package services
+
+import "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+
+const (
+	fahrenheitScale  = 9.0 / 5.0
+	fahrenheitOffset = 32.0
+	mpsToMph         = 3600 / 1609.344
+	hPaToInHg        = 1 / 33.8639
+	mmToIn           = 1 / 25.4
+	kmToMi           = 1 / 1.609344
+)
+
+// convertWeather expresses canonical metric weather in the requested units.
+func convertWeather(w domain.Weather, units domain.Units) domain.Weather {
+	if units != domain.UnitsImperial {
+		return w
+	}
+	w.Temperature = celsiusToFahrenheit(w.Temperature)
+	w.WindSpeed *= mpsToMph
+	w.Pressure *= hPaToInHg
+	w.Precipitation *= mmToIn
+	w.Visibility *= kmToMi
+	return w
+}
+
+// convertForecast expresses canonical metric forecast in the requested units.
+// The slices are copied, so values shared with the cache are never modified.
+func convertForecast(f domain.Forecast, units domain.Units) domain.Forecast {
+	if units != domain.UnitsImperial {
+		return f
+	}
+	converted := domain.Forecast{
+		Hourly: make([]domain.HourlyForecast, len(f.Hourly)),
+		Daily:  make([]domain.DailyForecast, len(f.Daily)),
+		Age:    f.Age,
+		Stale:  f.Stale,
+	}
+	for i, hour := range f.Hourly {
+		hour.Temperature = celsiusToFahrenheit(hour.Temperature)
+		converted.Hourly[i] = hour
+	}
+	for i, day := range f.Daily {
+		day.MinTemperature = celsiusToFahrenheit(day.MinTemperature)
+		day.MaxTemperature = celsiusToFahrenheit(day.MaxTemperature)
+		converted.Daily[i] = day
+	}
+	return converted
+}
+
+func celsiusToFahrenheit(c float64) float64 {
+	return c*fahrenheitScale + fahrenheitOffset
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+const (
+	hoursPerDay = 24
+	// share of past demand for an hour kept each time the hour is warmed, so the hot set follows changes
+	demandDecay = 0.5
+	// locations whose demand fell below this in every hour are forgotten
+	minDemand = 0.1
+	// warm-up of a window stops after this many failures in a row, to spare provider quotas
+	maxWarmupFailures = 3
+
+	warmupRefreshed = "refreshed"
+	warmupFresh     = "fresh"
+	warmupFailed    = "failed"
+)
+
+type warmCache interface {
+	Warm(ctx context.Context, loc domain.Location, until time.Time) (refreshed bool, err error)
+}
+
+type warmupMetrics interface {
+	CacheWarmup(result string)
+}
+
+type locationDemand struct {
+	loc   domain.Location
+	hours [hoursPerDay]float64
+}
+
+// Demand counts requests per location and hour of the day. Scheduled emails go out at the same
+// hours every day, so the locations asked for in an hour are likely to be asked for again at that hour.
+type Demand struct {
+	mu        sync.Mutex
+	locations map[string]*locationDemand
+}
+
+func NewDemand() *Demand {
+	return &Demand{locations: make(map[string]*locationDemand)}
+}
+
+// Record counts a request for loc made at t.
+func (d *Demand) Record(loc domain.Location, t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := loc.Key()
+	ld, ok := d.locations[key]
+	if !ok {
+		ld = &locationDemand{loc: loc}
+		d.locations[key] = ld
+	}
+	ld.hours[t.Hour()]++
+}
+
+// Top returns up to n locations with demand at the given hour, the most requested first.
+func (d *Demand) Top(n, hour int) []domain.Location {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	hot := make([]*locationDemand, 0, len(d.locations))
+	for _, ld := range d.locations {
+		if ld.hours[hour] >= minDemand {
+			hot = append(hot, ld)
+		}
+	}
+	sort.Slice(hot, func(i, j int) bool {
+		if hot[i].hours[hour] != hot[j].hours[hour] {
+			return hot[i].hours[hour] > hot[j].hours[hour]
+		}
+		return hot[i].loc.Key() < hot[j].loc.Key()
+	})
+
+	top := make([]domain.Location, 0, min(n, len(hot)))
+	for _, ld := range hot[:min(n, len(hot))] {
+		top = append(top, ld.loc)
+	}
+	return top
+}
+
+// Decay fades the past demand at hour and forgets locations nobody has asked for in a while.
+func (d *Demand) Decay(hour int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, ld := range d.locations {
+		ld.hours[hour] *= demandDecay
+		forgotten := true
+		for _, demand := range ld.hours {
+			if demand >= minDemand {
+				forgotten = false
+				break
+			}
+		}
+		if forgotten {
+			delete(d.locations, key)
+		}
+	}
+}
+
+type WarmupConfig struct {
+	// how long before every full hour the warm-up starts, shorter than the soft TTL of current weather
+	Lead time.Duration
+	// most locations refreshed per window
+	Size int
+	// most refreshes per second
+	Rate float64
+}
+
+// WarmupService refreshes the cached weather of the most requested locations shortly before every
+// full hour, when the hourly emails are sent, so they don't all miss the cache at once.
+// Refreshes are spread out to stay within provider quotas, and values that will still be fresh
+// when the window opens are skipped, which also keeps replicas from refreshing the same location.
+type WarmupService struct {
+	cache   warmCache
+	demand  *Demand
+	metrics warmupMetrics
+	cfg     WarmupConfig
+}
+
+func NewWarmupService(cache warmCache, demand *Demand, metrics warmupMetrics, cfg WarmupConfig) *WarmupService {
+	return &WarmupService{cache: cache, demand: demand, metrics: metrics, cfg: cfg}
+}
+
+// Run warms the cache before every full hour until ctx is done.
+func (s *WarmupService) Run(ctx context.Context) {
+	for {
+		window := time.Now().Add(s.cfg.Lead).Truncate(time.Hour).Add(time.Hour)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(window.Add(-s.cfg.Lead))):
+		}
+		s.Warm(ctx, window)
+	}
+}
+
+// Warm refreshes the locations in demand at the hour of window, stopping when the window opens.
+func (s *WarmupService) Warm(ctx context.Context, window time.Time) {
+	ctx, cancel := context.WithDeadline(ctx, window)
+	defer cancel()
+	hot := s.demand.Top(s.cfg.Size, window.Hour())
+	s.demand.Decay(window.Hour())
+
+	var throttle <-chan time.Time
+	if s.cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / s.cfg.Rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	refreshed, failures := 0, 0
+	for i, loc := range hot {
+		if i > 0 && throttle != nil {
+			select {
+			case <-ctx.Done():
+				log.Printf("warmup: window opened, %d of %d locations left\n", len(hot)-i, len(hot))
+				return
+			case <-throttle:
+			}
+		}
+
+		ok, err := s.cache.Warm(ctx, loc, window)
+		switch {
+		case err != nil:
+			s.metrics.CacheWarmup(warmupFailed)
+			log.Printf("warmup: failed to refresh %s: %v\n", loc, err)
+			failures++
+			if failures >= maxWarmupFailures {
+				log.Printf("warmup: %d failures in a row, giving up on this window\n", failures)
+				return
+			}
+		case ok:
+			s.metrics.CacheWarmup(warmupRefreshed)
+			refreshed++
+			failures = 0
+		default:
+			s.metrics.CacheWarmup(warmupFresh)
+			failures = 0
+		}
+	}
+	log.Printf("warmup: refreshed %d of %d locations before %s\n", refreshed, len(hot), window.Format(time.TimeOnly))
+}
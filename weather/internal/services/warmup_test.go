@@ -0,0 +1,152 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var lviv = domain.Location{ID: 702550, Name: "Lviv", Country: "UA", Lat: 49.83826, Lon: 24.02324}
+
+type mockWarmCache struct {
+	mu     sync.Mutex
+	warmed []domain.Location
+	fresh  map[string]bool
+	err    error
+}
+
+func (m *mockWarmCache) Warm(ctx context.Context, loc domain.Location, until time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.warmed = append(m.warmed, loc)
+	if m.err != nil {
+		return false, m.err
+	}
+	return !m.fresh[loc.Key()], nil
+}
+
+type mockWarmupMetrics struct {
+	mu      sync.Mutex
+	results map[string]int
+}
+
+func (m *mockWarmupMetrics) CacheWarmup(result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[result]++
+}
+
+func at(hour, minute int) time.Time {
+	return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestDemand(main *testing.T) {
+	main.Run("TopByHour", func(t *testing.T) {
+		// Arrange
+		demand := services.NewDemand()
+		demand.Record(kyiv, at(7, 0))
+		demand.Record(lviv, at(7, 1))
+		demand.Record(lviv, at(7, 2))
+		demand.Record(kyiv, at(8, 0))
+
+		// Act
+		atSeven := demand.Top(10, 7)
+		atEight := demand.Top(10, 8)
+		atNine := demand.Top(10, 9)
+		limited := demand.Top(1, 7)
+
+		// Assert
+		assert.Equal(t, []domain.Location{lviv, kyiv}, atSeven)
+		assert.Equal(t, []domain.Location{kyiv}, atEight)
+		assert.Empty(t, atNine)
+		assert.Equal(t, []domain.Location{lviv}, limited)
+	})
+
+	main.Run("DecayForgetsOldDemand", func(t *testing.T) {
+		// Arrange
+		demand := services.NewDemand()
+		demand.Record(kyiv, at(7, 0))
+
+		// Act
+		for range 3 {
+			demand.Decay(7)
+		}
+		stillHot := demand.Top(10, 7)
+		demand.Decay(7)
+		forgotten := demand.Top(10, 7)
+
+		// Assert
+		assert.Equal(t, []domain.Location{kyiv}, stillHot)
+		assert.Empty(t, forgotten)
+	})
+}
+
+func TestWarmupService_Warm(main *testing.T) {
+	newDemand := func(hour int, locs ...domain.Location) *services.Demand {
+		demand := services.NewDemand()
+		for _, loc := range locs {
+			demand.Record(loc, time.Now().Truncate(time.Hour).Add(time.Duration(hour)*time.Hour))
+		}
+		return demand
+	}
+
+	main.Run("RefreshesHotSet", func(t *testing.T) {
+		// Arrange
+		window := time.Now().Truncate(time.Hour).Add(time.Hour)
+		warmCache := &mockWarmCache{fresh: map[string]bool{kyiv.Key(): true}}
+		metrics := &mockWarmupMetrics{results: make(map[string]int)}
+		service := services.NewWarmupService(warmCache, newDemand(1, kyiv, lviv), metrics, services.WarmupConfig{Size: 10})
+
+		// Act
+		service.Warm(context.Background(), window)
+
+		// Assert
+		assert.ElementsMatch(t, []domain.Location{kyiv, lviv}, warmCache.warmed)
+		assert.Equal(t, map[string]int{"fresh": 1, "refreshed": 1}, metrics.results)
+	})
+
+	main.Run("GivesUpAfterFailures", func(t *testing.T) {
+		// Arrange
+		window := time.Now().Truncate(time.Hour).Add(time.Hour)
+		locs := []domain.Location{kyiv, lviv, {ID: 1}, {ID: 2}, {ID: 3}}
+		warmCache := &mockWarmCache{err: domain.ErrWeatherUnavailable}
+		metrics := &mockWarmupMetrics{results: make(map[string]int)}
+		service := services.NewWarmupService(warmCache, newDemand(1, locs...), metrics, services.WarmupConfig{Size: 10})
+
+		// Act
+		service.Warm(context.Background(), window)
+
+		// Assert
+		assert.Len(t, warmCache.warmed, 3)
+		assert.Equal(t, 3, metrics.results["failed"])
+	})
+
+	main.Run("StopsWhenWindowOpens", func(t *testing.T) {
+		// Arrange
+		window := time.Now().Add(50 * time.Millisecond)
+		locs := []domain.Location{kyiv, lviv, {ID: 1}, {ID: 2}, {ID: 3}}
+		warmCache := &mockWarmCache{}
+		demand := services.NewDemand()
+		for _, loc := range locs {
+			demand.Record(loc, window)
+		}
+		cfg := services.WarmupConfig{Size: 10, Rate: 20}
+		service := services.NewWarmupService(warmCache, demand, &mockWarmupMetrics{results: make(map[string]int)}, cfg)
+
+		// Act
+		start := time.Now()
+		service.Warm(context.Background(), window)
+
+		// Assert
+		require.Less(t, time.Since(start), 200*time.Millisecond)
+		assert.Less(t, len(warmCache.warmed), len(locs), "refreshes should be spread out and stop at the window")
+	})
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+)
+
+type currentRepo interface {
+	GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error)
+}
+
+type weatherHub interface {
+	Subscribe(ctx context.Context, key string) <-chan domain.Weather
+	Publish(key string, weather domain.Weather)
+}
+
+type poller struct {
+	watchers int
+	cancel   context.CancelFunc
+}
+
+// WatchService streams current weather to long-lived watchers. Updates come from the hub,
+// which is fed every time this replica refreshes the cached value of a location from providers,
+// and by the pollers of watched locations whenever they read a reading watchers have not been sent.
+type WatchService struct {
+	repo     currentRepo
+	hub      weatherHub
+	interval time.Duration
+
+	// PollRepo is read by the pollers instead of repo when set, so polls can skip what only
+	// client requests should go through, like demand accounting
+	PollRepo currentRepo
+
+	mu      sync.Mutex
+	pollers map[string]*poller
+}
+
+func NewWatchService(repo currentRepo, hub weatherHub, interval time.Duration) *WatchService {
+	return &WatchService{
+		repo:     repo,
+		hub:      hub,
+		interval: interval,
+		pollers:  make(map[string]*poller),
+	}
+}
+
+func (s *WatchService) pollRepo() currentRepo {
+	if s.PollRepo != nil {
+		return s.PollRepo
+	}
+	return s.repo
+}
+
+// Watch sends the current weather for loc, then every refreshed reading, in the given units.
+// The channel is closed once ctx is done. A consumer that falls behind skips stale readings
+// instead of holding them up. While a location has watchers it is re-read every interval,
+// so the cache keeps being refreshed even when nobody else asks for it.
+func (s *WatchService) Watch(ctx context.Context, loc domain.Location, units domain.Units) (<-chan domain.Weather, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// subscribe before the first read, so a refresh in between is not lost
+	updates := s.hub.Subscribe(ctx, loc.Key())
+	current, err := s.repo.GetCurrent(ctx, loc)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("watch service: %w", err)
+	}
+	s.keepFresh(ctx, loc, current)
+
+	out := make(chan domain.Weather, 1)
+	out <- convertWeather(current, units)
+	go func() {
+		defer cancel()
+		defer close(out)
+		for weather := range updates {
+			select {
+			case out <- convertWeather(weather, units):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// keepFresh makes sure a single poller re-reads loc while ctx or any other watcher of loc is alive.
+// current is what the watcher was sent first, a new poller starts from it.
+func (s *WatchService) keepFresh(ctx context.Context, loc domain.Location, current domain.Weather) {
+	key := loc.Key()
+
+	s.mu.Lock()
+	p, ok := s.pollers[key]
+	if !ok {
+		pollCtx, cancel := context.WithCancel(context.Background())
+		p = &poller{cancel: cancel}
+		s.pollers[key] = p
+		go s.poll(pollCtx, loc, current)
+	}
+	p.watchers++
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		p.watchers--
+		if p.watchers == 0 {
+			p.cancel()
+			delete(s.pollers, key)
+		}
+	}()
+}
+
+// poll re-reads loc every interval and publishes the reading when it differs from the last one
+// watchers were sent. Only provider fetches of this replica reach the hub on their own, a reading
+// another replica stored or one served from a cache tier would otherwise never be streamed.
+func (s *WatchService) poll(ctx context.Context, loc domain.Location, last domain.Weather) {
+	key := loc.Key()
+	// everything published for key, by this poller or not, is what watchers were sent last
+	published := s.hub.Subscribe(ctx, key)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case weather, ok := <-published:
+			if !ok {
+				return
+			}
+			last = weather
+		case <-ticker.C:
+			reqCtx, cancel := context.WithTimeout(ctx, s.interval)
+			weather, err := s.pollRepo().GetCurrent(reqCtx, loc)
+			cancel()
+			if err != nil {
+				log.Printf("watch service: failed to refresh %s: %v\n", loc, err)
+				continue
+			}
+			// a cache miss goes to the providers and is published on the way, take it in before comparing
+			select {
+			case fetched, ok := <-published:
+				if ok {
+					last = fetched
+				}
+			default:
+			}
+			if !sameReading(weather, last) {
+				last = weather
+				s.hub.Publish(key, weather)
+			}
+		}
+	}
+}
+
+// sameReading reports whether a and b are the same provider reading, however long each has been cached.
+func sameReading(a, b domain.Weather) bool {
+	if !a.ObservedAt.Equal(b.ObservedAt) {
+		return false
+	}
+	a.Age, b.Age = 0, 0
+	a.Stale, b.Stale = false, false
+	a.ObservedAt, b.ObservedAt = time.Time{}, time.Time{}
+	return a == b
+}
@@ -0,0 +1,180 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/hub"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingRepo struct {
+	calls   atomic.Int32
+	weather domain.Weather
+	err     error
+}
+
+func (r *countingRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	r.calls.Add(1)
+	return r.weather, r.err
+}
+
+// cachedRepo answers with whatever is set, like a cache another replica fills.
+type cachedRepo struct {
+	mu      sync.Mutex
+	weather domain.Weather
+}
+
+func (r *cachedRepo) set(weather domain.Weather) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.weather = weather
+}
+
+func (r *cachedRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// each read is a little older, which does not make it a new reading
+	r.weather.Age += time.Millisecond
+	return r.weather, nil
+}
+
+func receive(t *testing.T, updates <-chan domain.Weather) domain.Weather {
+	t.Helper()
+	select {
+	case w, ok := <-updates:
+		require.True(t, ok, "updates closed unexpectedly")
+		return w
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+		return domain.Weather{}
+	}
+}
+
+func TestWatchService_Watch(main *testing.T) {
+	main.Run("CurrentThenUpdates", func(t *testing.T) {
+		// Arrange
+		weatherHub := hub.New[domain.Weather]()
+		repo := &countingRepo{weather: domain.Weather{Temperature: 0}}
+		service := services.NewWatchService(repo, weatherHub, time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		updates, err := service.Watch(ctx, kyiv, domain.UnitsImperial)
+		require.NoError(t, err)
+		first := receive(t, updates)
+		weatherHub.Publish(kyiv.Key(), domain.Weather{Temperature: 100})
+		second := receive(t, updates)
+
+		// Assert
+		assert.InDelta(t, 32.0, first.Temperature, 0.001)
+		assert.InDelta(t, 212.0, second.Temperature, 0.001)
+	})
+
+	main.Run("CancelClosesUpdates", func(t *testing.T) {
+		// Arrange
+		weatherHub := hub.New[domain.Weather]()
+		service := services.NewWatchService(&countingRepo{}, weatherHub, time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		updates, err := service.Watch(ctx, kyiv, domain.UnitsMetric)
+		require.NoError(t, err)
+		receive(t, updates)
+
+		// Act
+		cancel()
+
+		// Assert
+		select {
+		case _, ok := <-updates:
+			assert.False(t, ok, "updates should be closed")
+		case <-time.After(time.Second):
+			t.Fatal("updates were not closed")
+		}
+		assert.Eventually(t, func() bool { return weatherHub.Subscribers(kyiv.Key()) == 0 }, time.Second, time.Millisecond)
+	})
+
+	main.Run("PollsWhileWatched", func(t *testing.T) {
+		// Arrange
+		repo := &countingRepo{}
+		service := services.NewWatchService(repo, hub.New[domain.Weather](), time.Millisecond)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Act
+		_, err := service.Watch(ctx, kyiv, domain.UnitsMetric)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Eventually(t, func() bool { return repo.calls.Load() > 2 }, time.Second, time.Millisecond)
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		calls := repo.calls.Load()
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, calls, repo.calls.Load(), "polling should stop with the last watcher")
+	})
+
+	main.Run("PollsThroughPollRepo", func(t *testing.T) {
+		// Arrange
+		repo, pollRepo := &countingRepo{}, &countingRepo{}
+		service := services.NewWatchService(repo, hub.New[domain.Weather](), time.Millisecond)
+		service.PollRepo = pollRepo
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		_, err := service.Watch(ctx, kyiv, domain.UnitsMetric)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Eventually(t, func() bool { return pollRepo.calls.Load() > 2 }, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), repo.calls.Load(), "only the first read of a watcher should go through repo")
+	})
+
+	main.Run("PublishesReadingsServedFromCache", func(t *testing.T) {
+		// Arrange
+		weatherHub := hub.New[domain.Weather]()
+		repo := &cachedRepo{}
+		repo.set(domain.Weather{Temperature: 10})
+		service := services.NewWatchService(repo, weatherHub, time.Millisecond)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		updates, err := service.Watch(ctx, kyiv, domain.UnitsMetric)
+		require.NoError(t, err)
+		first := receive(t, updates)
+
+		// Act
+		// another replica refreshed the cache, nothing is published on this one
+		repo.set(domain.Weather{Temperature: 12, Age: time.Second})
+		second := receive(t, updates)
+
+		// Assert
+		assert.Equal(t, 10.0, first.Temperature)
+		assert.Equal(t, 12.0, second.Temperature)
+		select {
+		case w := <-updates:
+			t.Fatalf("unchanged reading sent again: %+v", w)
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+
+	main.Run("Error", func(t *testing.T) {
+		// Arrange
+		weatherHub := hub.New[domain.Weather]()
+		service := services.NewWatchService(&countingRepo{err: domain.ErrCityNotFound}, weatherHub, time.Hour)
+
+		// Act
+		_, err := service.Watch(context.Background(), unknown, domain.UnitsMetric)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrCityNotFound)
+		assert.Eventually(t, func() bool { return weatherHub.Subscribers(unknown.Key()) == 0 }, time.Second, time.Millisecond)
+	})
+}
@@ -0,0 +1,45 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/test/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAirQualityGRPCHandler(main *testing.T) {
+	main.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetAirQualityRequest{
+			City: "Kyiv",
+		}
+
+		resp, err := WeathClient.GetAirQuality(ctx, req)
+		require.NoError(t, err, "Expected no error for valid city")
+		require.Equal(t, int32(57), resp.AirQuality.Aqi, "Expected the EPA index of the mocked concentrations")
+		require.Equal(t, 12.3, resp.AirQuality.Pm2_5)
+	})
+
+	main.Run("InvalidCity", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetAirQualityRequest{
+			City: mock.CityDoesNotExist,
+		}
+
+		resp, err := WeathClient.GetAirQuality(ctx, req)
+		require.Error(t, err, "Expected error for invalid city")
+		require.Nil(t, resp, "Expected nil response for invalid city")
+
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Expected gRPC status error")
+		require.Equal(t, codes.NotFound, st.Code(), "Expected NotFound status code")
+	})
+}
@@ -0,0 +1,45 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/test/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAlertsGRPCHandler(main *testing.T) {
+	main.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetAlertsRequest{
+			City: "Kyiv",
+		}
+
+		resp, err := WeathClient.GetAlerts(ctx, req)
+		require.NoError(t, err, "Expected no error for valid city")
+		require.NotEmpty(t, resp.Alerts, "Expected alerts in effect")
+		require.Equal(t, pb.AlertSeverity_ALERT_SEVERITY_MODERATE, resp.Alerts[0].Severity)
+	})
+
+	main.Run("InvalidCity", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetAlertsRequest{
+			City: mock.CityDoesNotExist,
+		}
+
+		resp, err := WeathClient.GetAlerts(ctx, req)
+		require.Error(t, err, "Expected error for invalid city")
+		require.Nil(t, resp, "Expected nil response for invalid city")
+
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Expected gRPC status error")
+		require.Equal(t, codes.NotFound, st.Code(), "Expected NotFound status code")
+	})
+}
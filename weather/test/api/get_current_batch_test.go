@@ -0,0 +1,39 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGetCurrentBatchGRPCHandler(main *testing.T) {
+	main.Run("MixedResults", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetCurrentBatchRequest{
+			Items: []*pb.GetCurrentRequest{
+				{Query: &pb.GetCurrentRequest_City{City: "Kyiv"}},
+				{Query: &pb.GetCurrentRequest_City{City: "Kyivv"}},
+				{Query: &pb.GetCurrentRequest_City{City: "Київ"}, Units: pb.Units_UNITS_IMPERIAL},
+			},
+		}
+
+		resp, err := WeathClient.GetCurrentBatch(ctx, req)
+		require.NoError(t, err, "Expected no error for batch request")
+		require.Len(t, resp.GetResults(), 3, "Expected one result per item")
+		require.NotNil(t, resp.GetResults()[0].GetCurrent(), "Expected weather for Kyiv")
+		require.Equal(t, int32(codes.NotFound), resp.GetResults()[1].GetError().GetCode(), "Expected NotFound for misspelled city")
+		require.Contains(t, resp.GetResults()[1].GetError().GetSuggestions(), "Kyiv, UA")
+		require.Equal(t, pb.Units_UNITS_IMPERIAL, resp.GetResults()[2].GetCurrent().GetUnits(), "Expected imperial units")
+	})
+
+	main.Run("Empty", func(t *testing.T) {
+		_, err := WeathClient.GetCurrentBatch(context.Background(), &pb.GetCurrentBatchRequest{})
+		require.Error(t, err, "Expected error for empty batch")
+	})
+}
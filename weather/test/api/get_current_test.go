@@ -4,72 +4,68 @@ package api_test
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"testing"
 
-	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha1"
-	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/app"
-	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
 	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/test/mock"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 )
 
 func TestGetCurrentWeatherGRPCHandler(main *testing.T) {
-	// setup fake weather APIs
-	freeWeatherAPI := mock.NewFreeWeatherAPI()
-	defer freeWeatherAPI.Close()
-	tomorrowAPI := mock.NewTomorrowAPI()
-	defer tomorrowAPI.Close()
-	vcAPI := mock.NewVisualCrossingAPI()
-	defer vcAPI.Close()
-
-	// setup config
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatal(err)
-	}
-	cfg.FreeWeather.URL = freeWeatherAPI.URL
-	cfg.TomorrowWeather.URL = tomorrowAPI.URL
-	cfg.VisualCrossing.URL = vcAPI.URL
-	fmt.Println(cfg)
-
-	// start App
-	a := app.New(cfg)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go a.Run(ctx)
-
-	// setup grpc client
-	opt := grpc.WithTransportCredentials(insecure.NewCredentials())
-	conn, err := grpc.NewClient(cfg.GRPCSrv.Addr(), opt)
-	require.NoError(main, err)
-	weathClient := pb.NewWeatherServiceClient(conn)
-
 	main.Run("Success", func(t *testing.T) {
 		ctx := context.Background()
 
 		req := &pb.GetCurrentRequest{
-			City: "Kyiv",
+			Query: &pb.GetCurrentRequest_City{City: "Kyiv"},
 		}
 
-		resp, err := weathClient.GetCurrent(ctx, req)
+		resp, err := WeathClient.GetCurrent(ctx, req)
 		require.NoError(t, err, "Expected no error for valid city")
 		require.NotNil(t, resp, "Expected non-nil response")
+		require.Equal(t, int64(703448), resp.GetLocation().GetId(), "Expected city resolved to Kyiv")
+	})
+
+	main.Run("Coordinates", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetCurrentRequest{
+			Query: &pb.GetCurrentRequest_Coordinates{Coordinates: &pb.Coordinates{Lat: 50.45, Lon: 30.52}},
+		}
+
+		resp, err := WeathClient.GetCurrent(ctx, req)
+		require.NoError(t, err, "Expected no error for valid coordinates")
+		require.Equal(t, "Kyiv", resp.GetLocation().GetName(), "Expected coordinates named after nearest city")
+	})
+
+	main.Run("Misspelled", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetCurrentRequest{
+			Query: &pb.GetCurrentRequest_City{City: "Kyivv"},
+		}
+
+		_, err := WeathClient.GetCurrent(ctx, req)
+		require.Error(t, err, "Expected error for misspelled city")
+
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Expected gRPC status error")
+		require.Equal(t, codes.NotFound, st.Code(), "Expected NotFound status code")
+		require.Len(t, st.Details(), 1, "Expected suggestions in status details")
+		suggestions, ok := st.Details()[0].(*pb.CitySuggestions)
+		require.True(t, ok, "Expected CitySuggestions details")
+		require.Contains(t, suggestions.GetSuggestions(), "Kyiv, UA")
 	})
 
 	main.Run("InvalidCity", func(t *testing.T) {
 		ctx := context.Background()
 
 		req := &pb.GetCurrentRequest{
-			City: mock.CityDoesNotExist,
+			Query: &pb.GetCurrentRequest_City{City: mock.CityDoesNotExist},
 		}
 
-		resp, err := weathClient.GetCurrent(ctx, req)
+		resp, err := WeathClient.GetCurrent(ctx, req)
 		require.Error(t, err, "Expected error for invalid city")
 		require.Nil(t, resp, "Expected nil response for invalid city")
 
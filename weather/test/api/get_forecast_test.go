@@ -0,0 +1,47 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/test/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetForecastGRPCHandler(main *testing.T) {
+	main.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetForecastRequest{
+			City: "Kyiv",
+			Days: 1,
+		}
+
+		resp, err := WeathClient.GetForecast(ctx, req)
+		require.NoError(t, err, "Expected no error for valid city")
+		require.NotEmpty(t, resp.Daily, "Expected daily forecast entries")
+		require.NotEmpty(t, resp.Hourly, "Expected hourly forecast entries")
+	})
+
+	main.Run("InvalidCity", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := &pb.GetForecastRequest{
+			City: mock.CityDoesNotExist,
+			Days: 1,
+		}
+
+		resp, err := WeathClient.GetForecast(ctx, req)
+		require.Error(t, err, "Expected error for invalid city")
+		require.Nil(t, resp, "Expected nil response for invalid city")
+
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Expected gRPC status error")
+		require.Equal(t, codes.NotFound, st.Code(), "Expected NotFound status code")
+	})
+}
@@ -0,0 +1,96 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/app"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/test/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var WeathClient pb.WeatherServiceClient
+
+func TestMain(m *testing.M) {
+	// setup fake weather APIs
+	freeWeatherAPI := mock.NewFreeWeatherAPI()
+	tomorrowAPI := mock.NewTomorrowAPI()
+	vcAPI := mock.NewVisualCrossingAPI()
+	openMeteoAPI := mock.NewOpenMeteoAPI()
+	openWeatherMapAPI := mock.NewOpenWeatherMapAPI()
+	closeAPIs := func() {
+		freeWeatherAPI.Close()
+		tomorrowAPI.Close()
+		vcAPI.Close()
+		openMeteoAPI.Close()
+		openWeatherMapAPI.Close()
+	}
+
+	// setup config
+	cfg, err := config.Load()
+	if err != nil {
+		closeAPIs()
+		log.Panic(err)
+	}
+	mockURLs := map[string]string{
+		"weatherapi.com":     freeWeatherAPI.URL,
+		"tomorrow.io":        tomorrowAPI.URL,
+		"visualcrossing.com": vcAPI.URL,
+		"open-meteo.com":     openMeteoAPI.URL,
+		"openweathermap.org": openWeatherMapAPI.URL,
+	}
+	for i, provider := range cfg.Providers.List {
+		cfg.Providers.List[i].URL = mockURLs[provider.Kind]
+	}
+	fmt.Println(cfg)
+
+	// start App
+	a := app.New(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		if runErr := a.Run(ctx); runErr != nil {
+			log.Println(runErr)
+		}
+	}()
+
+	// wait on grpc server start
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", cfg.GRPCSrv.Addr())
+		if err == nil {
+			_ = conn.Close()
+			log.Println("gRPC server is ready")
+			break
+		}
+
+		if time.Now().After(deadline) {
+			log.Panicf("gRPC server did not become ready within 3 second at %s", cfg.GRPCSrv.Addr())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// setup grpc client
+	opt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	conn, err := grpc.NewClient(cfg.GRPCSrv.Addr(), opt)
+	if err != nil {
+		log.Panic(err)
+	}
+	WeathClient = pb.NewWeatherServiceClient(conn)
+
+	// run tests
+	code := m.Run()
+	cancel()
+	_ = conn.Close()
+	closeAPIs()
+	os.Exit(code)
+}
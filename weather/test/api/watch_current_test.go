@@ -0,0 +1,48 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/proto/weath/v1alpha2"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/test/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWatchCurrentGRPCHandler(main *testing.T) {
+	main.Run("FirstReading", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := WeathClient.WatchCurrent(ctx, &pb.GetCurrentRequest{
+			Query: &pb.GetCurrentRequest_City{City: "Kyiv"},
+		})
+		require.NoError(t, err, "Expected stream to open")
+
+		resp, err := stream.Recv()
+		require.NoError(t, err, "Expected the current reading first")
+		require.NotNil(t, resp.GetWeather(), "Expected weather in the first reading")
+		require.Equal(t, int64(703448), resp.GetLocation().GetId(), "Expected city resolved to Kyiv")
+	})
+
+	main.Run("InvalidCity", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := WeathClient.WatchCurrent(ctx, &pb.GetCurrentRequest{
+			Query: &pb.GetCurrentRequest_City{City: mock.CityDoesNotExist},
+		})
+		require.NoError(t, err, "Expected stream to open")
+
+		_, err = stream.Recv()
+		require.Error(t, err, "Expected error for invalid city")
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Expected gRPC status error")
+		require.Equal(t, codes.NotFound, st.Code(), "Expected NotFound status code")
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"net/http"
 	"net/http/httptest"
+	"strings"
 )
 
 const CityDoesNotExist = "InvalidCity"
@@ -14,7 +15,7 @@ func NewFreeWeatherAPI() *httptest.Server {
 
 	handler.HandleFunc("/current.json", func(w http.ResponseWriter, r *http.Request) {
 		city := r.URL.Query().Get("q")
-		if city == CityDoesNotExist {
+		if strings.EqualFold(city, CityDoesNotExist) {
 			http.Error(w, `{"error": {"code": 1006, "message": "No matching location found."}}`,
 				http.StatusBadRequest)
 			return
@@ -22,6 +23,48 @@ func NewFreeWeatherAPI() *httptest.Server {
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
 		body := []byte(`{"current": {"temp_c": 20.0, "humidity": 80.0, "condition": {"text": "Sunny"}}}`)
+		if r.URL.Query().Get("aqi") == "yes" {
+			body = []byte(`{"current": {"air_quality": {"pm2_5": 12.3, "pm10": 20.1, "o3": 60.0, "no2": 15.0}}}`)
+		}
+		_, err := w.Write(body)
+		if err != nil {
+			log.Printf("free weather api: failed to write response body: %v", err)
+		}
+	})
+
+	handler.HandleFunc("/forecast.json", func(w http.ResponseWriter, r *http.Request) {
+		city := r.URL.Query().Get("q")
+		if strings.EqualFold(city, CityDoesNotExist) {
+			http.Error(w, `{"error": {"code": 1006, "message": "No matching location found."}}`,
+				http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		body := []byte(`{"forecast": {"forecastday": [{
+			"date_epoch": 1748736000,
+			"day": {"maxtemp_c": 25.0, "mintemp_c": 12.0, "avghumidity": 55.0, "condition": {"text": "Sunny"}},
+			"hour": [{"time_epoch": 1748736000, "temp_c": 13.0, "humidity": 70.0, "condition": {"text": "Clear"}}]
+		}]}}`)
+		_, err := w.Write(body)
+		if err != nil {
+			log.Printf("free weather api: failed to write response body: %v", err)
+		}
+	})
+
+	handler.HandleFunc("/alerts.json", func(w http.ResponseWriter, r *http.Request) {
+		city := r.URL.Query().Get("q")
+		if strings.EqualFold(city, CityDoesNotExist) {
+			http.Error(w, `{"error": {"code": 1006, "message": "No matching location found."}}`,
+				http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		body := []byte(`{"alerts": {"alert": [{
+			"headline": "Heat warning", "severity": "Moderate", "areas": "Kyiv", "event": "Heat",
+			"effective": "2025-06-01T09:00:00+03:00", "expires": ""
+		}]}}`)
 		_, err := w.Write(body)
 		if err != nil {
 			log.Printf("free weather api: failed to write response body: %v", err)
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+)
+
+// maxLatitude is the latitude past which the fake Open-Meteo answers like the real one does to bad coordinates.
+const maxLatitude = 90
+
+func NewOpenMeteoAPI() *httptest.Server {
+	handler := http.NewServeMux()
+
+	handler.HandleFunc("/forecast", func(w http.ResponseWriter, r *http.Request) {
+		lat, err := strconv.ParseFloat(r.URL.Query().Get("latitude"), 64)
+		if err != nil || lat > maxLatitude || lat < -maxLatitude {
+			http.Error(w, `{"error": true, "reason": "Latitude must be in range of -90 to 90°."}`,
+				http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		body := []byte(`{"current": {"time": 1748779200, "temperature_2m": 20.0, "relative_humidity_2m": 80.0, "weather_code": 0}}`)
+		if r.URL.Query().Has("daily") {
+			body = []byte(`{
+				"hourly": {"time": [1748736000], "temperature_2m": [13.0], "relative_humidity_2m": [70.0], "weather_code": [0]},
+				"daily": {"time": [1748736000], "temperature_2m_max": [25.0], "temperature_2m_min": [12.0],
+					"relative_humidity_2m_mean": [55.0], "weather_code": [0]}
+			}`)
+		}
+		_, err = w.Write(body)
+		if err != nil {
+			log.Printf("open meteo api: failed to write response body: %v", err)
+		}
+	})
+
+	httpServer := httptest.NewServer(handler)
+	return httpServer
+}
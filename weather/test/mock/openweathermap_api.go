@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+)
+
+func NewOpenWeatherMapAPI() *httptest.Server {
+	handler := http.NewServeMux()
+
+	handler.HandleFunc("/data/2.5/weather", func(w http.ResponseWriter, r *http.Request) {
+		if !validLatitude(r) {
+			http.Error(w, `{"cod": "400", "message": "wrong latitude"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		body := []byte(`{"dt": 1748779200, "weather": [{"description": "clear sky"}], "main": {"temp": 20.0, "humidity": 80.0}}`)
+		_, err := w.Write(body)
+		if err != nil {
+			log.Printf("open weather map api: failed to write response body: %v", err)
+		}
+	})
+
+	handler.HandleFunc("/data/2.5/forecast", func(w http.ResponseWriter, r *http.Request) {
+		if !validLatitude(r) {
+			http.Error(w, `{"cod": "400", "message": "wrong latitude"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		body := []byte(`{"list": [
+			{"dt": 1748779200, "main": {"temp": 24.0, "temp_min": 12.0, "temp_max": 25.0, "humidity": 55.0},
+				"weather": [{"description": "clear sky"}]}
+		]}`)
+		_, err := w.Write(body)
+		if err != nil {
+			log.Printf("open weather map api: failed to write response body: %v", err)
+		}
+	})
+
+	handler.HandleFunc("/data/2.5/air_pollution", func(w http.ResponseWriter, r *http.Request) {
+		if !validLatitude(r) {
+			http.Error(w, `{"cod": "400", "message": "wrong latitude"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		body := []byte(`{"list": [
+			{"dt": 1748779200, "main": {"aqi": 2}, "components": {"pm2_5": 12.3, "pm10": 20.1, "o3": 60.0, "no2": 15.0}}
+		]}`)
+		_, err := w.Write(body)
+		if err != nil {
+			log.Printf("open weather map api: failed to write response body: %v", err)
+		}
+	})
+
+	httpServer := httptest.NewServer(handler)
+	return httpServer
+}
+
+func validLatitude(r *http.Request) bool {
+	lat, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	return err == nil && lat <= maxLatitude && lat >= -maxLatitude
+}
@@ -5,6 +5,7 @@ import (
 
 	"net/http"
 	"net/http/httptest"
+	"strings"
 )
 
 func NewTomorrowAPI() *httptest.Server {
@@ -12,7 +13,7 @@ func NewTomorrowAPI() *httptest.Server {
 
 	handler.HandleFunc("/weather/realtime", func(w http.ResponseWriter, r *http.Request) {
 		city := r.URL.Query().Get("location")
-		if city == CityDoesNotExist {
+		if strings.EqualFold(city, CityDoesNotExist) {
 			errBody := `{"error": {"code": 400001, "message": "Not found.", "type": "error"}}`
 			http.Error(w, errBody, http.StatusBadRequest)
 			return
@@ -35,6 +36,28 @@ func NewTomorrowAPI() *httptest.Server {
 		}
 	})
 
+	handler.HandleFunc("/weather/forecast", func(w http.ResponseWriter, r *http.Request) {
+		city := r.URL.Query().Get("location")
+		if strings.EqualFold(city, CityDoesNotExist) {
+			errBody := `{"error": {"code": 400001, "message": "Not found.", "type": "error"}}`
+			http.Error(w, errBody, http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		body := `{
+			"timelines": {
+				"hourly": [{"time": "2025-06-01T00:00:00Z", "values": {"temperature": 13.0, "humidity": 70.0, "cloudCover": 10}}],
+				"daily": [{"time": "2025-06-01T00:00:00Z",
+					"values": {"temperatureMin": 12.0, "temperatureMax": 25.0, "humidityAvg": 55.0, "cloudCoverAvg": 15}}]
+			}
+		}`
+		_, err := w.Write([]byte(body))
+		if err != nil {
+			log.Printf("tomorrow api: failed to write response body: %v", err)
+		}
+	})
+
 	httpServer := httptest.NewServer(handler)
 	return httpServer
 }
@@ -3,6 +3,7 @@ package mock
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,7 +14,7 @@ func NewVisualCrossingAPI() *httptest.Server {
 	handler.GET("/:city/today", func(c *gin.Context) {
 		city := c.Param("city")
 
-		if city == CityDoesNotExist {
+		if strings.EqualFold(city, CityDoesNotExist) {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "city not found",
 			})
@@ -31,6 +32,32 @@ func NewVisualCrossingAPI() *httptest.Server {
 		})
 	})
 
+	handler.GET("/:city/:period", func(c *gin.Context) {
+		city := c.Param("city")
+		if strings.EqualFold(city, CityDoesNotExist) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "city not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"days": []gin.H{{
+				"datetimeEpoch": 1748736000,
+				"tempmax":       25.0,
+				"tempmin":       12.0,
+				"humidity":      55.0,
+				"conditions":    "Partly cloudy",
+				"hours": []gin.H{{
+					"datetimeEpoch": 1748736000,
+					"temp":          13.0,
+					"humidity":      70.0,
+					"conditions":    "Clear",
+				}},
+			}},
+		})
+	})
+
 	httpServer := httptest.NewServer(handler)
 	return httpServer
 }
@@ -0,0 +1,61 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/pkg/cache"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/config"
+	"github.com/GenesisEducationKyiv/software-engineering-school-5-0-velosypedno/weather/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tierMetrics struct{}
+
+func (m *tierMetrics) CacheTierHit(tier string)  {}
+func (m *tierMetrics) CacheTierMiss(tier string) {}
+
+func TestTieredCache_InvalidatesAcrossReplicas(t *testing.T) {
+	// Arrange
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr(),
+		Password: cfg.Redis.Pass,
+	})
+	require.NoError(t, redisClient.FlushDB(context.Background()).Err())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newReplica := func() (*cache.Tiered[domain.Weather], *cache.LRU[domain.Weather]) {
+		invalidator, err := cache.NewRedisInvalidator(redisClient, "test:cache:invalidate")
+		require.NoError(t, err)
+		go invalidator.Listen(ctx)
+		local := cache.NewLRU[domain.Weather](10, time.Minute)
+		remote := cache.NewRedisCacheClient[domain.Weather](redisClient, time.Minute)
+		return cache.NewTiered(local, remote, invalidator, &tierMetrics{}), local
+	}
+	first, _ := newReplica()
+	second, secondLocal := newReplica()
+	// give both subscriptions time to be set up
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, first.Set(ctx, "kyiv", domain.Weather{Temperature: 20}))
+	var weather domain.Weather
+	require.NoError(t, second.Get(ctx, "kyiv", &weather))
+	require.Equal(t, 1, secondLocal.Len())
+
+	// Act
+	require.NoError(t, first.Set(ctx, "kyiv", domain.Weather{Temperature: 25}))
+
+	// Assert
+	assert.Eventually(t, func() bool { return secondLocal.Len() == 0 }, time.Second, 10*time.Millisecond,
+		"second replica should drop its local copy")
+	require.NoError(t, second.Get(ctx, "kyiv", &weather))
+	assert.Equal(t, 25.0, weather.Temperature)
+}
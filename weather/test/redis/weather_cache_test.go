@@ -4,6 +4,8 @@ package redis_test
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,6 +27,8 @@ type weathMetrics struct {
 func (m *weathMetrics) CacheHit()                           { m.CacheHitCalled = true }
 func (m *weathMetrics) CacheMiss()                          { m.CacheMissCalled = true }
 func (m *weathMetrics) CacheAccessLatency(duration float64) { m.CacheAccessLatencyCalled = true }
+func (m *weathMetrics) CacheLoad(role string)               {}
+func (m *weathMetrics) CacheStale(reason string)            {}
 
 type weatherRepo struct {
 	called  bool
@@ -39,16 +43,82 @@ func (r *weatherRepo) Clear() {
 	r.called = false
 }
 
-func (r *weatherRepo) GetCurrent(ctx context.Context, city string) (domain.Weather, error) {
+func (r *weatherRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
 	r.called = true
 	return r.weather, nil
 }
 
+func (r *weatherRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	r.called = true
+	return domain.Forecast{}, nil
+}
+
+func (r *weatherRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	r.called = true
+	return domain.Alerts{}, nil
+}
+
+func (r *weatherRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	r.called = true
+	return domain.AirQuality{}, nil
+}
+
 type mocks struct {
 	repo      *weatherRepo
 	weather   domain.Weather
-	cacheBack *cache.RedisCacheClient[domain.Weather]
+	cacheBack *cache.RedisCacheClient[cache.Entry[domain.Weather]]
+	forecast  *cache.RedisCacheClient[cache.Entry[domain.Forecast]]
+	alerts    *cache.RedisCacheClient[cache.Entry[domain.Alerts]]
+	air       *cache.RedisCacheClient[cache.Entry[domain.AirQuality]]
 	metrics   *weathMetrics
+	locker    *cache.RedisLocker
+}
+
+type slowWeatherRepo struct {
+	calls   atomic.Int32
+	delay   time.Duration
+	weather domain.Weather
+}
+
+func (r *slowWeatherRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	r.calls.Add(1)
+	time.Sleep(r.delay)
+	return r.weather, nil
+}
+
+func (r *slowWeatherRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	r.calls.Add(1)
+	return domain.Forecast{}, nil
+}
+
+func (r *slowWeatherRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	r.calls.Add(1)
+	return domain.Alerts{}, nil
+}
+
+func (r *slowWeatherRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	r.calls.Add(1)
+	return domain.AirQuality{}, nil
+}
+
+type failingWeatherRepo struct {
+	err error
+}
+
+func (r *failingWeatherRepo) GetCurrent(ctx context.Context, loc domain.Location) (domain.Weather, error) {
+	return domain.Weather{}, r.err
+}
+
+func (r *failingWeatherRepo) GetForecast(ctx context.Context, loc domain.Location, days int) (domain.Forecast, error) {
+	return domain.Forecast{}, r.err
+}
+
+func (r *failingWeatherRepo) GetAlerts(ctx context.Context, loc domain.Location) (domain.Alerts, error) {
+	return domain.Alerts{}, r.err
+}
+
+func (r *failingWeatherRepo) GetAirQuality(ctx context.Context, loc domain.Location) (domain.AirQuality, error) {
+	return domain.AirQuality{}, r.err
 }
 
 func TestCacheWeatherDecorator(main *testing.T) {
@@ -58,7 +128,19 @@ func TestCacheWeatherDecorator(main *testing.T) {
 		Addr:     cfg.Redis.Addr(),
 		Password: cfg.Redis.Pass,
 	})
-	cacheBackend := cache.NewRedisCacheClient[domain.Weather](redisClient, time.Duration(0))
+	cacheBackend := cache.NewRedisCacheClient[cache.Entry[domain.Weather]](redisClient, time.Duration(0))
+	forecastBackend := cache.NewRedisCacheClient[cache.Entry[domain.Forecast]](redisClient, time.Duration(0))
+	alertsBackend := cache.NewRedisCacheClient[cache.Entry[domain.Alerts]](redisClient, time.Duration(0))
+	airBackend := cache.NewRedisCacheClient[cache.Entry[domain.AirQuality]](redisClient, time.Duration(0))
+	cacheCfg := decorator.CacheConfig{
+		Current:          decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+		Forecast:         decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+		Alerts:           decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+		Air:              decorator.CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+		BatchParallelism: 1,
+		RefreshTimeout:   time.Second,
+	}
+	locker := cache.NewRedisLocker(redisClient, 5*time.Second)
 	temp := 20.0
 	humidity := 50.0
 	mockWeather := domain.Weather{Temperature: temp, Humidity: humidity, Description: "Sunny"}
@@ -68,18 +150,29 @@ func TestCacheWeatherDecorator(main *testing.T) {
 		err = redisClient.FlushDB(context.Background()).Err()
 		require.NoError(main, err)
 		repo.Clear()
-		return &mocks{repo: repo, weather: mockWeather, cacheBack: cacheBackend, metrics: &weathMetrics{}}
+		return &mocks{
+			repo:      repo,
+			weather:   mockWeather,
+			cacheBack: cacheBackend,
+			forecast:  forecastBackend,
+			alerts:    alertsBackend,
+			air:       airBackend,
+			metrics:   &weathMetrics{},
+			locker:    locker,
+		}
 	}
 
 	main.Run("CacheMiss", func(t *testing.T) {
 		// Arrange
 		mocks := setup()
 		require.False(t, mocks.repo.called)
-		decoratedRepo := decorator.NewCacheDecorator(mocks.repo, mocks.cacheBack, mocks.metrics)
-		city := "Kyiv"
+		decoratedRepo := decorator.NewCacheDecorator(
+			mocks.repo, mocks.cacheBack, mocks.forecast, mocks.alerts, mocks.air, mocks.metrics, mocks.locker, cacheCfg,
+		)
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
 
 		// Acr
-		weather, err := decoratedRepo.GetCurrent(context.Background(), city)
+		weather, err := decoratedRepo.GetCurrent(context.Background(), kyiv)
 
 		// Assert
 		assert.True(t, mocks.metrics.CacheMissCalled, "Cache miss should be called")
@@ -93,12 +186,14 @@ func TestCacheWeatherDecorator(main *testing.T) {
 		// Arrange
 		mocks := setup()
 		require.False(t, mocks.repo.called)
-		city := "Kyiv"
-		mocks.cacheBack.Set(context.Background(), city, mocks.weather)
-		decoratedRepo := decorator.NewCacheDecorator(mocks.repo, mocks.cacheBack, mocks.metrics)
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+		mocks.cacheBack.Set(context.Background(), kyiv.Key(), cache.NewEntry(mocks.weather, time.Now(), time.Minute, time.Hour))
+		decoratedRepo := decorator.NewCacheDecorator(
+			mocks.repo, mocks.cacheBack, mocks.forecast, mocks.alerts, mocks.air, mocks.metrics, mocks.locker, cacheCfg,
+		)
 
 		// Act
-		weather, err := decoratedRepo.GetCurrent(context.Background(), "Kyiv")
+		weather, err := decoratedRepo.GetCurrent(context.Background(), kyiv)
 
 		// Assert
 		assert.False(t, mocks.metrics.CacheMissCalled, "Cache miss should not be called")
@@ -106,22 +201,24 @@ func TestCacheWeatherDecorator(main *testing.T) {
 		assert.True(t, mocks.metrics.CacheAccessLatencyCalled, "Cache access latency should be called")
 		require.NoError(t, err, "Failed to get weather: %v", err)
 		require.False(t, repo.called, "Repo GetCurrent method should not be called")
-		assert.Equal(t, mocks.weather, weather, "Expected weather %v, got %v", mocks.weather, weather)
+		assert.Equal(t, mocks.weather, weather.Aged(0, false), "Expected weather %v, got %v", mocks.weather, weather)
 	})
 
 	main.Run("CacheExpired", func(t *testing.T) {
 		// Arrange
 		mocks := setup()
 		ttl := 1 * time.Millisecond
-		cacheBackend := cache.NewRedisCacheClient[domain.Weather](redisClient, ttl)
+		cacheBackend := cache.NewRedisCacheClient[cache.Entry[domain.Weather]](redisClient, ttl)
 		require.False(t, mocks.repo.called)
-		city := "Kyiv"
-		cacheBackend.Set(context.Background(), city, mocks.weather)
-		decoratedRepo := decorator.NewCacheDecorator(mocks.repo, cacheBackend, mocks.metrics)
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+		cacheBackend.Set(context.Background(), kyiv.Key(), cache.NewEntry(mocks.weather, time.Now(), time.Minute, time.Hour))
+		decoratedRepo := decorator.NewCacheDecorator(
+			mocks.repo, cacheBackend, mocks.forecast, mocks.alerts, mocks.air, mocks.metrics, mocks.locker, cacheCfg,
+		)
 
 		// Act
 		<-time.After(ttl * 2)
-		weather, err := decoratedRepo.GetCurrent(context.Background(), "Kyiv")
+		weather, err := decoratedRepo.GetCurrent(context.Background(), kyiv)
 
 		// Assert
 		assert.True(t, mocks.metrics.CacheMissCalled, "Cache miss should be called")
@@ -130,4 +227,125 @@ func TestCacheWeatherDecorator(main *testing.T) {
 		require.True(t, repo.called, "Repo GetCurrent method should be called")
 		require.Equal(t, mockWeather, weather, "Expected weather %v, got %v", mockWeather, weather)
 	})
+
+	main.Run("Batch", func(t *testing.T) {
+		// Arrange
+		mocks := setup()
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+		lviv := domain.Location{ID: 702550, Name: "Lviv", Country: "UA", Lat: 49.83826, Lon: 24.02324}
+		cached := domain.Weather{Temperature: 1, Humidity: 2, Description: "Cached"}
+		mocks.cacheBack.Set(context.Background(), kyiv.Key(), cache.NewEntry(cached, time.Now(), time.Minute, time.Hour))
+		batchCfg := cacheCfg
+		batchCfg.BatchParallelism = 2
+		decoratedRepo := decorator.NewCacheDecorator(
+			mocks.repo, mocks.cacheBack, mocks.forecast, mocks.alerts, mocks.air, mocks.metrics, mocks.locker, batchCfg,
+		)
+
+		// Act
+		results := decoratedRepo.GetCurrentBatch(context.Background(), []domain.Location{kyiv, lviv})
+
+		// Assert
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		require.NoError(t, results[1].Err)
+		assert.Equal(t, cached, results[0].Weather.Aged(0, false), "Expected cached weather for hit")
+		assert.Equal(t, mocks.weather, results[1].Weather, "Expected repo weather for miss")
+		assert.True(t, mocks.metrics.CacheHitCalled, "Cache hit should be called")
+		assert.True(t, mocks.metrics.CacheMissCalled, "Cache miss should be called")
+		require.True(t, repo.called, "Repo GetCurrent method should be called for the miss")
+
+		var stored cache.Entry[domain.Weather]
+		require.NoError(t, mocks.cacheBack.Get(context.Background(), lviv.Key(), &stored), "Miss should be cached")
+		assert.Equal(t, mocks.weather, stored.Value)
+	})
+
+	main.Run("CoalescesMissesAcrossReplicas", func(t *testing.T) {
+		// Arrange
+		mocks := setup()
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+		slowRepo := &slowWeatherRepo{delay: 200 * time.Millisecond, weather: mocks.weather}
+		replicas := []*decorator.CacheDecorator{
+			decorator.NewCacheDecorator(slowRepo, mocks.cacheBack, mocks.forecast, mocks.alerts, mocks.air, &weathMetrics{}, mocks.locker, cacheCfg),
+			decorator.NewCacheDecorator(slowRepo, mocks.cacheBack, mocks.forecast, mocks.alerts, mocks.air, &weathMetrics{}, mocks.locker, cacheCfg),
+		}
+		const requestsPerReplica = 5
+		var wg sync.WaitGroup
+		results := make(chan domain.Weather, len(replicas)*requestsPerReplica)
+		errs := make(chan error, len(replicas)*requestsPerReplica)
+
+		// Act
+		for _, replica := range replicas {
+			for range requestsPerReplica {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					weather, err := replica.GetCurrent(context.Background(), kyiv)
+					results <- weather
+					errs <- err
+				}()
+			}
+		}
+		wg.Wait()
+		close(results)
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		for weather := range results {
+			assert.Equal(t, mocks.weather, weather)
+		}
+		assert.Equal(t, int32(1), slowRepo.calls.Load(), "Expected a single upstream call for all replicas")
+	})
+
+	main.Run("ServesStaleOnError", func(t *testing.T) {
+		// Arrange
+		mocks := setup()
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+		storedAt := time.Now().Add(-time.Hour)
+		mocks.cacheBack.Set(context.Background(), kyiv.Key(), cache.NewEntry(mocks.weather, storedAt, time.Minute, 10*time.Minute))
+		failingRepo := &failingWeatherRepo{err: domain.ErrWeatherUnavailable}
+		decoratedRepo := decorator.NewCacheDecorator(
+			failingRepo, mocks.cacheBack, mocks.forecast, mocks.alerts, mocks.air, mocks.metrics, mocks.locker, cacheCfg,
+		)
+
+		// Act
+		weather, err := decoratedRepo.GetCurrent(context.Background(), kyiv)
+
+		// Assert
+		require.NoError(t, err, "Expected the stale value instead of an error")
+		assert.True(t, weather.Stale)
+		assert.InDelta(t, time.Hour, weather.Age, float64(time.Second))
+		assert.Equal(t, mocks.weather, weather.Aged(0, false))
+	})
+
+	main.Run("NegativeEntriesAndPurge", func(t *testing.T) {
+		// Arrange
+		mocks := setup()
+		namespaced := cache.NewRedisCacheClient[cache.Entry[domain.Weather]](redisClient, time.Minute,
+			cache.WithNamespace("test:current", 1), cache.WithNegativeTTL(time.Minute))
+		atlantis := domain.Location{Name: "Atlantis", Lat: 31, Lon: -24}
+		kyiv := domain.Location{ID: 703448, Name: "Kyiv", Country: "UA", Lat: 50.45466, Lon: 30.5238}
+		failingRepo := &failingWeatherRepo{err: domain.ErrCityNotFound}
+		decoratedRepo := decorator.NewCacheDecorator(
+			failingRepo, namespaced, mocks.forecast, mocks.alerts, mocks.air, mocks.metrics, mocks.locker, cacheCfg,
+		)
+		require.NoError(t, namespaced.Set(context.Background(), kyiv.Key(), cache.NewEntry(mocks.weather, time.Now(), time.Minute, time.Hour)))
+
+		// Act
+		_, firstErr := decoratedRepo.GetCurrent(context.Background(), atlantis)
+		var entry cache.Entry[domain.Weather]
+		negativeErr := namespaced.Get(context.Background(), atlantis.Key(), &entry)
+		purged, purgeErr := decoratedRepo.PurgeAll(context.Background())
+
+		// Assert
+		require.ErrorIs(t, firstErr, domain.ErrCityNotFound)
+		require.ErrorIs(t, negativeErr, cache.ErrNegative)
+		require.NoError(t, purgeErr)
+		assert.Equal(t, 2, purged)
+		keys, err := redisClient.Keys(context.Background(), "test:current:v1:*").Result()
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
 }